@@ -0,0 +1,136 @@
+package adapterprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// ResolveFunc resolves a flag of any type, as legacy providers implementing a single generic resolution method do.
+// Errors which are an openfeature.ResolutionError keep their error code, other errors are reported as GENERAL errors.
+type ResolveFunc func(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) (interface{}, error)
+
+// ProviderAdapter is a FeatureProvider adapting a ResolveFunc, e.g. of a minimal downstream provider, to the typed
+// resolutions of the FeatureProvider interface. Resolved values not matching the requested flag type fail with a
+// TYPE_MISMATCH error; integer flags accept any Go integer type and float flags any Go float type.
+//
+// The reason of successful resolutions is UNKNOWN, as a ResolveFunc does not report it.
+type ProviderAdapter struct {
+	name    string
+	resolve ResolveFunc
+}
+
+// NewProviderAdapter creates a ProviderAdapter named name, resolving flags with resolve
+func NewProviderAdapter(name string, resolve ResolveFunc) *ProviderAdapter {
+	return &ProviderAdapter{
+		name:    name,
+		resolve: resolve,
+	}
+}
+
+// Metadata returns the ProviderAdapter's metadata, named after the adapted provider
+func (p *ProviderAdapter) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: p.name}
+}
+
+func (p *ProviderAdapter) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	value, detail := typed(p.adapt(ctx, flag, defaultValue, evalCtx), defaultValue, func(value interface{}) (bool, bool) {
+		v, ok := value.(bool)
+		return v, ok
+	})
+	return openfeature.BoolResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (p *ProviderAdapter) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	value, detail := typed(p.adapt(ctx, flag, defaultValue, evalCtx), defaultValue, func(value interface{}) (string, bool) {
+		v, ok := value.(string)
+		return v, ok
+	})
+	return openfeature.StringResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (p *ProviderAdapter) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	value, detail := typed(p.adapt(ctx, flag, defaultValue, evalCtx), defaultValue, func(value interface{}) (float64, bool) {
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case float32:
+			return float64(v), true
+		}
+		return 0, false
+	})
+	return openfeature.FloatResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (p *ProviderAdapter) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	value, detail := typed(p.adapt(ctx, flag, defaultValue, evalCtx), defaultValue, func(value interface{}) (int64, bool) {
+		switch v := value.(type) {
+		case int64:
+			return v, true
+		case int:
+			return int64(v), true
+		case int32:
+			return int64(v), true
+		case int16:
+			return int64(v), true
+		case int8:
+			return int64(v), true
+		}
+		return 0, false
+	})
+	return openfeature.IntResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (p *ProviderAdapter) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return p.adapt(ctx, flag, defaultValue, evalCtx)
+}
+
+// Hooks returns no hooks
+func (p *ProviderAdapter) Hooks() []openfeature.Hook {
+	return []openfeature.Hook{}
+}
+
+// adapt resolves the flag with the ResolveFunc, converting its error to a resolution error
+func (p *ProviderAdapter) adapt(
+	ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext,
+) openfeature.InterfaceResolutionDetail {
+	value, err := p.resolve(ctx, flag, defaultValue, evalCtx)
+	if err != nil {
+		var resolutionErr openfeature.ResolutionError
+		if !errors.As(err, &resolutionErr) {
+			resolutionErr = openfeature.NewGeneralResolutionError(err.Error())
+		}
+		return errorResolution(defaultValue, resolutionErr)
+	}
+	return openfeature.InterfaceResolutionDetail{
+		Value:                    value,
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{Reason: openfeature.UnknownReason},
+	}
+}
+
+// typed converts the adapted resolution to the flag's Go type, failing with a TYPE_MISMATCH error if the resolved
+// value cannot be converted
+func typed[T any](
+	resolution openfeature.InterfaceResolutionDetail, defaultValue T, convert func(interface{}) (T, bool),
+) (T, openfeature.ProviderResolutionDetail) {
+	if resolution.Error() != nil {
+		return defaultValue, resolution.ProviderResolutionDetail
+	}
+	if value, ok := convert(resolution.Value); ok {
+		return value, resolution.ProviderResolutionDetail
+	}
+	return defaultValue, errorResolution(defaultValue, openfeature.NewTypeMismatchResolutionError(
+		fmt.Sprintf("resolved value %v is not of type %T", resolution.Value, defaultValue))).ProviderResolutionDetail
+}
+
+func errorResolution(defaultValue interface{}, err openfeature.ResolutionError) openfeature.InterfaceResolutionDetail {
+	return openfeature.InterfaceResolutionDetail{
+		Value: defaultValue,
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+			ResolutionError: err,
+			Reason:          openfeature.ErrorReason,
+		},
+	}
+}
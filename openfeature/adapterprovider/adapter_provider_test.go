@@ -0,0 +1,92 @@
+package adapterprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// legacyProvider resolves the flags from a static map, failing for unknown flags
+func legacyProvider(flags map[string]interface{}) *ProviderAdapter {
+	return NewProviderAdapter("legacy", func(_ context.Context, flag string, _ interface{}, _ openfeature.FlattenedContext) (interface{}, error) {
+		value, ok := flags[flag]
+		if !ok {
+			return nil, openfeature.NewFlagNotFoundResolutionError("flag " + flag + " not found")
+		}
+		return value, nil
+	})
+}
+
+func TestProviderAdapter(t *testing.T) {
+	ctx, evalCtx := context.Background(), openfeature.FlattenedContext{}
+	adapter := legacyProvider(map[string]interface{}{
+		"bool":   true,
+		"string": "on",
+		"float":  float32(0.5),
+		"int":    3,
+		"object": map[string]interface{}{"color": "blue"},
+	})
+
+	t.Run("boolean", func(t *testing.T) {
+		resolution := adapter.BooleanEvaluation(ctx, "bool", false, evalCtx)
+		if resolution.Error() != nil || resolution.Value != true {
+			t.Errorf("expected true, got %+v", resolution)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		resolution := adapter.StringEvaluation(ctx, "string", "off", evalCtx)
+		if resolution.Error() != nil || resolution.Value != "on" {
+			t.Errorf("expected on, got %+v", resolution)
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		resolution := adapter.FloatEvaluation(ctx, "float", 0, evalCtx)
+		if resolution.Error() != nil || resolution.Value != 0.5 {
+			t.Errorf("expected 0.5, got %+v", resolution)
+		}
+	})
+
+	t.Run("int", func(t *testing.T) {
+		resolution := adapter.IntEvaluation(ctx, "int", 0, evalCtx)
+		if resolution.Error() != nil || resolution.Value != 3 {
+			t.Errorf("expected 3, got %+v", resolution)
+		}
+	})
+
+	t.Run("object", func(t *testing.T) {
+		resolution := adapter.ObjectEvaluation(ctx, "object", nil, evalCtx)
+		if resolution.Error() != nil || !reflect.DeepEqual(resolution.Value, map[string]interface{}{"color": "blue"}) {
+			t.Errorf("expected the object, got %+v", resolution)
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		resolution := adapter.IntEvaluation(ctx, "string", 7, evalCtx)
+		if resolution.ResolutionDetail().ErrorCode != openfeature.TypeMismatchCode || resolution.Value != 7 {
+			t.Errorf("expected the default value with a TYPE_MISMATCH error, got %+v", resolution)
+		}
+	})
+
+	t.Run("resolution errors keep their code", func(t *testing.T) {
+		resolution := adapter.BooleanEvaluation(ctx, "missing", true, evalCtx)
+		if resolution.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode || resolution.Value != true {
+			t.Errorf("expected the default value with a FLAG_NOT_FOUND error, got %+v", resolution)
+		}
+	})
+
+	t.Run("other errors are general errors", func(t *testing.T) {
+		failing := NewProviderAdapter("failing", func(context.Context, string, interface{}, openfeature.FlattenedContext) (interface{}, error) {
+			return nil, errors.New("backend unavailable")
+		})
+
+		resolution := failing.StringEvaluation(ctx, "string", "off", evalCtx)
+		if resolution.ResolutionDetail().ErrorCode != openfeature.GeneralCode || resolution.Value != "off" {
+			t.Errorf("expected the default value with a GENERAL error, got %+v", resolution)
+		}
+	})
+}
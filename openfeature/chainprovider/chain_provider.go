@@ -0,0 +1,208 @@
+package chainprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// ChainProvider is a FeatureProvider resolving flags with an ordered chain of providers. Every flag is resolved by
+// the first provider of the chain not failing its resolution, falling back to the resolution of the last provider
+// if all of them fail.
+//
+// The ChainProvider initializes and shuts down all of its providers, and multiplexes their events. Hooks of the
+// chained providers are not run.
+type ChainProvider struct {
+	providers []openfeature.FeatureProvider
+	events    chan openfeature.Event
+
+	mu       sync.Mutex
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// interface guards to ensure that ChainProvider initializes its providers and multiplexes their events
+var (
+	_ openfeature.StateHandler = (*ChainProvider)(nil)
+	_ openfeature.EventHandler = (*ChainProvider)(nil)
+)
+
+// NewChainProvider creates a ChainProvider resolving flags with the given providers, in order
+func NewChainProvider(providers ...openfeature.FeatureProvider) *ChainProvider {
+	return &ChainProvider{
+		providers: providers,
+		events:    make(chan openfeature.Event, 5),
+	}
+}
+
+// Metadata returns the ChainProvider's metadata, naming all of the chained providers
+func (c *ChainProvider) Metadata() openfeature.Metadata {
+	names := make([]string, len(c.providers))
+	for i, provider := range c.providers {
+		names[i] = provider.Metadata().Name
+	}
+	return openfeature.Metadata{
+		Name: fmt.Sprintf("ChainProvider(%s)", strings.Join(names, ", ")),
+	}
+}
+
+func (c *ChainProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	return resolve(c.providers,
+		openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: emptyChainDetail()},
+		func(provider openfeature.FeatureProvider) openfeature.BoolResolutionDetail {
+			return provider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.BoolResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (c *ChainProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	return resolve(c.providers,
+		openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: emptyChainDetail()},
+		func(provider openfeature.FeatureProvider) openfeature.StringResolutionDetail {
+			return provider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.StringResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (c *ChainProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	return resolve(c.providers,
+		openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: emptyChainDetail()},
+		func(provider openfeature.FeatureProvider) openfeature.FloatResolutionDetail {
+			return provider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.FloatResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (c *ChainProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	return resolve(c.providers,
+		openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: emptyChainDetail()},
+		func(provider openfeature.FeatureProvider) openfeature.IntResolutionDetail {
+			return provider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.IntResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (c *ChainProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return resolve(c.providers,
+		openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: emptyChainDetail()},
+		func(provider openfeature.FeatureProvider) openfeature.InterfaceResolutionDetail {
+			return provider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.InterfaceResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+// Hooks returns no hooks, the hooks of the chained providers are not run
+func (c *ChainProvider) Hooks() []openfeature.Hook {
+	return []openfeature.Hook{}
+}
+
+// Init initializes all chained providers implementing openfeature.StateHandler, returning their joined errors, and
+// starts multiplexing the events of the chained providers implementing openfeature.EventHandler
+func (c *ChainProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shutdown == nil {
+		c.shutdown = make(chan struct{})
+		for _, provider := range c.providers {
+			if handler, ok := provider.(openfeature.EventHandler); ok {
+				c.wg.Add(1)
+				go c.forward(handler.EventChannel(), c.shutdown)
+			}
+		}
+	}
+
+	var errs []error
+	for _, provider := range c.providers {
+		if handler, ok := provider.(openfeature.StateHandler); ok {
+			if err := handler.Init(evaluationContext); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", provider.Metadata().Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down all chained providers implementing openfeature.StateHandler and stops multiplexing their events
+func (c *ChainProvider) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shutdown != nil {
+		close(c.shutdown)
+		c.wg.Wait()
+		c.shutdown = nil
+	}
+
+	for _, provider := range c.providers {
+		if handler, ok := provider.(openfeature.StateHandler); ok {
+			handler.Shutdown()
+		}
+	}
+}
+
+// EventChannel returns the channel the events of all chained providers are multiplexed to
+func (c *ChainProvider) EventChannel() <-chan openfeature.Event {
+	return c.events
+}
+
+func (c *ChainProvider) forward(events <-chan openfeature.Event, shutdown <-chan struct{}) {
+	defer c.wg.Done()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			select {
+			case c.events <- event:
+			case <-shutdown:
+				return
+			}
+		case <-shutdown:
+			return
+		}
+	}
+}
+
+// resolve returns the first resolution of the providers which did not fail, otherwise the last failed resolution
+func resolve[T any](
+	providers []openfeature.FeatureProvider, empty T, evaluate func(openfeature.FeatureProvider) T,
+	detail func(T) openfeature.ProviderResolutionDetail,
+) T {
+	result := empty
+	for _, provider := range providers {
+		result = evaluate(provider)
+		resolution := detail(result)
+		if resolution.Error() == nil && resolution.Reason != openfeature.ErrorReason {
+			return result
+		}
+	}
+	return result
+}
+
+func emptyChainDetail() openfeature.ProviderResolutionDetail {
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewGeneralResolutionError("chain has no providers"),
+		Reason:          openfeature.ErrorReason,
+	}
+}
@@ -0,0 +1,150 @@
+package chainprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+// statefulProvider is an InMemoryProvider recording its initialization & shutdown, and emitting events
+type statefulProvider struct {
+	memprovider.InMemoryProvider
+	initErr  error
+	inits    *int
+	shutdown *bool
+	events   chan openfeature.Event
+}
+
+func newStatefulProvider(initErr error) statefulProvider {
+	return statefulProvider{
+		InMemoryProvider: memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{}),
+		initErr:          initErr,
+		inits:            new(int),
+		shutdown:         new(bool),
+		events:           make(chan openfeature.Event, 1),
+	}
+}
+
+func (p statefulProvider) Init(openfeature.EvaluationContext) error {
+	*p.inits++
+	return p.initErr
+}
+
+func (p statefulProvider) Shutdown() {
+	*p.shutdown = true
+}
+
+func (p statefulProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+func TestChainProvider_Evaluation(t *testing.T) {
+	primary := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{})
+	secondary := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"boolFlag": {
+			Key:            "boolFlag",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants:       map[string]interface{}{"on": true, "off": false},
+		},
+	})
+
+	t.Run("a failing primary falls back to the secondary", func(t *testing.T) {
+		chain := NewChainProvider(primary, secondary)
+
+		resolution := chain.BooleanEvaluation(context.Background(), "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.Error() != nil {
+			t.Fatalf("unexpected error: %v", resolution.Error())
+		}
+		if resolution.Value != true || resolution.Variant != "on" {
+			t.Errorf("expected the secondary's resolution, got %+v", resolution)
+		}
+	})
+
+	t.Run("the last error is returned if all providers fail", func(t *testing.T) {
+		chain := NewChainProvider(secondary, primary)
+
+		resolution := chain.StringEvaluation(context.Background(), "boolFlag", "default", openfeature.FlattenedContext{})
+		if resolution.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode {
+			t.Errorf("expected the primary's FLAG_NOT_FOUND error, got %+v", resolution)
+		}
+		if resolution.Value != "default" {
+			t.Errorf("expected the default value, got %s", resolution.Value)
+		}
+	})
+
+	t.Run("an empty chain returns an error", func(t *testing.T) {
+		resolution := NewChainProvider().IntEvaluation(context.Background(), "intFlag", 1, openfeature.FlattenedContext{})
+		if resolution.Error() == nil || resolution.Value != 1 {
+			t.Errorf("expected the default value with an error, got %+v", resolution)
+		}
+	})
+
+	t.Run("metadata names the chained providers", func(t *testing.T) {
+		name := NewChainProvider(primary, secondary).Metadata().Name
+		if name != "ChainProvider(InMemoryProvider, InMemoryProvider)" {
+			t.Errorf("unexpected name %s", name)
+		}
+	})
+}
+
+func TestChainProvider_StateHandling(t *testing.T) {
+	initErr := errors.New("init failed")
+	first, second := newStatefulProvider(nil), newStatefulProvider(initErr)
+	chain := NewChainProvider(first, second)
+
+	err := chain.Init(openfeature.EvaluationContext{})
+	if !errors.Is(err, initErr) {
+		t.Errorf("expected the init error of the second provider, got %v", err)
+	}
+	if *first.inits != 1 || *second.inits != 1 {
+		t.Errorf("expected all providers to be initialized once, got %d & %d", *first.inits, *second.inits)
+	}
+
+	for _, provider := range []statefulProvider{first, second} {
+		provider.events <- openfeature.Event{EventType: openfeature.ProviderConfigChange}
+		select {
+		case event := <-chain.EventChannel():
+			if event.EventType != openfeature.ProviderConfigChange {
+				t.Errorf("unexpected event %v", event)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the event to be multiplexed")
+		}
+	}
+
+	chain.Shutdown()
+	if !*first.shutdown || !*second.shutdown {
+		t.Error("expected all providers to be shut down")
+	}
+}
+
+func TestChainProvider_WithClient(t *testing.T) {
+	primary := newStatefulProvider(nil)
+	secondary := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"stringFlag": {
+			Key:            "stringFlag",
+			State:          memprovider.Enabled,
+			DefaultVariant: "greeting",
+			Variants:       map[string]interface{}{"greeting": "hello"},
+		},
+	})
+
+	err := openfeature.SetNamedProviderAndWait(t.Name(), NewChainProvider(primary, secondary))
+	if err != nil {
+		t.Fatalf("error setting provider: %v", err)
+	}
+	defer openfeature.Shutdown()
+
+	value, err := openfeature.NewClient(t.Name()).StringValue(context.Background(), "stringFlag", "default", openfeature.EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != "hello" {
+		t.Errorf("expected the secondary's value, got %s", value)
+	}
+}
@@ -2,9 +2,16 @@ package openfeature
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"reflect"
+	"runtime/debug"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/go-logr/logr"
@@ -42,6 +49,16 @@ type Client struct {
 	hooks             []Hook
 	evaluationContext EvaluationContext
 	domain            string
+	cache             *evaluationCache
+	cacheInvalidation EventCallback
+	// validateTargetingKey is nil unless set with WithTargetingKeyValidator
+	validateTargetingKey func(targetingKey string) error
+	// hookHints are the hook hints of all evaluations made by the client, see AddHookHints
+	hookHints HookHints
+	// flagHints are the hook hints of the evaluations of a single flag, see AddFlagHints
+	flagHints map[string]HookHints
+	// handlers are the event handlers added with AddHandler, removed on Close
+	handlers map[EventType][]EventCallback
 
 	mx sync.RWMutex
 }
@@ -71,6 +88,12 @@ func (c *Client) State() State {
 	return c.clientEventing.State(c.domain)
 }
 
+// ProviderStatus returns the state of the associated provider, reflecting the provider's latest lifecycle event.
+// It is safe for concurrent use, allowing callers to gate traffic until the provider is ready.
+func (c *Client) ProviderStatus() State {
+	return c.State()
+}
+
 // Deprecated
 // WithLogger sets the logger of the client
 func (c *Client) WithLogger(l logr.Logger) *Client {
@@ -79,6 +102,54 @@ func (c *Client) WithLogger(l logr.Logger) *Client {
 	return c
 }
 
+// WithEvaluationCache enables caching the flag resolutions of the client, memoized by flag key, default value and
+// evaluation context. Cached resolutions are invalidated when the provider emits a PROVIDER_CONFIGURATION_CHANGED
+// event for their flag, or for all flags if the event lists no flag changes.
+//
+// The cache is looked up with the evaluation context before it is amended by before hooks, which should therefore be
+// deterministic. Evaluations served from the cache have the CACHED reason and skip the hooks unless
+// CacheConfig.RunHooks is set. Use the WithoutEvaluationCache option to bypass the cache for an evaluation.
+func (c *Client) WithEvaluationCache(config CacheConfig) *Client {
+	cache := newEvaluationCache(config)
+	invalidate := func(details EventDetails) {
+		cache.invalidate(details.FlagChanges)
+	}
+
+	c.mx.Lock()
+	replaced := c.cacheInvalidation
+	c.cache, c.cacheInvalidation = cache, &invalidate
+	domain := c.metadata.Domain()
+	c.mx.Unlock()
+
+	// the handlers are registered without holding the client's lock, as the event executor may run them synchronously
+	if replaced != nil {
+		c.clientEventing.RemoveClientHandler(domain, ProviderConfigChange, replaced)
+	}
+	c.clientEventing.AddClientHandler(domain, ProviderConfigChange, &invalidate)
+	return c
+}
+
+// WithTargetingKeyValidator validates the targeting key of the client's evaluations once the before hooks amended the
+// evaluation context, before the flag is resolved. An evaluation whose targeting key fails the validation returns the
+// default value with a TARGETING_KEY_MISSING error wrapping the validation error, running the error and finally hooks
+// as for any other error. See NonBlankTargetingKey for a built-in validator.
+func (c *Client) WithTargetingKeyValidator(validator func(targetingKey string) error) *Client {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.validateTargetingKey = validator
+	return c
+}
+
+// NonBlankTargetingKey is a targeting key validator rejecting empty and whitespace only targeting keys, see
+// Client.WithTargetingKeyValidator
+func NonBlankTargetingKey(targetingKey string) error {
+	if strings.TrimSpace(targetingKey) == "" {
+		return errors.New("targeting key is blank")
+	}
+	return nil
+}
+
 // Metadata returns the client's metadata
 func (c *Client) Metadata() ClientMetadata {
 	c.mx.RLock()
@@ -93,17 +164,110 @@ func (c *Client) AddHooks(hooks ...Hook) {
 	c.hooks = append(c.hooks, hooks...)
 }
 
-// AddHandler allows to add Client level event handler
-func (c *Client) AddHandler(eventType EventType, callback EventCallback) {
-	c.clientEventing.AddClientHandler(c.metadata.Domain(), eventType, callback)
+// AddHookHints adds standing hook hints given to the hooks of all evaluations made by the client. Hints added are
+// merged, the latest taking precedence for keys present in both. The hints of a flag added with AddFlagHints, of the
+// Go context added with WithContextHookHints, then the hints given at the call site with WithHookHints take precedence
+// over the client's hints.
+func (c *Client) AddHookHints(hints HookHints) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.hookHints = c.hookHints.Merge(hints)
+}
+
+// AddFlagHints adds hook hints given to the hooks of the evaluations of the flag only, e.g. to enable extra logging
+// for a single flaky flag. Hints added for the same flag are merged, the latest taking precedence for keys present in
+// both. The hints given at the call site with WithHookHints take precedence over the flag's hints.
+func (c *Client) AddFlagHints(flagKey string, hints HookHints) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.flagHints == nil {
+		c.flagHints = map[string]HookHints{}
+	}
+	c.flagHints[flagKey] = c.flagHints[flagKey].Merge(hints)
+}
+
+// ClearHooks removes all of the client's hooks. Evaluations in flight keep running the hooks they started with.
+func (c *Client) ClearHooks() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.hooks = []Hook{}
+}
+
+// RemoveHook removes all occurrences of the hook from the client's hooks, reporting whether the hook was registered.
+// Hooks are compared by identity, hooks of non-comparable types cannot be removed. Evaluations in flight keep running
+// the hooks they started with.
+func (c *Client) RemoveHook(hook Hook) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	var removed bool
+	c.hooks, removed = removeHook(c.hooks, hook)
+	return removed
+}
+
+// Hooks returns a copy of the client's hooks
+func (c *Client) Hooks() []Hook {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	return slices.Clone(c.hooks)
+}
+
+// AddHandler allows to add Client level event handler. The returned unsubscribe function removes the handler like
+// RemoveHandler, and is a no-op when called again.
+func (c *Client) AddHandler(eventType EventType, callback EventCallback) (unsubscribe func()) {
+	c.mx.Lock()
+	if c.handlers == nil {
+		c.handlers = map[EventType][]EventCallback{}
+	}
+	c.handlers[eventType] = append(c.handlers[eventType], callback)
+	domain := c.metadata.Domain()
+	c.mx.Unlock()
+
+	// the handler is registered without holding the client's lock, as it runs synchronously if the provider is
+	// already in the state of the event, and may evaluate flags with the client
+	c.clientEventing.AddClientHandler(domain, eventType, callback)
+	return unsubscriber(func() { c.RemoveHandler(eventType, callback) })
 }
 
 // RemoveHandler allows to remove Client level event handler
 func (c *Client) RemoveHandler(eventType EventType, callback EventCallback) {
-	c.clientEventing.RemoveClientHandler(c.metadata.Domain(), eventType, callback)
+	c.mx.Lock()
+	c.handlers[eventType] = slices.DeleteFunc(c.handlers[eventType], func(h EventCallback) bool { return h == callback })
+	domain := c.metadata.Domain()
+	c.mx.Unlock()
+
+	c.clientEventing.RemoveClientHandler(domain, eventType, callback)
+}
+
+// Close detaches the client from the API, e.g. for short-lived per-tenant clients of long-lived processes: the
+// client's hooks, flag hints and evaluation cache are released, and the event handlers it added, including the cache
+// invalidation, are removed. Handlers added for the same domain by other clients are kept, and the provider of the
+// domain is not shut down.
+//
+// Close is meant to be called once, when the client is no longer used. Evaluations after Close still resolve flags,
+// without the client's hooks, and evaluations in flight keep running the hooks they started with.
+func (c *Client) Close() {
+	c.mx.Lock()
+	handlers, cacheInvalidation := c.handlers, c.cacheInvalidation
+	c.handlers, c.hooks, c.flagHints, c.cache, c.cacheInvalidation = nil, nil, nil, nil, nil
+	c.hookHints = HookHints{}
+	domain := c.metadata.Domain()
+	c.mx.Unlock()
+
+	for eventType, callbacks := range handlers {
+		for _, callback := range callbacks {
+			c.clientEventing.RemoveClientHandler(domain, eventType, callback)
+		}
+	}
+	if cacheInvalidation != nil {
+		c.clientEventing.RemoveClientHandler(domain, ProviderConfigChange, cacheInvalidation)
+	}
 }
 
-// SetEvaluationContext sets the client's evaluation context
+// SetEvaluationContext sets the client's evaluation context, the default context of all evaluations made by the
+// client. Its attributes take precedence over those of the API and transaction contexts, and are overridden
+// per attribute by the invocation context and the before hooks. It is safe to call concurrently with evaluations,
+// which keep using the context they started with.
 func (c *Client) SetEvaluationContext(evalCtx EvaluationContext) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
@@ -129,7 +293,10 @@ const (
 )
 
 func (t Type) String() string {
-	return typeToString[t]
+	if s, ok := typeToString[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("Type(%d)", int64(t))
 }
 
 var typeToString = map[Type]string{
@@ -144,6 +311,14 @@ type EvaluationDetails struct {
 	FlagKey  string
 	FlagType Type
 	ResolutionDetail
+	// EvaluationDuration is the time the evaluation took, from the start of the Before stage to the completion of the
+	// After or Error stage, including the provider call. The Finally stage is not measured, so Finally hooks receive
+	// the duration. For batch evaluations the duration extends to the completion of the whole batch.
+	// EvaluationDuration is zero if measurement is disabled with WithoutEvaluationDuration.
+	EvaluationDuration time.Duration
+	// FromCache reports whether the resolution was served from the evaluation cache of the client, see
+	// Client.WithEvaluationCache, rather than by the provider. It is always false if the cache is disabled.
+	FromCache bool
 }
 
 type BooleanEvaluationDetails struct {
@@ -176,27 +351,44 @@ type ResolutionDetail struct {
 	Reason       Reason
 	ErrorCode    ErrorCode
 	ErrorMessage string
+	// ErrorDetails are the provider specific details of the resolution error, see ResolutionError.WithDetails
+	ErrorDetails map[string]interface{}
 	FlagMetadata FlagMetadata
 }
 
+// Error returns the resolution error of the evaluation, or nil if it succeeded. The error matches the sentinel error
+// of its code with errors.Is, e.g. FlagNotFoundError, and can be inspected as a ResolutionError with errors.As.
+func (r ResolutionDetail) Error() error {
+	if r.ErrorCode == "" {
+		return nil
+	}
+	return ResolutionError{code: r.ErrorCode, message: r.ErrorMessage, details: r.ErrorDetails}
+}
+
 // FlagMetadata is a structure which supports definition of arbitrary properties, with keys of type string, and values
 // of type boolean, string, int64 or float64. This structure is populated by a provider for use by an Application
 // Author (via the Evaluation API) or an Application Integrator (via hooks).
 type FlagMetadata map[string]interface{}
 
+var (
+	// FlagMetadataKeyNotFoundError signifies that the key requested from a FlagMetadata does not exist
+	FlagMetadataKeyNotFoundError = errors.New("key does not exist in FlagMetadata")
+	// FlagMetadataTypeMismatchError signifies that the value of the key requested from a FlagMetadata is of another type
+	FlagMetadataTypeMismatchError = errors.New("wrong type in FlagMetadata")
+)
+
 // GetString fetch string value from FlagMetadata.
 // Returns an error if the key does not exist, or, the value is of the wrong type
 func (f FlagMetadata) GetString(key string) (string, error) {
 	v, ok := f[key]
 	if !ok {
-		return "", fmt.Errorf("key %s does not exist in FlagMetadata", key)
+		return "", flagMetadataKeyNotFound(key)
 	}
-	switch t := v.(type) {
-	case string:
-		return v.(string), nil
-	default:
-		return "", fmt.Errorf("wrong type for key %s, expected string, got %T", key, t)
+	s, ok := v.(string)
+	if !ok {
+		return "", flagMetadataTypeMismatch(key, "string", v)
 	}
+	return s, nil
 }
 
 // GetBool fetch bool value from FlagMetadata.
@@ -204,54 +396,51 @@ func (f FlagMetadata) GetString(key string) (string, error) {
 func (f FlagMetadata) GetBool(key string) (bool, error) {
 	v, ok := f[key]
 	if !ok {
-		return false, fmt.Errorf("key %s does not exist in FlagMetadata", key)
+		return false, flagMetadataKeyNotFound(key)
 	}
-	switch t := v.(type) {
-	case bool:
-		return v.(bool), nil
-	default:
-		return false, fmt.Errorf("wrong type for key %s, expected bool, got %T", key, t)
+	b, ok := v.(bool)
+	if !ok {
+		return false, flagMetadataTypeMismatch(key, "bool", v)
 	}
+	return b, nil
 }
 
 // GetInt fetch int64 value from FlagMetadata.
+// Float values without a fractional part, such as the numbers of decoded JSON, are converted.
 // Returns an error if the key does not exist, or, the value is of the wrong type
 func (f FlagMetadata) GetInt(key string) (int64, error) {
 	v, ok := f[key]
 	if !ok {
-		return 0, fmt.Errorf("key %s does not exist in FlagMetadata", key)
-	}
-	switch t := v.(type) {
-	case int:
-		return int64(v.(int)), nil
-	case int8:
-		return int64(v.(int8)), nil
-	case int16:
-		return int64(v.(int16)), nil
-	case int32:
-		return int64(v.(int32)), nil
-	case int64:
-		return v.(int64), nil
-	default:
-		return 0, fmt.Errorf("wrong type for key %s, expected integer, got %T", key, t)
+		return 0, flagMetadataKeyNotFound(key)
+	}
+	i, ok := toInt64(v)
+	if !ok {
+		return 0, flagMetadataTypeMismatch(key, "integer", v)
 	}
+	return i, nil
 }
 
 // GetFloat fetch float64 value from FlagMetadata.
+// Integer values are converted if they fit into a float64 without loss.
 // Returns an error if the key does not exist, or, the value is of the wrong type
 func (f FlagMetadata) GetFloat(key string) (float64, error) {
 	v, ok := f[key]
 	if !ok {
-		return 0, fmt.Errorf("key %s does not exist in FlagMetadata", key)
+		return 0, flagMetadataKeyNotFound(key)
 	}
-	switch t := v.(type) {
-	case float32:
-		return float64(v.(float32)), nil
-	case float64:
-		return v.(float64), nil
-	default:
-		return 0, fmt.Errorf("wrong type for key %s, expected float, got %T", key, t)
+	n, ok := toFloat64(v)
+	if !ok {
+		return 0, flagMetadataTypeMismatch(key, "float", v)
 	}
+	return n, nil
+}
+
+func flagMetadataKeyNotFound(key string) error {
+	return fmt.Errorf("key %s does not exist in FlagMetadata: %w", key, FlagMetadataKeyNotFoundError)
+}
+
+func flagMetadataTypeMismatch(key string, expected string, value interface{}) error {
+	return fmt.Errorf("wrong type for key %s, expected %s, got %T: %w", key, expected, value, FlagMetadataTypeMismatchError)
 }
 
 // Option applies a change to EvaluationOptions
@@ -259,8 +448,39 @@ type Option func(*EvaluationOptions)
 
 // EvaluationOptions should contain a list of hooks to be executed for a flag evaluation
 type EvaluationOptions struct {
-	hooks     []Hook
-	hookHints HookHints
+	hooks       []Hook
+	hookHints   HookHints
+	hookTimeout time.Duration
+	// providerTimeout bounds the resolution of the flag by the provider, see WithProviderTimeout
+	providerTimeout time.Duration
+	// strictProviderTimeout applies providerTimeout even if the evaluation's context has a deadline, see
+	// Client.BooleanValueWithTimeout
+	strictProviderTimeout bool
+	// disableHookPanicRecovery is inverted so that the zero value recovers hook panics
+	disableHookPanicRecovery bool
+	// disableProviderPanicRecovery is inverted so that the zero value recovers provider panics
+	disableProviderPanicRecovery bool
+	// hookErrorEvents emits a HookError event when a before or after hook fails, see WithHookErrorEvents
+	hookErrorEvents bool
+	bypassCache     bool
+	skipDuration    bool
+	hookConcurrency int
+	// maxHooks bounds the number of hooks of the evaluation, see WithMaxHooks
+	maxHooks int
+	// provider overrides the registered provider, see Client.BooleanValueWithProvider
+	provider FeatureProvider
+	// targetingKeyStrategy selects the targeting key of the merged evaluation context
+	targetingKeyStrategy TargetingKeyStrategy
+	// forcedVariant is the variant the flag is resolved to, see Client.BooleanValueForceVariant
+	forcedVariant *string
+	// preflattened is the merged & flattened context of the evaluation, see Client.BooleanValuePreflattened
+	preflattened FlattenedContext
+	// trace collects the hook stage invocations of the evaluation, see Client.EvaluateWithTrace
+	trace *[]HookTraceEntry
+	// mergedContext receives the merged evaluation context of the evaluation, see Client.BooleanValueDetailsFunc
+	mergedContext *EvaluationContext
+	// anyFlagType resolves the flag regardless of its type, see Client.EvaluationMetadata
+	anyFlagType bool
 }
 
 // HookHints returns evaluation options' hook hints
@@ -273,6 +493,36 @@ func (e EvaluationOptions) Hooks() []Hook {
 	return e.hooks
 }
 
+// HookTimeout returns evaluation options' hook timeout
+func (e EvaluationOptions) HookTimeout() time.Duration {
+	return e.hookTimeout
+}
+
+// HookConcurrency returns the maximum number of flags of a batch evaluation evaluated in parallel
+func (e EvaluationOptions) HookConcurrency() int {
+	return e.hookConcurrency
+}
+
+// MaxHooks returns the maximum number of hooks of an evaluation, zero if unbounded
+func (e EvaluationOptions) MaxHooks() int {
+	return e.maxHooks
+}
+
+// HookPanicRecovery returns whether evaluation options recover panicking hooks
+func (e EvaluationOptions) HookPanicRecovery() bool {
+	return !e.disableHookPanicRecovery
+}
+
+// ProviderPanicRecovery returns whether evaluation options recover panicking providers
+func (e EvaluationOptions) ProviderPanicRecovery() bool {
+	return !e.disableProviderPanicRecovery
+}
+
+// HookErrorEvents returns whether evaluation options emit HookError events for failing hooks
+func (e EvaluationOptions) HookErrorEvents() bool {
+	return e.hookErrorEvents
+}
+
 // WithHooks applies provided hooks.
 func WithHooks(hooks ...Hook) Option {
 	return func(options *EvaluationOptions) {
@@ -280,10 +530,148 @@ func WithHooks(hooks ...Hook) Option {
 	}
 }
 
-// WithHookHints applies provided hook hints.
+// WithHookHints applies provided hook hints. The hints are merged with the hints of the client, of the flag and of the
+// Go context, see Client.AddHookHints and WithContextHookHints, taking precedence for keys present in both, and with
+// the hints of earlier WithHookHints options of the call.
 func WithHookHints(hookHints HookHints) Option {
 	return func(options *EvaluationOptions) {
-		options.hookHints = hookHints
+		if len(options.hookHints.mapOfHints) == 0 {
+			options.hookHints = hookHints
+			return
+		}
+		options.hookHints = options.hookHints.Merge(hookHints)
+	}
+}
+
+// WithHookTimeout bounds the time each hook stage invocation may take. A Before or After hook exceeding the timeout
+// fails the evaluation with an error wrapping HookTimeoutError, which runs the Error and Finally stages as for any
+// other hook error. An Error or Finally hook exceeding the timeout is abandoned and the remaining hooks of the stage
+// still run. The context given to the hook is cancelled on timeout, hooks should honor it to stop promptly: the later
+// stages of a hook wait for its abandoned stage to return, for at most the timeout, and are skipped with a warning
+// logged if it does not. A zero or negative timeout disables the bound, which is the default.
+func WithHookTimeout(timeout time.Duration) Option {
+	return func(options *EvaluationOptions) {
+		options.hookTimeout = timeout
+	}
+}
+
+// WithProviderTimeout bounds the time the provider may take to resolve the flag, when the evaluation's context has
+// no deadline of its own. Callers of a context with a deadline are respected, their deadline applies instead. A
+// resolution exceeding the timeout fails with a GENERAL error matching ProviderTimeoutError with errors.Is. The
+// hooks are not bounded, see WithHookTimeout. A zero or negative timeout disables the bound, which is the default.
+func WithProviderTimeout(timeout time.Duration) Option {
+	return func(options *EvaluationOptions) {
+		options.providerTimeout = timeout
+	}
+}
+
+// WithoutEvaluationCache bypasses the evaluation cache of the client, see Client.WithEvaluationCache. The resolution
+// of the evaluation is not cached either.
+func WithoutEvaluationCache() Option {
+	return func(options *EvaluationOptions) {
+		options.bypassCache = true
+	}
+}
+
+// WithoutEvaluationDuration disables the measurement of EvaluationDetails.EvaluationDuration, which is then zero
+func WithoutEvaluationDuration() Option {
+	return func(options *EvaluationOptions) {
+		options.skipDuration = true
+	}
+}
+
+// WithHookConcurrency bounds the number of flags of an EvaluateBatch call evaluated in parallel, running their hooks
+// and resolutions concurrently. A value below 2 evaluates the flags one at a time, which is the default. Hooks shared
+// by the flags of the batch must then be safe for concurrent use, as for concurrent evaluations.
+func WithHookConcurrency(n int) Option {
+	return func(options *EvaluationOptions) {
+		options.hookConcurrency = n
+	}
+}
+
+// WithMaxHooks bounds the number of hooks of an evaluation, counting the API, client, invocation and provider hooks,
+// as a safeguard against hooks registered recursively or repeatedly by misconfigured composite setups. An evaluation
+// exceeding the limit runs none of its hooks and fails with a GENERAL error matching HookLimitExceededError with
+// errors.Is, returning the default value. A zero or negative limit disables the bound, which is the default.
+func WithMaxHooks(n int) Option {
+	return func(options *EvaluationOptions) {
+		options.maxHooks = n
+	}
+}
+
+// WithTargetingKeyStrategy selects the targeting key of the evaluation context merged from the API (global),
+// transaction, client and invocation contexts. With LastNonEmptyWins, the default, the last non-empty targeting key
+// wins. With LastWins, the targeting key of the invocation context always wins: an invocation context without
+// targeting key clears the inherited one. Enrichers and before hooks can set, but never clear, the targeting key.
+func WithTargetingKeyStrategy(strategy TargetingKeyStrategy) Option {
+	return func(options *EvaluationOptions) {
+		options.targetingKeyStrategy = strategy
+	}
+}
+
+// withProvider resolves the flag with the given provider in place of the registered provider
+func withProvider(provider FeatureProvider) Option {
+	return func(options *EvaluationOptions) {
+		options.provider = provider
+	}
+}
+
+// withForcedVariant resolves the flag to the given variant, see VariantForcer
+func withForcedVariant(variant string) Option {
+	return func(options *EvaluationOptions) {
+		options.forcedVariant = &variant
+	}
+}
+
+// withResolutionTimeout bounds the resolution of the flag by the provider with the timeout, whether the evaluation's
+// context has a deadline or not, see Client.BooleanValueWithTimeout
+func withResolutionTimeout(timeout time.Duration) Option {
+	return func(options *EvaluationOptions) {
+		options.providerTimeout = timeout
+		options.strictProviderTimeout = true
+	}
+}
+
+// withPreflattenedContext evaluates the flag with the given merged & flattened context, see
+// Client.BooleanValuePreflattened
+func withPreflattenedContext(flatCtx FlattenedContext) Option {
+	return func(options *EvaluationOptions) {
+		options.preflattened = flatCtx
+	}
+}
+
+// WithProviderPanicRecovery configures whether a panic of the provider resolving the flag is recovered, which is the
+// default. A recovered panic fails the evaluation with a GENERAL error caused by a ProviderPanicError, which runs the
+// error and finally hooks as for any other resolution error, and is reported with a PROVIDER_ERROR event on behalf of
+// the provider, even if the provider panics after the deadline of the evaluation passed. As the provider resolves the
+// flags of contexts with a deadline in another goroutine, a panic of the provider not recovered crashes the program.
+func WithProviderPanicRecovery(enabled bool) Option {
+	return func(options *EvaluationOptions) {
+		options.disableProviderPanicRecovery = !enabled
+	}
+}
+
+const (
+	// HookErrorFlagKey is the EventMetadata key of the flag key of a HookError event
+	HookErrorFlagKey = "flagKey"
+	// HookErrorStageKey is the EventMetadata key of the failed hook stage of a HookError event
+	HookErrorStageKey = "hookStage"
+)
+
+// WithHookErrorEvents configures whether a failing before or after hook emits a HookError event on behalf of the
+// provider, which is disabled by default. The details of the event carry the error as message, and the flag key and
+// hook stage in their EventMetadata under the HookErrorFlagKey and HookErrorStageKey keys.
+func WithHookErrorEvents(enabled bool) Option {
+	return func(options *EvaluationOptions) {
+		options.hookErrorEvents = enabled
+	}
+}
+
+// WithHookPanicRecovery configures whether a panic in a hook stage is recovered, which is the default. A recovered
+// panic is converted into a HookPanicError, which is handled like any other error returned by the hook stage.
+func WithHookPanicRecovery(enabled bool) Option {
+	return func(options *EvaluationOptions) {
+		options.disableHookPanicRecovery = !enabled
 	}
 }
 
@@ -372,6 +760,110 @@ func (c *Client) ObjectValue(ctx context.Context, flag string, defaultValue inte
 	return details.Value, nil
 }
 
+// GetValue performs an object flag evaluation, decoding the resolved value into T.
+//
+// A resolved value which is not already a T is decoded through a JSON round-trip. If the value cannot be decoded
+// into T the default value is returned with a TYPE_MISMATCH ResolutionError.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - client is the client performing the evaluation
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func GetValue[T any](ctx context.Context, client *Client, flag string, defaultValue T, evalCtx EvaluationContext, options ...Option) (T, error) {
+	value, err := client.ObjectValue(ctx, flag, defaultValue, evalCtx, options...)
+	if err != nil {
+		return defaultValue, err
+	}
+
+	if typed, ok := value.(T); ok {
+		return typed, nil
+	}
+
+	var decoded T
+	encoded, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(encoded, &decoded)
+	}
+	if err != nil {
+		return defaultValue, ResolutionError{
+			code:    TypeMismatchCode,
+			message: fmt.Sprintf("value of flag %s cannot be decoded into %T: %s", flag, decoded, err),
+			cause:   err,
+		}
+	}
+
+	return decoded, nil
+}
+
+// ObjectValueInto performs an object flag evaluation, decoding the resolved value into the value pointed to by target.
+// The value pointed to by target is the default value of the evaluation.
+//
+// A resolved value which is not assignable to the target is decoded through a JSON round-trip. The target is left
+// unchanged on error: if the evaluation fails, or if the value cannot be decoded into the target, in which case a
+// TYPE_MISMATCH ResolutionError is returned.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - target is a non-nil pointer to the value to decode the resolved value into
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) ObjectValueInto(ctx context.Context, flag string, target interface{}, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error) {
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Pointer || pointer.IsNil() {
+		err := NewGeneralResolutionError(fmt.Sprintf("target of flag %s must be a non-nil pointer, got %T", flag, target))
+		return InterfaceEvaluationDetails{
+			EvaluationDetails: EvaluationDetails{
+				FlagKey:  flag,
+				FlagType: Object,
+				ResolutionDetail: ResolutionDetail{
+					Reason:       ErrorReason,
+					ErrorCode:    err.code,
+					ErrorMessage: err.message,
+					FlagMetadata: FlagMetadata{},
+				},
+			},
+		}, err
+	}
+	defaultValue := pointer.Elem().Interface()
+
+	details, err := c.ObjectValueDetails(ctx, flag, defaultValue, evalCtx, options...)
+	if err != nil {
+		return details, err
+	}
+
+	value := reflect.ValueOf(details.Value)
+	if value.IsValid() && value.Type().AssignableTo(pointer.Elem().Type()) {
+		pointer.Elem().Set(value)
+		return details, nil
+	}
+
+	// decoded into a new value first, so that the target is left unchanged if the decoding fails
+	decoded := reflect.New(pointer.Elem().Type())
+	encoded, err := json.Marshal(details.Value)
+	if err == nil {
+		err = json.Unmarshal(encoded, decoded.Interface())
+	}
+	if err != nil {
+		resolutionErr := ResolutionError{
+			code:    TypeMismatchCode,
+			message: fmt.Sprintf("value of flag %s cannot be decoded into %s: %s", flag, pointer.Elem().Type(), err),
+			cause:   err,
+		}
+		details.Value = defaultValue
+		details.Reason = ErrorReason
+		details.ErrorCode = resolutionErr.code
+		details.ErrorMessage = resolutionErr.message
+		return details, resolutionErr
+	}
+	pointer.Elem().Set(decoded.Elem())
+
+	return details, nil
+}
+
 // BooleanValueDetails performs a flag evaluation that returns an evaluation details struct.
 //
 // Parameters:
@@ -568,175 +1060,1365 @@ func (c *Client) ObjectValueDetails(ctx context.Context, flag string, defaultVal
 	return c.evaluate(ctx, flag, Object, defaultValue, evalCtx, *evalOptions)
 }
 
-// Boolean performs a flag evaluation that returns a boolean. Any error
-// encountered during the evaluation will result in the default value being
-// returned. To explicitly handle errors, use [BooleanValue] or [BooleanValueDetails]
+// BooleanValueFunc performs a flag evaluation that returns a boolean, computing the default value lazily.
+// See BooleanValueDetailsFunc for when the default value is computed.
 //
 // Parameters:
 // - ctx is the standard go context struct used to manage requests (e.g. timeouts)
 // - flag is the key that uniquely identifies a particular flag
-// - defaultValue is returned if an error occurs
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
 // - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
 // - options are optional additional evaluation options e.g. WithHooks & WithHookHints
-func (c *Client) Boolean(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, options ...Option) bool {
-	value, _ := c.BooleanValue(ctx, flag, defaultValue, evalCtx, options...)
-
-	return value
+func (c *Client) BooleanValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) bool, evalCtx EvaluationContext, options ...Option) (bool, error) {
+	details, err := c.BooleanValueDetailsFunc(ctx, flag, defaultFn, evalCtx, options...)
+	return details.Value, err
 }
 
-// String performs a flag evaluation that returns a string. Any error
-// encountered during the evaluation will result in the default value being
-// returned. To explicitly handle errors, use [StringValue] or [StringValueDetails]
+// BooleanValueDetailsFunc performs a flag evaluation that returns an evaluation details struct, computing the default
+// value lazily. The provider resolves the flag against the zero value, and defaultFn is only called if the evaluation
+// fails or the provider resolves the flag with the DEFAULT reason, in which case the details carry the computed value
+// while keeping the ERROR or DEFAULT reason. Hooks receive the zero value as the default value of the evaluation.
 //
 // Parameters:
 // - ctx is the standard go context struct used to manage requests (e.g. timeouts)
 // - flag is the key that uniquely identifies a particular flag
-// - defaultValue is returned if an error occurs
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
 // - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
 // - options are optional additional evaluation options e.g. WithHooks & WithHookHints
-func (c *Client) String(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, options ...Option) string {
-	value, _ := c.StringValue(ctx, flag, defaultValue, evalCtx, options...)
-
-	return value
+func (c *Client) BooleanValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) bool, evalCtx EvaluationContext, options ...Option) (BooleanEvaluationDetails, error) {
+	merged := evalCtx
+	details, err := c.BooleanValueDetails(ctx, flag, false, evalCtx, append([]Option{withMergedContext(&merged)}, options...)...)
+	if resolvedToDefault(details.EvaluationDetails, err) {
+		details.Value = defaultFn(merged)
+	}
+	return details, err
 }
 
-// Float performs a flag evaluation that returns a float64. Any error
-// encountered during the evaluation will result in the default value being
-// returned. To explicitly handle errors, use [FloatValue] or [FloatValueDetails]
+// StringValueFunc performs a flag evaluation that returns a string, computing the default value lazily.
+// See StringValueDetailsFunc for when the default value is computed.
 //
 // Parameters:
 // - ctx is the standard go context struct used to manage requests (e.g. timeouts)
 // - flag is the key that uniquely identifies a particular flag
-// - defaultValue is returned if an error occurs
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
 // - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
 // - options are optional additional evaluation options e.g. WithHooks & WithHookHints
-func (c *Client) Float(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) float64 {
-	value, _ := c.FloatValue(ctx, flag, defaultValue, evalCtx, options...)
-
-	return value
+func (c *Client) StringValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) string, evalCtx EvaluationContext, options ...Option) (string, error) {
+	details, err := c.StringValueDetailsFunc(ctx, flag, defaultFn, evalCtx, options...)
+	return details.Value, err
 }
 
-// Int performs a flag evaluation that returns an int64. Any error
-// encountered during the evaluation will result in the default value being
-// returned. To explicitly handle errors, use [IntValue] or [IntValueDetails]
+// StringValueDetailsFunc performs a flag evaluation that returns an evaluation details struct, computing the default
+// value lazily. The provider resolves the flag against the zero value, and defaultFn is only called if the evaluation
+// fails or the provider resolves the flag with the DEFAULT reason, in which case the details carry the computed value
+// while keeping the ERROR or DEFAULT reason. Hooks receive the zero value as the default value of the evaluation.
 //
 // Parameters:
 // - ctx is the standard go context struct used to manage requests (e.g. timeouts)
 // - flag is the key that uniquely identifies a particular flag
-// - defaultValue is returned if an error occurs
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
 // - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
 // - options are optional additional evaluation options e.g. WithHooks & WithHookHints
-func (c *Client) Int(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) int64 {
-	value, _ := c.IntValue(ctx, flag, defaultValue, evalCtx, options...)
+func (c *Client) StringValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) string, evalCtx EvaluationContext, options ...Option) (StringEvaluationDetails, error) {
+	merged := evalCtx
+	details, err := c.StringValueDetails(ctx, flag, "", evalCtx, append([]Option{withMergedContext(&merged)}, options...)...)
+	if resolvedToDefault(details.EvaluationDetails, err) {
+		details.Value = defaultFn(merged)
+	}
+	return details, err
+}
 
-	return value
+// FloatValueFunc performs a flag evaluation that returns a float, computing the default value lazily.
+// See FloatValueDetailsFunc for when the default value is computed.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) FloatValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) float64, evalCtx EvaluationContext, options ...Option) (float64, error) {
+	details, err := c.FloatValueDetailsFunc(ctx, flag, defaultFn, evalCtx, options...)
+	return details.Value, err
 }
 
-// Object performs a flag evaluation that returns an object. Any error
-// encountered during the evaluation will result in the default value being
-// returned. To explicitly handle errors, use [ObjectValue] or [ObjectValueDetails]
+// FloatValueDetailsFunc performs a flag evaluation that returns an evaluation details struct, computing the default
+// value lazily. The provider resolves the flag against the zero value, and defaultFn is only called if the evaluation
+// fails or the provider resolves the flag with the DEFAULT reason, in which case the details carry the computed value
+// while keeping the ERROR or DEFAULT reason. Hooks receive the zero value as the default value of the evaluation.
 //
 // Parameters:
 // - ctx is the standard go context struct used to manage requests (e.g. timeouts)
 // - flag is the key that uniquely identifies a particular flag
-// - defaultValue is returned if an error occurs
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
 // - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
 // - options are optional additional evaluation options e.g. WithHooks & WithHookHints
-func (c *Client) Object(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) interface{} {
-	value, _ := c.ObjectValue(ctx, flag, defaultValue, evalCtx, options...)
+func (c *Client) FloatValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) float64, evalCtx EvaluationContext, options ...Option) (FloatEvaluationDetails, error) {
+	merged := evalCtx
+	details, err := c.FloatValueDetails(ctx, flag, 0, evalCtx, append([]Option{withMergedContext(&merged)}, options...)...)
+	if resolvedToDefault(details.EvaluationDetails, err) {
+		details.Value = defaultFn(merged)
+	}
+	return details, err
+}
 
-	return value
+// IntValueFunc performs a flag evaluation that returns an integer, computing the default value lazily.
+// See IntValueDetailsFunc for when the default value is computed.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) IntValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) int64, evalCtx EvaluationContext, options ...Option) (int64, error) {
+	details, err := c.IntValueDetailsFunc(ctx, flag, defaultFn, evalCtx, options...)
+	return details.Value, err
 }
 
-// Track performs an action for tracking for occurrence  of a particular action or application state.
+// IntValueDetailsFunc performs a flag evaluation that returns an evaluation details struct, computing the default
+// value lazily. The provider resolves the flag against the zero value, and defaultFn is only called if the evaluation
+// fails or the provider resolves the flag with the DEFAULT reason, in which case the details carry the computed value
+// while keeping the ERROR or DEFAULT reason. Hooks receive the zero value as the default value of the evaluation.
 //
 // Parameters:
 // - ctx is the standard go context struct used to manage requests (e.g. timeouts)
-// - trackingEventName is the event name to track
+// - flag is the key that uniquely identifies a particular flag
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
 // - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
-// - trackingEventDetails defines optional data pertinent to a particular
-func (c *Client) Track(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails) {
-	provider, evalCtx := c.forTracking(ctx, evalCtx)
-	provider.Track(ctx, trackingEventName, evalCtx, details)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) IntValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) int64, evalCtx EvaluationContext, options ...Option) (IntEvaluationDetails, error) {
+	merged := evalCtx
+	details, err := c.IntValueDetails(ctx, flag, 0, evalCtx, append([]Option{withMergedContext(&merged)}, options...)...)
+	if resolvedToDefault(details.EvaluationDetails, err) {
+		details.Value = defaultFn(merged)
+	}
+	return details, err
 }
 
-// forTracking return the TrackingHandler and the combination of EvaluationContext from api, transaction, client and invocation.
+// ObjectValueFunc performs a flag evaluation that returns an object, computing the default value lazily.
+// See ObjectValueDetailsFunc for when the default value is computed.
 //
-// The returned evaluation context MUST be merged in the order, with duplicate values being overwritten:
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) ObjectValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) interface{}, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	details, err := c.ObjectValueDetailsFunc(ctx, flag, defaultFn, evalCtx, options...)
+	return details.Value, err
+}
+
+// ObjectValueDetailsFunc performs a flag evaluation that returns an evaluation details struct, computing the default
+// value lazily. The provider resolves the flag against the zero value, and defaultFn is only called if the evaluation
+// fails or the provider resolves the flag with the DEFAULT reason, in which case the details carry the computed value
+// while keeping the ERROR or DEFAULT reason. Hooks receive the zero value as the default value of the evaluation.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultFn computes the default value from the merged evaluation context, if the evaluation fails or defaults
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) ObjectValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) interface{}, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error) {
+	merged := evalCtx
+	details, err := c.ObjectValueDetails(ctx, flag, nil, evalCtx, append([]Option{withMergedContext(&merged)}, options...)...)
+	if resolvedToDefault(details.EvaluationDetails, err) {
+		details.Value = defaultFn(merged)
+	}
+	return details, err
+}
+
+// WeightedValue is a fallback value of ObjectValueWeightedDefault, picked for a share of the targeting keys
+// proportional to its Weight
+type WeightedValue struct {
+	Value  interface{}
+	Weight int
+}
+
+// ObjectValueWeightedDefault performs a flag evaluation that returns an object, picking the default value among the
+// weighted fallbacks if the evaluation fails or resolves to the default, e.g. to gradually roll out a value client
+// side while the backend is down. See ObjectValueDetailsFunc for when the default value is picked.
+//
+// The fallback is picked by deterministically bucketing the flag key and the targeting key of the evaluation context,
+// merged with the transaction and client contexts, so that a user always gets the same fallback. Fallbacks without a
+// positive weight are never picked, and nil is returned if no fallback has one.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - fallbacks are the weighted values the default value is picked from
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) ObjectValueWeightedDefault(ctx context.Context, flag string, fallbacks []WeightedValue, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	clientCtx := c.EvaluationContext()
+	return c.ObjectValueFunc(ctx, flag, func(evalCtx EvaluationContext) interface{} {
+		targetingKey := mergeContexts(evalCtx, TransactionContext(ctx), clientCtx).targetingKey
+		return pickWeighted(fallbacks, flag+"/"+targetingKey)
+	}, evalCtx, options...)
+}
+
+// pickWeighted picks the weighted value of the bucket the key hashes to, or nil if no value has a positive weight
+func pickWeighted(values []WeightedValue, key string) interface{} {
+	total := 0
+	for _, value := range values {
+		total += max(value.Weight, 0)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	bucket := int(hash.Sum32() % uint32(total))
+	for _, value := range values {
+		if value.Weight <= 0 {
+			continue
+		}
+		if bucket < value.Weight {
+			return value.Value
+		}
+		bucket -= value.Weight
+	}
+	return nil
+}
+
+// withMergedContext records the evaluation context merged from the API, transaction, client and invocation contexts
+// in evalCtx
+func withMergedContext(evalCtx *EvaluationContext) Option {
+	return func(options *EvaluationOptions) {
+		options.mergedContext = evalCtx
+	}
+}
+
+// withAnyFlagType resolves the flag regardless of its type, see resolveAnyFlagType
+func withAnyFlagType() Option {
+	return func(options *EvaluationOptions) {
+		options.anyFlagType = true
+	}
+}
+
+// resolvedToDefault reports whether an evaluation failed or resolved the flag to its default, requiring the lazily
+// computed default value
+func resolvedToDefault(details EvaluationDetails, err error) bool {
+	return err != nil || details.Reason == DefaultReason
+}
+
+// BooleanValueWithProvider performs a flag evaluation that returns a boolean, resolving the flag with the given
+// provider instead of the provider registered for the client's domain, e.g. to canary test a provider on a single
+// flag. The evaluation runs the API, client, invocation and provider hooks as usual, and leaves the registered provider
+// untouched. The provider's state is not checked: its lifecycle, i.e. Init and Shutdown, is the caller's
+// responsibility. Evaluations with a provider override bypass the evaluation cache of the client.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - provider resolves the flag in place of the registered provider
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) BooleanValueWithProvider(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (bool, error) {
+	return c.BooleanValue(ctx, flag, defaultValue, evalCtx, append(options, withProvider(provider))...)
+}
+
+// StringValueWithProvider performs a flag evaluation that returns a string, resolving the flag with the given provider
+// instead of the provider registered for the client's domain, e.g. to canary test a provider on a single flag.
+// See StringValue for the parameters and BooleanValueWithProvider for the behavior of the override.
+func (c *Client) StringValueWithProvider(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (string, error) {
+	return c.StringValue(ctx, flag, defaultValue, evalCtx, append(options, withProvider(provider))...)
+}
+
+// FloatValueWithProvider performs a flag evaluation that returns a float, resolving the flag with the given provider
+// instead of the provider registered for the client's domain, e.g. to canary test a provider on a single flag.
+// See FloatValue for the parameters and BooleanValueWithProvider for the behavior of the override.
+func (c *Client) FloatValueWithProvider(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (float64, error) {
+	return c.FloatValue(ctx, flag, defaultValue, evalCtx, append(options, withProvider(provider))...)
+}
+
+// IntValueWithProvider performs a flag evaluation that returns an integer, resolving the flag with the given provider
+// instead of the provider registered for the client's domain, e.g. to canary test a provider on a single flag.
+// See IntValue for the parameters and BooleanValueWithProvider for the behavior of the override.
+func (c *Client) IntValueWithProvider(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (int64, error) {
+	return c.IntValue(ctx, flag, defaultValue, evalCtx, append(options, withProvider(provider))...)
+}
+
+// ObjectValueWithProvider performs a flag evaluation that returns an object, resolving the flag with the given provider
+// instead of the provider registered for the client's domain, e.g. to canary test a provider on a single flag.
+// See ObjectValue for the parameters and BooleanValueWithProvider for the behavior of the override.
+func (c *Client) ObjectValueWithProvider(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (interface{}, error) {
+	return c.ObjectValue(ctx, flag, defaultValue, evalCtx, append(options, withProvider(provider))...)
+}
+
+// BooleanValueForceVariant performs a flag evaluation that returns the value of the given variant of a boolean flag,
+// regardless of the flag's targeting rules, e.g. to verify the behavior of every variant in tests. The provider must
+// implement VariantForcer, otherwise the evaluation fails with a GENERAL error matching
+// VariantForcingUnsupportedError with errors.Is.
+//
+// The evaluation runs the hooks as usual and bypasses the evaluation cache of the client. On error, false is
+// returned.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - variant is the variant of the flag to resolve
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) BooleanValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (bool, error) {
+	return c.BooleanValue(ctx, flag, false, evalCtx, append(options, withForcedVariant(variant))...)
+}
+
+// StringValueForceVariant performs a flag evaluation that returns the value of the given variant of a string flag,
+// see BooleanValueForceVariant. On error, the empty string is returned.
+func (c *Client) StringValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (string, error) {
+	return c.StringValue(ctx, flag, "", evalCtx, append(options, withForcedVariant(variant))...)
+}
+
+// FloatValueForceVariant performs a flag evaluation that returns the value of the given variant of a float flag,
+// see BooleanValueForceVariant. On error, zero is returned.
+func (c *Client) FloatValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (float64, error) {
+	return c.FloatValue(ctx, flag, 0, evalCtx, append(options, withForcedVariant(variant))...)
+}
+
+// IntValueForceVariant performs a flag evaluation that returns the value of the given variant of an integer flag,
+// see BooleanValueForceVariant. On error, zero is returned.
+func (c *Client) IntValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (int64, error) {
+	return c.IntValue(ctx, flag, 0, evalCtx, append(options, withForcedVariant(variant))...)
+}
+
+// ObjectValueForceVariant performs a flag evaluation that returns the value of the given variant of an object flag,
+// see BooleanValueForceVariant. On error, nil is returned.
+func (c *Client) ObjectValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	return c.ObjectValue(ctx, flag, nil, evalCtx, append(options, withForcedVariant(variant))...)
+}
+
+// BooleanValuePreflattened performs a flag evaluation that returns a boolean, like BooleanValue, with an evaluation
+// context already merged & flattened by the caller, e.g. once for the many flags evaluated in a hot path. The API
+// (global), transaction, client and invocation contexts are not merged, and the context enrichers do not run.
+//
+// Hooks still run, and receive the flattened context as their evaluation context, with the targeting key taken from
+// the TargetingKey attribute. Unless a before hook amends the context, the flattened context reaches the provider
+// unchanged, without being flattened again even for providers implementing ContextFlattening. The caller must not
+// modify the flattened context while evaluations use it.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - flatCtx is the merged & flattened evaluation context handed to the provider
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) BooleanValuePreflattened(ctx context.Context, flag string, defaultValue bool, flatCtx FlattenedContext, options ...Option) (bool, error) {
+	return c.BooleanValue(ctx, flag, defaultValue, EvaluationContext{}, append(options, withPreflattenedContext(flatCtx))...)
+}
+
+// StringValuePreflattened performs a flag evaluation that returns a string, with an evaluation context already merged
+// & flattened by the caller, see BooleanValuePreflattened
+func (c *Client) StringValuePreflattened(ctx context.Context, flag string, defaultValue string, flatCtx FlattenedContext, options ...Option) (string, error) {
+	return c.StringValue(ctx, flag, defaultValue, EvaluationContext{}, append(options, withPreflattenedContext(flatCtx))...)
+}
+
+// FloatValuePreflattened performs a flag evaluation that returns a float64, with an evaluation context already
+// merged & flattened by the caller, see BooleanValuePreflattened
+func (c *Client) FloatValuePreflattened(ctx context.Context, flag string, defaultValue float64, flatCtx FlattenedContext, options ...Option) (float64, error) {
+	return c.FloatValue(ctx, flag, defaultValue, EvaluationContext{}, append(options, withPreflattenedContext(flatCtx))...)
+}
+
+// IntValuePreflattened performs a flag evaluation that returns an int64, with an evaluation context already merged
+// & flattened by the caller, see BooleanValuePreflattened
+func (c *Client) IntValuePreflattened(ctx context.Context, flag string, defaultValue int64, flatCtx FlattenedContext, options ...Option) (int64, error) {
+	return c.IntValue(ctx, flag, defaultValue, EvaluationContext{}, append(options, withPreflattenedContext(flatCtx))...)
+}
+
+// ObjectValuePreflattened performs a flag evaluation that returns an object, with an evaluation context already
+// merged & flattened by the caller, see BooleanValuePreflattened
+func (c *Client) ObjectValuePreflattened(ctx context.Context, flag string, defaultValue interface{}, flatCtx FlattenedContext, options ...Option) (interface{}, error) {
+	return c.ObjectValue(ctx, flag, defaultValue, EvaluationContext{}, append(options, withPreflattenedContext(flatCtx))...)
+}
+
+// BooleanValueWithTimeout performs a flag evaluation that returns a boolean, like BooleanValue, waiting at most the
+// timeout for the provider to resolve the flag, e.g. for latency sensitive code preferring the default value to a slow
+// resolution. The timeout applies even if ctx has a later deadline.
+//
+// If the provider does not resolve the flag in time, the context given to the provider is cancelled, and the default
+// value is returned with the ERROR reason and a GENERAL error matching ProviderTimeoutError with errors.Is. The hooks
+// are not bounded by the timeout, and the error and finally hooks run as usual. A zero or negative timeout disables
+// the bound.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - timeout bounds the resolution of the flag by the provider
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) BooleanValueWithTimeout(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (bool, error) {
+	return c.BooleanValue(ctx, flag, defaultValue, evalCtx, append(options, withResolutionTimeout(timeout))...)
+}
+
+// StringValueWithTimeout performs a flag evaluation that returns a string, waiting at most the timeout for the
+// provider to resolve the flag, see BooleanValueWithTimeout
+func (c *Client) StringValueWithTimeout(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (string, error) {
+	return c.StringValue(ctx, flag, defaultValue, evalCtx, append(options, withResolutionTimeout(timeout))...)
+}
+
+// FloatValueWithTimeout performs a flag evaluation that returns a float64, waiting at most the timeout for the
+// provider to resolve the flag, see BooleanValueWithTimeout
+func (c *Client) FloatValueWithTimeout(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (float64, error) {
+	return c.FloatValue(ctx, flag, defaultValue, evalCtx, append(options, withResolutionTimeout(timeout))...)
+}
+
+// IntValueWithTimeout performs a flag evaluation that returns an int64, waiting at most the timeout for the provider
+// to resolve the flag, see BooleanValueWithTimeout
+func (c *Client) IntValueWithTimeout(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (int64, error) {
+	return c.IntValue(ctx, flag, defaultValue, evalCtx, append(options, withResolutionTimeout(timeout))...)
+}
+
+// ObjectValueWithTimeout performs a flag evaluation that returns an object, waiting at most the timeout for the
+// provider to resolve the flag, see BooleanValueWithTimeout
+func (c *Client) ObjectValueWithTimeout(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (interface{}, error) {
+	return c.ObjectValue(ctx, flag, defaultValue, evalCtx, append(options, withResolutionTimeout(timeout))...)
+}
+
+// Boolean performs a flag evaluation that returns a boolean. Any error
+// encountered during the evaluation will result in the default value being
+// returned. To explicitly handle errors, use [BooleanValue] or [BooleanValueDetails]
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) Boolean(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, options ...Option) bool {
+	value, _ := c.BooleanValue(ctx, flag, defaultValue, evalCtx, options...)
+
+	return value
+}
+
+// String performs a flag evaluation that returns a string. Any error
+// encountered during the evaluation will result in the default value being
+// returned. To explicitly handle errors, use [StringValue] or [StringValueDetails]
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) String(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, options ...Option) string {
+	value, _ := c.StringValue(ctx, flag, defaultValue, evalCtx, options...)
+
+	return value
+}
+
+// Float performs a flag evaluation that returns a float64. Any error
+// encountered during the evaluation will result in the default value being
+// returned. To explicitly handle errors, use [FloatValue] or [FloatValueDetails]
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) Float(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) float64 {
+	value, _ := c.FloatValue(ctx, flag, defaultValue, evalCtx, options...)
+
+	return value
+}
+
+// Int performs a flag evaluation that returns an int64. Any error
+// encountered during the evaluation will result in the default value being
+// returned. To explicitly handle errors, use [IntValue] or [IntValueDetails]
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) Int(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) int64 {
+	value, _ := c.IntValue(ctx, flag, defaultValue, evalCtx, options...)
+
+	return value
+}
+
+// Object performs a flag evaluation that returns an object. Any error
+// encountered during the evaluation will result in the default value being
+// returned. To explicitly handle errors, use [ObjectValue] or [ObjectValueDetails]
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) Object(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) interface{} {
+	value, _ := c.ObjectValue(ctx, flag, defaultValue, evalCtx, options...)
+
+	return value
+}
+
+// Track performs an action for tracking for occurrence  of a particular action or application state.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - trackingEventName is the event name to track
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - trackingEventDetails defines optional data pertinent to a particular
+func (c *Client) Track(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails) {
+	provider, evalCtx := c.forTracking(ctx, evalCtx)
+	provider.Track(ctx, trackingEventName, evalCtx, details)
+}
+
+// TrackAsync queues the tracking of the occurrence of a particular action or application state, returning immediately,
+// and reports whether the tracking event was queued. The provider and evaluation context are determined at the call,
+// the provider receiving the event from a bounded worker pool configured with SetTrackingQueueConfig. If the queue is
+// full, the event is dropped, or the call blocks until the event fits in the queue or ctx is done if configured with
+// BlockOnOverflow. The provider receives a context carrying the values of ctx without its cancellation. Queued events
+// are handed to the providers on Shutdown, within the deadline of ShutdownWithContext.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - trackingEventName is the event name to track
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - trackingEventDetails defines optional data pertinent to a particular
+func (c *Client) TrackAsync(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails) bool {
+	provider, evalCtx := c.forTracking(ctx, evalCtx)
+	trackCtx := context.WithoutCancel(ctx)
+	return c.api.enqueueTracking(ctx, func() {
+		provider.Track(trackCtx, trackingEventName, evalCtx, details)
+	})
+}
+
+// forTracking return the TrackingHandler and the combination of EvaluationContext from api, transaction, client and invocation.
+//
+// The returned evaluation context MUST be merged in the order, with duplicate values being overwritten:
 // - API (global; lowest precedence)
 // - transaction
 // - client
 // - invocation (highest precedence)
 func (c *Client) forTracking(ctx context.Context, evalCtx EvaluationContext) (Tracker, EvaluationContext) {
-	provider, _, apiCtx := c.api.ForEvaluation(c.metadata.domain)
+	provider, _, apiCtx, _ := c.api.ForEvaluation(c.metadata.domain)
 	evalCtx = mergeContexts(evalCtx, c.evaluationContext, TransactionContext(ctx), apiCtx)
 	trackingProvider, ok := provider.(Tracker)
 	if !ok {
 		trackingProvider = NoopProvider{}
 	}
-	return trackingProvider, evalCtx
+	return trackingProvider, evalCtx
+}
+
+// FlagExists reports whether the flag exists with the provider of the client's domain, without evaluating it. The
+// provider must implement FlagChecker, otherwise an error matching FlagCheckingUnsupportedError with errors.Is is
+// returned. Hooks are not run.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+func (c *Client) FlagExists(ctx context.Context, flag string) (bool, error) {
+	provider, _, _, _ := c.api.ForEvaluation(c.metadata.domain)
+	checker, ok := provider.(FlagChecker)
+	if !ok {
+		return false, fmt.Errorf("provider %s: %w", provider.Metadata().Name, FlagCheckingUnsupportedError)
+	}
+
+	switch c.State() {
+	case NotReadyState:
+		return false, ProviderNotReadyError
+	case FatalState:
+		return false, ProviderFatalError
+	}
+	if ctx.Err() != nil {
+		return false, context.Cause(ctx)
+	}
+	return checker.FlagExists(ctx, flag)
+}
+
+// ListFlags returns the keys of the flags of the provider of the client's domain. The provider must implement
+// FlagLister, otherwise an error matching FlagListingUnsupportedError with errors.Is is returned. Hooks are not run.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+func (c *Client) ListFlags(ctx context.Context) ([]string, error) {
+	provider, _, _, _ := c.api.ForEvaluation(c.metadata.domain)
+	lister, ok := provider.(FlagLister)
+	if !ok {
+		return nil, fmt.Errorf("provider %s: %w", provider.Metadata().Name, FlagListingUnsupportedError)
+	}
+
+	switch c.State() {
+	case NotReadyState:
+		return nil, ProviderNotReadyError
+	case FatalState:
+		return nil, ProviderFatalError
+	}
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
+	return lister.ListFlags(ctx)
+}
+
+// EvaluationMetadata evaluates the flag regardless of its type, returning only the flag metadata of the resolution,
+// e.g. to read the version of a flag. The flag is resolved as an object flag first, then as a flag of each of the other
+// types in turn while the provider reports a TYPE_MISMATCH, bypassing the evaluation cache. The metadata of a failed
+// resolution is returned along with its error.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) EvaluationMetadata(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (FlagMetadata, error) {
+	details, err := c.ObjectValueDetails(ctx, flag, nil, evalCtx, append(options, withAnyFlagType())...)
+	return details.FlagMetadata, err
+}
+
+// FlagRequest identifies a flag of a batch evaluation, along with its type and default value
+type FlagRequest struct {
+	Key          string
+	Type         Type
+	DefaultValue interface{}
+}
+
+// EvaluateBatch performs the evaluation of multiple flags, returning an evaluation details struct per flag in the
+// order of the requests, and the joined errors of all failed flag evaluations.
+//
+// Hooks run for each flag as they would for a single flag evaluation, and the flags are resolved within the provider
+// timeout, unless the context is done first, and served from the evaluation cache as single flags are. Providers
+// implementing BatchEvaluator resolve all flags passing their before hooks and missing from the cache in a single call,
+// other providers resolve the flags one at a time. The flags
+// are evaluated sequentially, unless the WithHookConcurrency option allows evaluating some of them in parallel.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - requests are the flags to evaluate, with their types and default values
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) EvaluateBatch(ctx context.Context, requests []FlagRequest, evalCtx EvaluationContext, options ...Option) ([]InterfaceEvaluationDetails, error) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	evalOptions := &EvaluationOptions{}
+	for _, option := range options {
+		option(evalOptions)
+	}
+
+	// ensure that the same provider & hooks are used across the batch to avoid unexpected behaviour
+	provider, globalHooks, globalCtx, enrichers := c.api.ForEvaluation(c.metadata.domain)
+	batchEvaluator, isBatchEvaluator := provider.(BatchEvaluator)
+
+	results := make([]InterfaceEvaluationDetails, len(requests))
+	errs := make([]error, len(requests))
+	evals := make([]*flagEvaluation, len(requests))
+	defer forEachBounded(evalOptions.hookConcurrency, len(evals), func(i int) {
+		if evals[i] != nil {
+			c.runFinallyStage(ctx, evals[i])
+		}
+	})
+
+	// the flattened contexts of the flags left to resolve by the batch evaluator, and the cache keys of the flags
+	pending := make([]*FlattenedContext, len(requests))
+	keys := make([]cacheKey, len(requests))
+	cacheable := make([]bool, len(requests))
+	forEachBounded(evalOptions.hookConcurrency, len(requests), func(i int) {
+		request := requests[i]
+		if err := validateFlagRequest(request); err != nil {
+			results[i], errs[i] = newEvaluationDetails(request.Key, request.Type, request.DefaultValue), err
+			return
+		}
+
+		eval := c.newFlagEvaluation(ctx, provider, globalHooks, globalCtx, enrichers, request.Key, request.Type, request.DefaultValue, evalCtx, *evalOptions)
+		if eval.err != nil {
+			evals[i] = eval
+			return
+		}
+
+		keys[i], cacheable[i] = c.cacheKey(eval, *evalOptions)
+		cached, hit := InterfaceResolutionDetail{}, false
+		if cacheable[i] {
+			cached, hit = c.cache.get(keys[i])
+		}
+		if hit && !c.cache.config.RunHooks {
+			eval.details.Value = cached.Value
+			eval.details.ResolutionDetail = cached.ResolutionDetail()
+			eval.details.FromCache = true
+			eval.measure()
+			results[i] = eval.details
+			return
+		}
+
+		evals[i] = eval
+		if !c.runBeforeStage(ctx, provider, eval) {
+			return
+		}
+		switch {
+		case eval.shortCircuit != nil:
+			c.runAfterStage(ctx, eval, checkResolutionType(request.Type, eval.shortCircuit.resolution()))
+		case hit:
+			eval.details.FromCache = true
+			c.runAfterStage(ctx, eval, cached)
+		case !isBatchEvaluator:
+			c.runAfterStage(ctx, eval, c.resolveEvaluation(ctx, provider, eval, keys[i], cacheable[i]))
+		default:
+			flatCtx := eval.providerContext(provider)
+			pending[i] = &flatCtx
+		}
+	})
+
+	var resolving []int
+	var batch []BatchFlagRequest
+	for i, flatCtx := range pending {
+		if flatCtx != nil {
+			resolving = append(resolving, i)
+			batch = append(batch, BatchFlagRequest{FlagRequest: requests[i], FlattenedContext: *flatCtx})
+		}
+	}
+
+	if len(batch) > 0 {
+		resolveCtx, cancel := withProviderTimeout(ctx, evalOptions.providerTimeout, evalOptions.strictProviderTimeout)
+		defer cancel()
+
+		var resolutions []InterfaceResolutionDetail
+		var panicErr *ResolutionError
+		if resolveCtx.Err() == nil {
+			resolutions, panicErr = recoverProvider(c, provider, *evalOptions, func() []InterfaceResolutionDetail {
+				return batchEvaluator.BatchEvaluation(resolveCtx, batch)
+			})
+		}
+		forEachBounded(evalOptions.hookConcurrency, len(resolving), func(j int) {
+			i := resolving[j]
+			var resolution InterfaceResolutionDetail
+			if resolveCtx.Err() != nil {
+				resolution = cancelledResolution(resolveCtx, requests[i].DefaultValue)
+			} else if panicErr != nil {
+				resolution = InterfaceResolutionDetail{
+					Value:                    requests[i].DefaultValue,
+					ProviderResolutionDetail: ProviderResolutionDetail{ResolutionError: *panicErr, Reason: ErrorReason},
+				}
+			} else if len(resolutions) != len(batch) {
+				resolution.ResolutionError = NewGeneralResolutionError(
+					fmt.Sprintf("provider returned %d resolutions for %d flags", len(resolutions), len(batch)))
+			} else {
+				resolution = checkResolutionType(requests[i].Type, resolutions[j])
+				if cacheable[i] && resolution.Error() == nil {
+					c.cacheResolution(keys[i], resolution)
+				}
+			}
+			c.runAfterStage(ctx, evals[i], resolution)
+		})
+	}
+
+	for i, eval := range evals {
+		if eval != nil {
+			eval.recoverWithFallback()
+			eval.measure()
+			results[i], errs[i] = eval.details, eval.err
+		}
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("flag %s: %w", requests[i].Key, errs[i])
+		}
+	}
+	return results, errors.Join(errs...)
 }
 
-func (c *Client) evaluate(
-	ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx EvaluationContext, options EvaluationOptions,
-) (InterfaceEvaluationDetails, error) {
-	evalDetails := InterfaceEvaluationDetails{
-		Value: defaultValue,
-		EvaluationDetails: EvaluationDetails{
-			FlagKey:  flag,
-			FlagType: flagType,
+// HookTraceEntry records a single hook stage invocation of an evaluation traced with EvaluateWithTrace
+type HookTraceEntry struct {
+	Hook     Hook
+	Stage    HookStage
+	Duration time.Duration
+	// Err is the error returned by the stage, including the HookTimeoutError or HookPanicError of the stage
+	Err error
+	// ContextChanges are the evaluation context attributes added or changed by a before hook, keyed by TargetingKey
+	// for the targeting key
+	ContextChanges map[string]interface{}
+	// Skipped reports that the stage was not invoked, as a previous stage of the hook abandoned on timeout was still
+	// running, see WithHookTimeout. Duration is then the time waited for the abandoned stage.
+	Skipped bool
+}
+
+// EvaluateWithTrace performs the evaluation of a flag like the other evaluation methods, additionally returning a
+// trace of the hook stage invocations of the evaluation, in order, e.g. to debug the behavior of hooks. Tracing is
+// purely observational, the evaluation and its hooks run as they would without it.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - request is the flag to evaluate, with its type and default value
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) EvaluateWithTrace(
+	ctx context.Context, request FlagRequest, evalCtx EvaluationContext, options ...Option,
+) (InterfaceEvaluationDetails, []HookTraceEntry, error) {
+	if err := validateFlagRequest(request); err != nil {
+		return newEvaluationDetails(request.Key, request.Type, request.DefaultValue), nil, err
+	}
+
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	evalOptions := &EvaluationOptions{}
+	for _, option := range options {
+		option(evalOptions)
+	}
+	trace := []HookTraceEntry{}
+	evalOptions.trace = &trace
+
+	details, err := c.evaluate(ctx, request.Key, request.Type, request.DefaultValue, evalCtx, *evalOptions)
+	return details, trace, err
+}
+
+// record appends the invocation of the hook stage to the trace of the evaluation, if it is traced
+func (e EvaluationOptions) record(h evaluationHook, stage HookStage, start time.Time, err error, changes map[string]interface{}) {
+	if e.trace == nil {
+		return
+	}
+	*e.trace = append(*e.trace, HookTraceEntry{
+		Hook:           h.hook,
+		Stage:          stage,
+		Duration:       since(start),
+		Err:            err,
+		ContextChanges: changes,
+	})
+}
+
+// recordSkipped appends the hook stage skipped after waiting since start to the trace of the evaluation, if it is
+// traced
+func (e EvaluationOptions) recordSkipped(h evaluationHook, stage HookStage, start time.Time) {
+	if e.trace == nil {
+		return
+	}
+	*e.trace = append(*e.trace, HookTraceEntry{Hook: h.hook, Stage: stage, Duration: since(start), Skipped: true})
+}
+
+// contextChanges returns the attributes of the evaluation context returned by a before hook which are missing from,
+// or differ from, the evaluation context given to the hook
+func contextChanges(given EvaluationContext, returned EvaluationContext) map[string]interface{} {
+	diff := DiffEvaluationContexts(given, returned)
+	changes := diff.Added
+	for key, change := range diff.Changed {
+		changes[key] = change.After
+	}
+	// the returned context is merged over the given one, so that it can neither remove attributes nor clear the
+	// targeting key
+	if diff.TargetingKey != nil && returned.targetingKey != "" {
+		changes[TargetingKey] = returned.targetingKey
+	}
+	return changes
+}
+
+// forEachBounded calls fn with each index below n, running at most concurrency calls in parallel, and returns once
+// all calls returned. A concurrency below 2 calls fn sequentially, in order.
+func forEachBounded(concurrency int, n int, fn func(i int)) {
+	if concurrency < 2 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// validateFlagRequest returns an error if the flag of a batch evaluation cannot be evaluated
+func validateFlagRequest(request FlagRequest) error {
+	if !utf8.Valid([]byte(request.Key)) {
+		return NewParseErrorResolutionError("flag key is not a UTF-8 encoded string")
+	}
+	if !hasFlagType(request.Type, request.DefaultValue) {
+		return NewTypeMismatchResolutionError(fmt.Sprintf("default value %v is not of flag type %s", request.DefaultValue, request.Type))
+	}
+	return nil
+}
+
+// checkResolutionType replaces a resolved value not matching the flag type with a TYPE_MISMATCH resolution error,
+// keeping the flag metadata of the resolution. Values of unknown flag types never match.
+func checkResolutionType(flagType Type, resolution InterfaceResolutionDetail) InterfaceResolutionDetail {
+	if resolution.Error() != nil || hasFlagType(flagType, resolution.Value) {
+		return resolution
+	}
+	return InterfaceResolutionDetail{
+		ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewTypeMismatchResolutionError(fmt.Sprintf("resolved value %v (%T) is not of flag type %s", resolution.Value, resolution.Value, flagType)),
+			Reason:          ErrorReason,
+			FlagMetadata:    resolution.FlagMetadata,
 		},
 	}
+}
+
+// hasFlagType reports whether the value is of the Go type evaluated for the flag type
+func hasFlagType(flagType Type, value interface{}) bool {
+	var ok bool
+	switch flagType {
+	case Boolean:
+		_, ok = value.(bool)
+	case String:
+		_, ok = value.(string)
+	case Float:
+		_, ok = value.(float64)
+	case Int:
+		_, ok = value.(int64)
+	case Object:
+		ok = true
+	}
+	return ok
+}
 
+func (c *Client) evaluate(
+	ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx EvaluationContext, options EvaluationOptions,
+) (InterfaceEvaluationDetails, error) {
 	if !utf8.Valid([]byte(flag)) {
-		return evalDetails, NewParseErrorResolutionError("flag key is not a UTF-8 encoded string")
+		return newEvaluationDetails(flag, flagType, defaultValue), NewParseErrorResolutionError("flag key is not a UTF-8 encoded string")
 	}
 
 	// ensure that the same provider & hooks are used across this transaction to avoid unexpected behaviour
-	provider, globalHooks, globalCtx := c.api.ForEvaluation(c.metadata.domain)
+	provider, globalHooks, globalCtx, enrichers := c.api.ForEvaluation(c.metadata.domain)
+	if options.provider != nil {
+		provider = options.provider
+	}
 
-	evalCtx = mergeContexts(evalCtx, c.evaluationContext, TransactionContext(ctx), globalCtx)                                  // API (global) -> transaction -> client -> invocation
-	apiClientInvocationProviderHooks := append(append(append(globalHooks, c.hooks...), options.hooks...), provider.Hooks()...) // API, Client, Invocation, Provider
-	providerInvocationClientApiHooks := append(append(append(provider.Hooks(), options.hooks...), c.hooks...), globalHooks...) // Provider, Invocation, Client, API
+	eval := c.newFlagEvaluation(ctx, provider, globalHooks, globalCtx, enrichers, flag, flagType, defaultValue, evalCtx, options)
+	if eval.err != nil {
+		eval.measure()
+		return eval.details, eval.err
+	}
 
-	var err error
-	hookCtx := HookContext{
-		flagKey:           flag,
-		flagType:          flagType,
-		defaultValue:      defaultValue,
-		clientMetadata:    c.metadata,
-		providerMetadata:  provider.Metadata(),
-		evaluationContext: evalCtx,
+	key, cacheable := c.cacheKey(eval, options)
+	cached, hit := InterfaceResolutionDetail{}, false
+	if cacheable {
+		cached, hit = c.cache.get(key)
+	}
+	if hit && !c.cache.config.RunHooks {
+		eval.details.Value = cached.Value
+		eval.details.ResolutionDetail = cached.ResolutionDetail()
+		eval.details.FromCache = true
+		eval.measure()
+		return eval.details, nil
 	}
 
-	defer func() {
-		c.finallyHooks(ctx, hookCtx, providerInvocationClientApiHooks, options)
-	}()
+	defer c.runFinallyStage(ctx, eval)
+
+	if c.runBeforeStage(ctx, provider, eval) {
+		resolution := cached
+		switch {
+		case eval.shortCircuit != nil:
+			// a before hook supplied the final resolution, neither the cache nor the provider are involved
+			resolution = checkResolutionType(flagType, eval.shortCircuit.resolution())
+		case options.forcedVariant != nil:
+			resolveCtx, cancel := withProviderTimeout(ctx, options.providerTimeout, options.strictProviderTimeout)
+			defer cancel()
+			flatCtx := eval.providerContext(provider)
+			resolution = checkResolutionType(flagType, c.resolveRecovering(provider, options, defaultValue, func() InterfaceResolutionDetail {
+				return forceVariant(resolveCtx, provider, flag, flagType, *options.forcedVariant, defaultValue, flatCtx)
+			}))
+		case !hit:
+			resolution = c.resolveEvaluation(ctx, provider, eval, key, cacheable)
+		default:
+			eval.details.FromCache = true
+		}
+		c.runAfterStage(ctx, eval, resolution)
+	}
+
+	eval.recoverWithFallback()
+	eval.measure()
+	return eval.details, eval.err
+}
+
+// resolveEvaluation resolves the flag of the evaluation with the provider, within the provider timeout of the
+// evaluation options and unless the context is done first, see resolveFlagUntilDone. Successful resolutions are stored
+// in the evaluation cache under the key if cacheable.
+func (c *Client) resolveEvaluation(
+	ctx context.Context, provider FeatureProvider, eval *flagEvaluation, key cacheKey, cacheable bool,
+) InterfaceResolutionDetail {
+	options, flag, flagType, defaultValue := eval.options, eval.hookCtx.flagKey, eval.hookCtx.flagType, eval.hookCtx.defaultValue
+	resolveCtx, cancel := withProviderTimeout(ctx, options.providerTimeout, options.strictProviderTimeout)
+	defer cancel()
+
+	flatCtx := eval.providerContext(provider)
+	resolution := resolveFlagUntilDone(resolveCtx, defaultValue, func() InterfaceResolutionDetail {
+		return c.resolveRecovering(provider, options, defaultValue, func() InterfaceResolutionDetail {
+			if options.anyFlagType {
+				return resolveAnyFlagType(resolveCtx, provider, flag, flatCtx)
+			}
+			return resolveFlag(resolveCtx, provider, flag, flagType, defaultValue, flatCtx)
+		})
+	})
+	if cacheable && resolution.Error() == nil {
+		c.cacheResolution(key, resolution)
+	}
+	return resolution
+}
+
+// cacheResolution stores the successful resolution in the evaluation cache, served with the CACHED reason
+func (c *Client) cacheResolution(key cacheKey, resolution InterfaceResolutionDetail) {
+	resolution.Reason = CachedReason
+	c.cache.set(key, resolution)
+}
+
+// cacheKey returns the evaluation cache key of the evaluation, reporting whether the evaluation may use the cache.
+// Evaluations are not cached if the provider is not ready to evaluate flags, is overridden, a variant is forced, or the
+// flag is resolved regardless of its type.
+func (c *Client) cacheKey(eval *flagEvaluation, options EvaluationOptions) (cacheKey, bool) {
+	if c.cache == nil || options.bypassCache || options.provider != nil || options.forcedVariant != nil || options.anyFlagType || c.State() == NotReadyState || c.State() == FatalState {
+		return cacheKey{}, false
+	}
+
+	hookCtx := eval.hookCtx
+	return newCacheKey(hookCtx.providerMetadata.Name, hookCtx.flagKey, hookCtx.flagType, hookCtx.defaultValue,
+		flattenContext(hookCtx.evaluationContext))
+}
+
+// flagEvaluation is the state of a single flag evaluation, carried across its hook stages
+type flagEvaluation struct {
+	provider          FeatureProvider
+	hookCtx           HookContext
+	options           EvaluationOptions
+	beforeStageHooks  []evaluationHook
+	afterStageHooks   []evaluationHook
+	errorStageHooks   []evaluationHook
+	finallyStageHooks []evaluationHook
+	details           InterfaceEvaluationDetails
+	err               error
+	shortCircuit      *ResolutionShortCircuit
+	// fallback is the value supplied by the first ErrorRecoveryHook recovering the evaluation from its error
+	fallback *interface{}
+	// preflattened is the flattened context handed to the provider as is, unless a before hook amends the context
+	preflattened FlattenedContext
+	start        time.Time
+}
+
+// fail fails the evaluation with an error occurring outside the provider resolution, e.g. in hooks or due to the
+// provider state, reflecting it in the reason, error code and error message of the details
+func (e *flagEvaluation) fail(err error) {
+	e.err = err
+	e.details.Reason = ErrorReason
+	e.details.ErrorCode = errorCode(err)
+	e.details.ErrorMessage = err.Error()
+}
+
+// recoverWithFallback replaces the failed outcome of the evaluation with the fallback supplied by an
+// ErrorRecoveryHook, if any
+func (e *flagEvaluation) recoverWithFallback() {
+	if e.err == nil || e.fallback == nil {
+		return
+	}
+	e.details.Value = *e.fallback
+	e.details.ResolutionDetail = ResolutionDetail{Reason: DefaultReason, FlagMetadata: e.details.FlagMetadata}
+	e.err = nil
+}
+
+// providerContext returns the flattened context to resolve the flag with, recording it in the hook context for the
+// after, error and finally hooks
+func (e *flagEvaluation) providerContext(provider FeatureProvider) FlattenedContext {
+	flatCtx := e.preflattened
+	if flatCtx == nil {
+		flatCtx = providerContext(provider, e.hookCtx.evaluationContext)
+	}
+	e.hookCtx.flattenedContext = flatCtx
+	return flatCtx
+}
+
+// measure sets the evaluation duration of the details, unless measurement is disabled
+func (e *flagEvaluation) measure() {
+	if !e.start.IsZero() {
+		e.details.EvaluationDuration = since(e.start)
+	}
+}
+
+func newEvaluationDetails(flag string, flagType Type, defaultValue interface{}) InterfaceEvaluationDetails {
+	return InterfaceEvaluationDetails{
+		Value: defaultValue,
+		EvaluationDetails: EvaluationDetails{
+			FlagKey:          flag,
+			FlagType:         flagType,
+			ResolutionDetail: ResolutionDetail{FlagMetadata: FlagMetadata{}},
+		},
+	}
+}
 
-        // bypass short-circuit logic for the Noop provider; it is essentially stateless and a "special case"
-	if _, ok := provider.(NoopProvider); !ok {
+func (c *Client) newFlagEvaluation(
+	ctx context.Context, provider FeatureProvider, globalHooks []Hook, globalCtx EvaluationContext, enrichers []ContextEnricher,
+	flag string, flagType Type, defaultValue interface{}, evalCtx EvaluationContext, options EvaluationOptions,
+) *flagEvaluation {
+	if options.preflattened != nil {
+		// the caller merged & flattened the contexts already
+		evalCtx = preflattenedContext(options.preflattened)
+	} else {
+		evalCtx = options.targetingKeyStrategy.merge(evalCtx, c.evaluationContext, TransactionContext(ctx), globalCtx) // API (global) -> transaction -> client -> invocation
+		for _, enrich := range enrichers {
+			evalCtx = mergeContexts(enrich(ctx, evalCtx), evalCtx) // enrichers override, but cannot remove, attributes
+		}
+	}
+	if options.mergedContext != nil {
+		*options.mergedContext = evalCtx
+	}
+	// client -> flag -> context -> invocation, call-site hints take precedence
+	if contextHints := ContextHookHints(ctx); len(contextHints.mapOfHints) > 0 {
+		options.hookHints = contextHints.Merge(options.hookHints)
+	}
+	if flagHints, ok := c.flagHints[flag]; ok {
+		options.hookHints = flagHints.Merge(options.hookHints)
+	}
+	if len(c.hookHints.mapOfHints) > 0 {
+		options.hookHints = c.hookHints.Merge(options.hookHints)
+	}
+	// each hook is bound to its own HookData, shared by all of its stages in this evaluation
+	apiHooks, clientHooks := bindHookData(globalHooks), bindHookData(c.hooks)
+	invocationHooks, providerHooks := bindHookData(options.hooks), bindHookData(provider.Hooks())
+	apiClientInvocationProviderHooks := sortHooksByPriority(concatHooks(apiHooks, clientHooks, invocationHooks, providerHooks), false) // API, Client, Invocation, Provider
+	providerInvocationClientApiHooks := sortHooksByPriority(concatHooks(providerHooks, invocationHooks, clientHooks, apiHooks), true)  // Provider, Invocation, Client, API
+
+	var start time.Time
+	if !options.skipDuration {
+		start = CurrentClock().Now()
+	}
+
+	eval := &flagEvaluation{
+		provider: provider,
+		hookCtx: HookContext{
+			flagKey:           flag,
+			flagType:          flagType,
+			defaultValue:      defaultValue,
+			clientMetadata:    c.metadata,
+			providerMetadata:  providerMetadata(provider),
+			evaluationContext: evalCtx,
+		},
+		options:           options,
+		beforeStageHooks:  hooksForStage(apiClientInvocationProviderHooks, BeforeStage),
+		afterStageHooks:   hooksForStage(providerInvocationClientApiHooks, AfterStage),
+		errorStageHooks:   hooksForStage(providerInvocationClientApiHooks, ErrorStage),
+		finallyStageHooks: hooksForStage(providerInvocationClientApiHooks, FinallyStage),
+		details:           newEvaluationDetails(flag, flagType, defaultValue),
+		preflattened:      options.preflattened,
+		start:             start,
+	}
+	if hookCount := len(apiClientInvocationProviderHooks); options.maxHooks > 0 && hookCount > options.maxHooks {
+		// none of the hooks run, so that a runaway hook chain does no work at all
+		eval.beforeStageHooks, eval.afterStageHooks, eval.errorStageHooks, eval.finallyStageHooks = nil, nil, nil, nil
+		resolutionErr := NewGeneralResolutionError(
+			fmt.Sprintf("evaluation of flag %s has %d hooks, exceeding the limit of %d", flag, hookCount, options.maxHooks))
+		resolutionErr.cause = HookLimitExceededError
+		eval.fail(resolutionErr)
+	}
+	return eval
+}
+
+// runBeforeStage short circuits the evaluation if the provider is not ready and runs the before hooks, reporting
+// whether the evaluation should proceed with the resolution of the flag, unless a before hook supplied it
+func (c *Client) runBeforeStage(ctx context.Context, provider FeatureProvider, eval *flagEvaluation) bool {
+	// bypass short-circuit logic for the Noop provider; it is essentially stateless and a "special case"
+	// the state of the client is that of the registered provider, the lifecycle of an override is the caller's
+	if _, ok := provider.(NoopProvider); !ok && eval.options.provider == nil {
 		// short circuit if provider is in NOT READY state
 		if c.State() == NotReadyState {
-			c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, ProviderNotReadyError, options)
-			return evalDetails, ProviderNotReadyError
+			hookErr := c.errorHooks(ctx, eval, ProviderNotReadyError)
+			eval.fail(joinHookErrors(ProviderNotReadyError, hookErr))
+			return false
 		}
 
 		// short circuit if provider is in FATAL state
 		if c.State() == FatalState {
-			c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, ProviderFatalError, options)
-			return evalDetails, ProviderFatalError
+			hookErr := c.errorHooks(ctx, eval, ProviderFatalError)
+			eval.fail(joinHookErrors(ProviderFatalError, hookErr))
+			return false
 		}
 	}
 
-	evalCtx, err = c.beforeHooks(ctx, hookCtx, apiClientInvocationProviderHooks, evalCtx, options)
-	hookCtx.evaluationContext = evalCtx
+	amended, shortCircuit, err := c.beforeHooks(ctx, eval.hookCtx, eval.beforeStageHooks, eval.hookCtx.evaluationContext, eval.options)
+	if amended != nil {
+		eval.hookCtx.evaluationContext = *amended
+		// the amended context is flattened again for the provider
+		eval.preflattened = nil
+	}
 	if err != nil {
-		err = fmt.Errorf("before hook: %w", err)
-		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
-		return evalDetails, err
+		c.emitHookError(eval, BeforeStage, err)
+		// the error hooks receive the error returned to the caller, joined with the failures of the error hooks
+		hookStageErr := newHookResolutionError(BeforeStage, err)
+		hookErr := c.errorHooks(ctx, eval, hookStageErr)
+		eval.fail(joinHookErrors(hookStageErr, hookErr))
+		return false
 	}
 
-	flatCtx := flattenContext(evalCtx)
+	if err := c.validateContext(provider, eval.hookCtx.evaluationContext); err != nil {
+		hookErr := c.errorHooks(ctx, eval, err)
+		eval.fail(joinHookErrors(err, hookErr))
+		return false
+	}
+	eval.shortCircuit = shortCircuit
+
+	return true
+}
+
+// validateContext validates the evaluation context amended by the before hooks with the client's targeting key
+// validator and against the fields required by the provider, if it implements ContextRequirements
+func (c *Client) validateContext(provider FeatureProvider, evalCtx EvaluationContext) error {
+	if c.validateTargetingKey != nil {
+		if err := c.validateTargetingKey(evalCtx.TargetingKey()); err != nil {
+			resolutionErr := NewTargetingKeyMissingResolutionError(fmt.Sprintf("invalid targeting key: %v", err))
+			resolutionErr.cause = err
+			return resolutionErr
+		}
+	}
+
+	requirements, ok := provider.(ContextRequirements)
+	if !ok {
+		return nil
+	}
+	for _, field := range requirements.RequiredContextFields() {
+		switch {
+		case field == TargetingKey && evalCtx.TargetingKey() == "":
+			return NewTargetingKeyMissingResolutionError("the provider requires a targeting key")
+		case field != TargetingKey && evalCtx.Attribute(field) == nil:
+			return NewInvalidContextResolutionError(fmt.Sprintf("the provider requires the evaluation context field %s", field))
+		}
+	}
+	return nil
+}
+
+// runAfterStage completes the evaluation with the provider's resolution, running the error hooks if the resolution
+// failed and the after hooks otherwise
+func (c *Client) runAfterStage(ctx context.Context, eval *flagEvaluation, resolution InterfaceResolutionDetail) {
+	err := resolution.Error()
+	if err != nil {
+		err = fmt.Errorf("error code: %w", err)
+		hookErr := c.errorHooks(ctx, eval, err)
+		eval.details.ResolutionDetail = resolution.ResolutionDetail()
+		eval.details.Reason = ErrorReason
+		eval.err = joinHookErrors(err, hookErr)
+		return
+	}
+	eval.details.Value = resolution.Value
+	eval.details.ResolutionDetail = resolution.ResolutionDetail()
+
+	if err := c.afterHooks(ctx, eval.hookCtx, eval.afterStageHooks, eval.details, eval.options); err != nil {
+		c.emitHookError(eval, AfterStage, err)
+		// the error hooks receive the error returned to the caller, joined with the failures of the error hooks
+		hookStageErr := newHookResolutionError(AfterStage, err)
+		hookErr := c.errorHooks(ctx, eval, hookStageErr)
+		// the resolved value is rejected, the evaluation returns the default value
+		eval.details.Value = eval.hookCtx.defaultValue
+		eval.fail(joinHookErrors(hookStageErr, hookErr))
+	}
+}
+
+// emitHookError emits a HookError event on behalf of the provider for the failed hook stage, if enabled by the options
+func (c *Client) emitHookError(eval *flagEvaluation, stage HookStage, err error) {
+	if !eval.options.hookErrorEvents {
+		return
+	}
+	c.clientEventing.emitProviderEvent(eval.provider, Event{
+		ProviderName: eval.provider.Metadata().Name,
+		EventType:    HookError,
+		ProviderEventDetails: ProviderEventDetails{
+			Message:   err.Error(),
+			ErrorCode: GeneralCode,
+			EventMetadata: map[string]interface{}{
+				HookErrorFlagKey:  eval.hookCtx.flagKey,
+				HookErrorStageKey: stage.String(),
+			},
+		},
+	})
+}
+
+// runFinallyStage runs the finally hooks with the outcome of the evaluation
+func (c *Client) runFinallyStage(ctx context.Context, eval *flagEvaluation) {
+	c.finallyHooks(ctx, eval.hookCtx, eval.finallyStageHooks, finallyDetails(eval.details, eval.err), eval.options)
+}
+
+// withProviderTimeout bounds the context of the provider's resolution with the timeout, unless the timeout is disabled
+// or the context already has a deadline and the timeout is not strict. The context is cancelled with
+// ProviderTimeoutError as cause on timeout.
+func withProviderTimeout(ctx context.Context, timeout time.Duration, strict bool) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok && !strict {
+		return ctx, func() {}
+	}
+	return withTimeoutCause(ctx, timeout, ProviderTimeoutError)
+}
+
+// resolveFlagUntilDone resolves the flag with resolve unless the context is done before or while the provider resolves
+// it, in which case the resolution fails with a general error caused by the context's cancellation cause. Providers are
+// expected to honor the cancellation of the context: only once the deadline of a context passes is a provider still
+// resolving the flag left to complete in the background. The flag is then resolved in another goroutine, resolve is
+// expected to recover the panics of the provider, see resolveRecovering, as they are not propagated to the caller.
+func resolveFlagUntilDone(ctx context.Context, defaultValue interface{}, resolve func() InterfaceResolutionDetail) InterfaceResolutionDetail {
+	if ctx.Err() != nil {
+		return cancelledResolution(ctx, defaultValue)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		resolution := resolve()
+		if ctx.Err() != nil {
+			return cancelledResolution(ctx, defaultValue)
+		}
+		return resolution
+	}
+
+	done := make(chan InterfaceResolutionDetail, 1)
+	go func() {
+		done <- resolve()
+	}()
+
+	select {
+	case resolution := <-done:
+		return resolution
+	case <-ctx.Done():
+		return cancelledResolution(ctx, defaultValue)
+	}
+}
+
+// forceVariant resolves the flag to the given variant with the provider, failing with a GENERAL error if the provider
+// does not implement VariantForcer
+func forceVariant(
+	ctx context.Context, provider FeatureProvider, flag string, flagType Type, variant string, defaultValue interface{}, flatCtx FlattenedContext,
+) InterfaceResolutionDetail {
+	forcer, ok := provider.(VariantForcer)
+	if !ok {
+		resolutionErr := NewGeneralResolutionError(fmt.Sprintf("provider %s does not support forcing variants", provider.Metadata().Name))
+		resolutionErr.cause = VariantForcingUnsupportedError
+		return InterfaceResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: resolutionErr,
+				Reason:          ErrorReason,
+			},
+		}
+	}
+	if ctx.Err() != nil {
+		return cancelledResolution(ctx, defaultValue)
+	}
+	return forcer.ForceVariant(ctx, flag, flagType, variant, defaultValue, flatCtx)
+}
+
+func cancelledResolution(ctx context.Context, defaultValue interface{}) InterfaceResolutionDetail {
+	cause := context.Cause(ctx)
+	resolutionErr := NewGeneralResolutionError(fmt.Sprintf("evaluation cancelled: %v", cause))
+	resolutionErr.cause = cause
+	return InterfaceResolutionDetail{
+		Value: defaultValue,
+		ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: resolutionErr,
+			Reason:          ErrorReason,
+		},
+	}
+}
+
+// resolveFlag resolves the flag with the provider method matching the flag type
+func resolveFlag(
+	ctx context.Context, provider FeatureProvider, flag string, flagType Type, defaultValue interface{}, flatCtx FlattenedContext,
+) InterfaceResolutionDetail {
 	var resolution InterfaceResolutionDetail
 	switch flagType {
 	case Object:
@@ -762,25 +2444,40 @@ func (c *Client) evaluate(
 		resolution.ProviderResolutionDetail = res.ProviderResolutionDetail
 		resolution.Value = res.Value
 	}
+	return resolution
+}
 
-	err = resolution.Error()
-	if err != nil {
-		err = fmt.Errorf("error code: %w", err)
-		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
-		evalDetails.ResolutionDetail = resolution.ResolutionDetail()
-		evalDetails.Reason = ErrorReason
-		return evalDetails, err
+// resolveAnyFlagType resolves the flag regardless of its type with the zero value of the flag type as default, as an
+// object flag first, then as a flag of each of the other types in turn while the provider reports a TYPE_MISMATCH
+func resolveAnyFlagType(ctx context.Context, provider FeatureProvider, flag string, flatCtx FlattenedContext) InterfaceResolutionDetail {
+	var resolution InterfaceResolutionDetail
+	for _, typed := range []struct {
+		flagType     Type
+		defaultValue interface{}
+	}{{Object, nil}, {Boolean, false}, {String, ""}, {Float, 0.0}, {Int, int64(0)}} {
+		resolution = resolveFlag(ctx, provider, flag, typed.flagType, typed.defaultValue, flatCtx)
+		if resolution.ResolutionDetail().ErrorCode != TypeMismatchCode {
+			break
+		}
 	}
-	evalDetails.Value = resolution.Value
-	evalDetails.ResolutionDetail = resolution.ResolutionDetail()
+	return resolution
+}
 
-	if err := c.afterHooks(ctx, hookCtx, providerInvocationClientApiHooks, evalDetails, options); err != nil {
-		err = fmt.Errorf("after hook: %w", err)
-		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
-		return evalDetails, err
+// providerContext flattens the evaluation context for the provider, flattening nested maps into dotted keys if the
+// provider implements ContextFlattening
+func providerContext(provider FeatureProvider, evalCtx EvaluationContext) FlattenedContext {
+	flatCtx := flattenContext(evalCtx)
+	if flattening, ok := provider.(ContextFlattening); ok && flattening.FlattenNestedContext() {
+		return FlattenNested(flatCtx)
 	}
+	return flatCtx
+}
 
-	return evalDetails, nil
+// preflattenedContext returns the evaluation context of a flattened context, taking the targeting key from its
+// TargetingKey attribute. The attributes are shared with the flattened context, which the caller must not modify.
+func preflattenedContext(flatCtx FlattenedContext) EvaluationContext {
+	targetingKey, _ := flatCtx[TargetingKey].(string)
+	return EvaluationContext{targetingKey: targetingKey, attributes: flatCtx}
 }
 
 func flattenContext(evalCtx EvaluationContext) FlattenedContext {
@@ -794,27 +2491,73 @@ func flattenContext(evalCtx EvaluationContext) FlattenedContext {
 	return flatCtx
 }
 
+// beforeHooks runs the before stage of the hooks, stopping at the first hook failing or supplying the final resolution.
+// It returns the evaluation context amended by the hooks, nil if no hook amended it.
 func (c *Client) beforeHooks(
-	ctx context.Context, hookCtx HookContext, hooks []Hook, evalCtx EvaluationContext, options EvaluationOptions,
-) (EvaluationContext, error) {
-	for _, hook := range hooks {
-		resultEvalCtx, err := hook.Before(ctx, hookCtx, options.hookHints)
-		if resultEvalCtx != nil {
-			hookCtx.evaluationContext = *resultEvalCtx
+	ctx context.Context, hookCtx HookContext, hooks []evaluationHook, evalCtx EvaluationContext, options EvaluationOptions,
+) (*EvaluationContext, *ResolutionShortCircuit, error) {
+	type beforeResult struct {
+		evalCtx      *EvaluationContext
+		shortCircuit *ResolutionShortCircuit
+	}
+	var amended bool
+	amendedContext := func() *EvaluationContext {
+		if !amended {
+			return nil
+		}
+		merged := mergeContexts(hookCtx.evaluationContext, evalCtx)
+		return &merged
+	}
+
+	for _, h := range hooks {
+		if !h.acquire(BeforeStage, options) {
+			continue
+		}
+		stageHookCtx := hookCtx
+		stageHookCtx.hookData = h.data
+		start := CurrentClock().Now()
+		result, err := runHookStage(ctx, BeforeStage, options, func(ctx context.Context) (beforeResult, error) {
+			defer h.release()
+			if shortCircuiting, ok := h.hook.(ShortCircuitHook); ok {
+				resultEvalCtx, shortCircuit, err := shortCircuiting.BeforeWithShortCircuit(ctx, stageHookCtx, h.hints(options.hookHints))
+				return beforeResult{evalCtx: resultEvalCtx, shortCircuit: shortCircuit}, err
+			}
+			resultEvalCtx, err := h.hook.Before(ctx, stageHookCtx, h.hints(options.hookHints))
+			return beforeResult{evalCtx: resultEvalCtx}, err
+		})
+		var changes map[string]interface{}
+		if result.evalCtx != nil {
+			changes = contextChanges(hookCtx.evaluationContext, *result.evalCtx)
+			hookCtx.evaluationContext = *result.evalCtx
+			amended = true
 		}
+		options.record(h, BeforeStage, start, err, changes)
 		if err != nil {
-			return mergeContexts(hookCtx.evaluationContext, evalCtx), err
+			return amendedContext(), nil, err
+		}
+		if result.shortCircuit != nil {
+			return amendedContext(), result.shortCircuit, nil
 		}
 	}
 
-	return mergeContexts(hookCtx.evaluationContext, evalCtx), nil
+	return amendedContext(), nil, nil
 }
 
 func (c *Client) afterHooks(
-	ctx context.Context, hookCtx HookContext, hooks []Hook, evalDetails InterfaceEvaluationDetails, options EvaluationOptions,
+	ctx context.Context, hookCtx HookContext, hooks []evaluationHook, evalDetails InterfaceEvaluationDetails, options EvaluationOptions,
 ) error {
-	for _, hook := range hooks {
-		if err := hook.After(ctx, hookCtx, evalDetails, options.hookHints); err != nil {
+	for _, h := range hooks {
+		if !h.acquire(AfterStage, options) {
+			continue
+		}
+		hookCtx.hookData = h.data
+		start := CurrentClock().Now()
+		_, err := runHookStage(ctx, AfterStage, options, func(ctx context.Context) (struct{}, error) {
+			defer h.release()
+			return struct{}{}, h.hook.After(ctx, hookCtx, evalDetails, h.hints(options.hookHints))
+		})
+		options.record(h, AfterStage, start, err, nil)
+		if err != nil {
 			return err
 		}
 	}
@@ -822,16 +2565,203 @@ func (c *Client) afterHooks(
 	return nil
 }
 
-func (c *Client) errorHooks(ctx context.Context, hookCtx HookContext, hooks []Hook, err error, options EvaluationOptions) {
-	for _, hook := range hooks {
-		hook.Error(ctx, hookCtx, err, options.hookHints)
+// errorHooks runs the error stage of all hooks, returning the joined failures of the error hooks, if any. The first
+// fallback of an ErrorRecoveryHook matching the flag type is kept for the evaluation to recover with.
+func (c *Client) errorHooks(ctx context.Context, eval *flagEvaluation, err error) error {
+	hookCtx, options := eval.hookCtx, eval.options
+	var hookErrs []error
+	for _, h := range eval.errorStageHooks {
+		if !h.acquire(ErrorStage, options) {
+			continue
+		}
+		hookCtx.hookData = h.data
+		// an error hook exceeding the hook timeout is abandoned, the remaining error hooks still run
+		start := CurrentClock().Now()
+		fallback, hookErr := runHookStage(ctx, ErrorStage, options, func(ctx context.Context) (*interface{}, error) {
+			defer h.release()
+			if recovering, ok := h.hook.(ErrorRecoveryHook); ok {
+				if value, recovered := recovering.ErrorWithFallback(ctx, hookCtx, err, h.hints(options.hookHints)); recovered {
+					return &value, nil
+				}
+				return nil, nil
+			}
+			h.hook.Error(ctx, hookCtx, err, h.hints(options.hookHints))
+			return nil, nil
+		})
+		options.record(h, ErrorStage, start, hookErr, nil)
+		if hookErr == nil && fallback != nil && eval.fallback == nil && hasFlagType(hookCtx.flagType, *fallback) {
+			eval.fallback = fallback
+		}
+		if hookErr != nil {
+			hookErrs = append(hookErrs, hookErr)
+		}
+	}
+	return errors.Join(hookErrs...)
+}
+
+// joinHookErrors joins the failures of the error hooks to the evaluation error, if there are any
+func joinHookErrors(err error, hookErr error) error {
+	if hookErr == nil {
+		return err
+	}
+	return errors.Join(err, hookErr)
+}
+
+func (c *Client) finallyHooks(
+	ctx context.Context, hookCtx HookContext, hooks []evaluationHook, evalDetails InterfaceEvaluationDetails, options EvaluationOptions,
+) {
+	for _, h := range hooks {
+		if !h.acquire(FinallyStage, options) {
+			continue
+		}
+		hookCtx.hookData = h.data
+		// a finally hook exceeding the hook timeout is abandoned, the remaining finally hooks still run
+		start := CurrentClock().Now()
+		_, hookErr := runHookStage(ctx, FinallyStage, options, func(ctx context.Context) (struct{}, error) {
+			defer h.release()
+			if withDetails, ok := h.hook.(FinallyWithDetailsHook); ok {
+				withDetails.FinallyWithDetails(ctx, hookCtx, evalDetails, h.hints(options.hookHints))
+			} else {
+				h.hook.Finally(ctx, hookCtx, h.hints(options.hookHints))
+			}
+			return struct{}{}, nil
+		})
+		options.record(h, FinallyStage, start, hookErr, nil)
+	}
+}
+
+// finallyDetails returns the evaluation details given to the Finally stage. Errors occurring outside the provider
+// resolution (e.g. in hooks or due to the provider state) are reflected in the reason, error code and error message.
+func finallyDetails(evalDetails InterfaceEvaluationDetails, err error) InterfaceEvaluationDetails {
+	if err == nil {
+		return evalDetails
+	}
+
+	evalDetails.Reason = ErrorReason
+	if evalDetails.ErrorCode == "" {
+		evalDetails.ErrorCode = errorCode(err)
+		evalDetails.ErrorMessage = err.Error()
+	}
+	return evalDetails
+}
+
+// errorCode maps an evaluation error to its ErrorCode, defaulting to GENERAL
+func errorCode(err error) ErrorCode {
+	var resolutionErr ResolutionError
+	switch {
+	case errors.As(err, &resolutionErr):
+		return resolutionErr.code
+	case errors.Is(err, ProviderNotReadyError):
+		return ProviderNotReadyCode
+	case errors.Is(err, ProviderFatalError):
+		return ProviderFatalCode
+	default:
+		return GeneralCode
+	}
+}
+
+// runHookStage invokes a single hook stage, bounded by the hook timeout of the evaluation options if one is set.
+// When the timeout elapses the stage's context is cancelled and the invocation is abandoned, returning an error
+// wrapping HookTimeoutError. If the context of the evaluation is done first, the invocation is abandoned likewise and
+// the error wraps the cause of the context instead. The abandoned invocation keeps running in the background until it
+// returns.
+// Unless disabled by the evaluation options, a panic of the stage is recovered and returned as a HookPanicError.
+func runHookStage[T any](
+	ctx context.Context, stage HookStage, options EvaluationOptions, invoke func(ctx context.Context) (T, error),
+) (T, error) {
+	if options.HookPanicRecovery() {
+		invoke = recoverHookStage(stage, invoke)
+	}
+
+	if options.hookTimeout <= 0 {
+		return invoke(ctx)
+	}
+
+	timeoutErr := fmt.Errorf("%w: %s stage did not complete within %s: %w", HookTimeoutError, stage, options.hookTimeout, context.DeadlineExceeded)
+	ctx, cancel := withTimeoutCause(ctx, options.hookTimeout, timeoutErr)
+	defer cancel()
+
+	type stageResult struct {
+		value T
+		err   error
+	}
+	done := make(chan stageResult, 1)
+	go func() {
+		value, err := invoke(ctx)
+		done <- stageResult{value: value, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero T
+		if cause := context.Cause(ctx); cause != timeoutErr {
+			// the context of the evaluation is done before the hook timeout elapsed
+			return zero, fmt.Errorf("%s stage did not complete: %w", stage, cause)
+		}
+		return zero, timeoutErr
+	}
+}
+
+// recoverHookStage wraps the invocation of a hook stage, converting a panic into a HookPanicError
+func recoverHookStage[T any](stage HookStage, invoke func(ctx context.Context) (T, error)) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (value T, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				var zero T
+				value, err = zero, &HookPanicError{Stage: stage, Value: r, Stack: debug.Stack()}
+			}
+		}()
+		return invoke(ctx)
+	}
+}
+
+// recoverProvider calls the provider with resolve, recovering a panic of the provider unless disabled by the options.
+// A recovered panic is reported with a PROVIDER_ERROR event on behalf of the provider, and returned as a GENERAL
+// resolution error caused by a ProviderPanicError.
+func recoverProvider[T any](
+	c *Client, provider FeatureProvider, options EvaluationOptions, resolve func() T,
+) (result T, panicErr *ResolutionError) {
+	if !options.ProviderPanicRecovery() {
+		return resolve(), nil
 	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			cause := &ProviderPanicError{Provider: provider.Metadata().Name, Value: r, Stack: debug.Stack()}
+			resolutionErr := NewGeneralResolutionError(cause.Error())
+			resolutionErr.cause = cause
+			panicErr = &resolutionErr
+			c.clientEventing.emitProviderEvent(provider, Event{
+				ProviderName: cause.Provider,
+				EventType:    ProviderError,
+				ProviderEventDetails: ProviderEventDetails{
+					Message:   cause.Error(),
+					ErrorCode: GeneralCode,
+				},
+			})
+		}
+	}()
+	return resolve(), nil
 }
 
-func (c *Client) finallyHooks(ctx context.Context, hookCtx HookContext, hooks []Hook, options EvaluationOptions) {
-	for _, hook := range hooks {
-		hook.Finally(ctx, hookCtx, options.hookHints)
+// resolveRecovering resolves the flag with resolve, see recoverProvider, returning the default value with the
+// resolution error of a recovered panic
+func (c *Client) resolveRecovering(
+	provider FeatureProvider, options EvaluationOptions, defaultValue interface{}, resolve func() InterfaceResolutionDetail,
+) InterfaceResolutionDetail {
+	resolution, panicErr := recoverProvider(c, provider, options, resolve)
+	if panicErr != nil {
+		return InterfaceResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: *panicErr,
+				Reason:          ErrorReason,
+			},
+		}
 	}
+	return resolution
 }
 
 // merges attributes from the given EvaluationContexts with the nth EvaluationContext taking precedence in case
@@ -1,10 +1,16 @@
 package openfeature
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,8 +33,8 @@ func hydratedMocksForClientTests(t *testing.T, expectedEvaluations int) clientMo
 
 	mockProvider.EXPECT().Metadata().AnyTimes()
 	mockProvider.EXPECT().Hooks().AnyTimes()
-	mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Times(expectedEvaluations).DoAndReturn(func(_ string) (*MockFeatureProvider, []Hook, EvaluationContext) {
-		return mockProvider, nil, EvaluationContext{}
+	mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Times(expectedEvaluations).DoAndReturn(func(_ string) (*MockFeatureProvider, []Hook, EvaluationContext, []ContextEnricher) {
+		return mockProvider, nil, EvaluationContext{}, nil
 	})
 
 	return clientMocks{
@@ -870,6 +876,25 @@ func TestTrack(t *testing.T) {
 				return provider
 			},
 		},
+		"targeting key of the highest precedence context": {
+			eventName: "example-event",
+			inCtx: inputCtx{
+				api:        EvaluationContext{targetingKey: "api"},
+				txn:        EvaluationContext{targetingKey: "txn"},
+				client:     EvaluationContext{targetingKey: "client"},
+				invocation: EvaluationContext{},
+			},
+			outCtx: EvaluationContext{targetingKey: "client", attributes: map[string]interface{}{}},
+			provider: func(tc *testcase, mockProvider *MockFeatureProvider) FeatureProvider {
+				provider := &mockTrackingProvider{
+					MockTracker:         NewMockTracker(mockProvider.ctrl),
+					MockFeatureProvider: mockProvider,
+				}
+				provider.MockTracker.EXPECT().Track(gomock.Any(), gomock.Any(), tc.outCtx, TrackingEventDetails{}).Times(1)
+
+				return provider
+			},
+		},
 		"do no-op if Provider do not implement Tracker": {
 			inCtx:     inputCtx{},
 			eventName: "example-event",
@@ -889,8 +914,8 @@ func TestTrack(t *testing.T) {
 
 			provider := test.provider(test, mocks.providerAPI)
 
-			mocks.evaluationAPI.EXPECT().ForEvaluation("test-client").AnyTimes().DoAndReturn(func(_ string) (FeatureProvider, []Hook, EvaluationContext) {
-				return provider, nil, test.inCtx.api
+			mocks.evaluationAPI.EXPECT().ForEvaluation("test-client").AnyTimes().DoAndReturn(func(_ string) (FeatureProvider, []Hook, EvaluationContext, []ContextEnricher) {
+				return provider, nil, test.inCtx.api, nil
 			})
 			client.evaluationContext = test.inCtx.client
 			ctx := WithTransactionContext(context.Background(), test.inCtx.txn)
@@ -1181,6 +1206,54 @@ func TestFlagMetadataAccessors(t *testing.T) {
 			t.Error("unexpected error value", err)
 		}
 	})
+
+	t.Run("typed errors", func(t *testing.T) {
+		metadata := FlagMetadata{"version": "v1"}
+
+		getters := map[string]func(key string) error{
+			"string": func(key string) error { _, err := metadata.GetString(key); return err },
+			"bool":   func(key string) error { _, err := metadata.GetBool(key); return err },
+			"int":    func(key string) error { _, err := metadata.GetInt(key); return err },
+			"float":  func(key string) error { _, err := metadata.GetFloat(key); return err },
+		}
+		for name, get := range getters {
+			if err := get("missing"); !errors.Is(err, FlagMetadataKeyNotFoundError) {
+				t.Errorf("%s: expected %v for a missing key, got %v", name, FlagMetadataKeyNotFoundError, err)
+			}
+			if name == "string" {
+				continue
+			}
+			if err := get("version"); !errors.Is(err, FlagMetadataTypeMismatchError) {
+				t.Errorf("%s: expected %v for a string value, got %v", name, FlagMetadataTypeMismatchError, err)
+			}
+		}
+	})
+
+	t.Run("numeric coercion", func(t *testing.T) {
+		var metadata FlagMetadata
+		if err := json.Unmarshal([]byte(`{"version": 3, "ratio": 0.5, "big": 1e20}`), &metadata); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		version, err := metadata.GetInt("version")
+		if err != nil || version != 3 {
+			t.Errorf("expected the JSON number to be read as integer 3, got %d, %v", version, err)
+		}
+		if _, err := metadata.GetInt("ratio"); !errors.Is(err, FlagMetadataTypeMismatchError) {
+			t.Errorf("expected a fractional number not to be read as integer, got %v", err)
+		}
+		if _, err := metadata.GetInt("big"); !errors.Is(err, FlagMetadataTypeMismatchError) {
+			t.Errorf("expected an imprecise number not to be read as integer, got %v", err)
+		}
+
+		integers := FlagMetadata{"count": int64(7), "unsigned": uint8(7)}
+		for key := range integers {
+			count, err := integers.GetFloat(key)
+			if err != nil || count != 7 {
+				t.Errorf("expected %s to be read as float 7, got %f, %v", key, count, err)
+			}
+		}
+	})
 }
 
 // The client MUST define a provider status accessor which indicates the readiness of the associated provider.
@@ -1457,3 +1530,1611 @@ func TestRequirement_5_3_5(t *testing.T) {
 	}, time.Second, 100*time.Millisecond, "expected client to report FATAL state")
 
 }
+
+func TestGetValue(t *testing.T) {
+	type config struct {
+		Color string `json:"color"`
+		Size  int    `json:"size"`
+	}
+	defaultConfig := config{Color: "blue", Size: 1}
+
+	tests := map[string]struct {
+		resolved    InterfaceResolutionDetail
+		expected    config
+		expectedErr ErrorCode
+	}{
+		"value of the requested type is returned as is": {
+			resolved: InterfaceResolutionDetail{Value: config{Color: "red", Size: 2}},
+			expected: config{Color: "red", Size: 2},
+		},
+		"structured value is decoded": {
+			resolved: InterfaceResolutionDetail{Value: map[string]interface{}{"color": "green", "size": 3.0}},
+			expected: config{Color: "green", Size: 3},
+		},
+		"non-convertible value returns the default": {
+			resolved:    InterfaceResolutionDetail{Value: "not a config"},
+			expected:    defaultConfig,
+			expectedErr: TypeMismatchCode,
+		},
+		"resolution error returns the default": {
+			resolved: InterfaceResolutionDetail{
+				ProviderResolutionDetail: ProviderResolutionDetail{ResolutionError: NewFlagNotFoundResolutionError("not found")},
+			},
+			expected:    defaultConfig,
+			expectedErr: FlagNotFoundCode,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mocks := hydratedMocksForClientTests(t, 1)
+			client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+			mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "foo", defaultConfig, gomock.Any()).Return(test.resolved)
+
+			value, err := GetValue(context.Background(), client, "foo", defaultConfig, EvaluationContext{})
+			if value != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, value)
+			}
+
+			if test.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), string(test.expectedErr)) {
+				t.Errorf("expected a %s error, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestObjectValueInto(t *testing.T) {
+	type config struct {
+		Color string `json:"color"`
+		Size  int    `json:"size"`
+	}
+
+	t.Run("decodes into a struct", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		target := config{Color: "blue", Size: 1}
+		mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "foo", target, gomock.Any()).
+			Return(InterfaceResolutionDetail{Value: map[string]interface{}{"color": "green", "size": 3.0}})
+
+		details, err := client.ObjectValueInto(context.Background(), "foo", &target, EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := (config{Color: "green", Size: 3}); target != expected {
+			t.Errorf("expected %+v, got %+v", expected, target)
+		}
+		if details.FlagKey != "foo" || details.ErrorCode != "" {
+			t.Errorf("expected the details of the evaluation, got %+v", details)
+		}
+	})
+
+	t.Run("decodes into a map", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "foo", gomock.Any(), gomock.Any()).
+			Return(InterfaceResolutionDetail{Value: config{Color: "red", Size: 2}})
+
+		var target map[string]interface{}
+		if _, err := client.ObjectValueInto(context.Background(), "foo", &target, EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := map[string]interface{}{"color": "red", "size": 2.0}; !reflect.DeepEqual(target, expected) {
+			t.Errorf("expected %v, got %v", expected, target)
+		}
+	})
+
+	t.Run("assignable values are set as is", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "foo", gomock.Any(), gomock.Any()).
+			Return(InterfaceResolutionDetail{Value: []string{"a", "b"}})
+
+		var target []string
+		if _, err := client.ObjectValueInto(context.Background(), "foo", &target, EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(target, []string{"a", "b"}) {
+			t.Errorf("expected the resolved value, got %v", target)
+		}
+	})
+
+	t.Run("errors leave the target unchanged", func(t *testing.T) {
+		tests := map[string]struct {
+			resolved    InterfaceResolutionDetail
+			expectedErr ErrorCode
+		}{
+			"undecodable value": {
+				resolved:    InterfaceResolutionDetail{Value: map[string]interface{}{"color": 42}},
+				expectedErr: TypeMismatchCode,
+			},
+			"resolution error": {
+				resolved: InterfaceResolutionDetail{
+					ProviderResolutionDetail: ProviderResolutionDetail{ResolutionError: NewFlagNotFoundResolutionError("not found")},
+				},
+				expectedErr: FlagNotFoundCode,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				mocks := hydratedMocksForClientTests(t, 1)
+				client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+				mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "foo", gomock.Any(), gomock.Any()).Return(test.resolved)
+
+				target := config{Color: "blue", Size: 1}
+				details, err := client.ObjectValueInto(context.Background(), "foo", &target, EvaluationContext{})
+				if err == nil || details.ErrorCode != test.expectedErr || details.Reason != ErrorReason {
+					t.Errorf("expected a %s error, got %+v, %v", test.expectedErr, details, err)
+				}
+				if expected := (config{Color: "blue", Size: 1}); target != expected || details.Value != expected {
+					t.Errorf("expected the target and value to be left unchanged, got %+v and %+v", target, details.Value)
+				}
+			})
+		}
+	})
+
+	t.Run("non-pointer targets are rejected", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 0)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		for _, target := range []interface{}{config{}, (*config)(nil), nil} {
+			details, err := client.ObjectValueInto(context.Background(), "foo", target, EvaluationContext{})
+			if err == nil || details.ErrorCode != GeneralCode {
+				t.Errorf("expected a GENERAL error for target %#v, got %+v, %v", target, details, err)
+			}
+		}
+	})
+}
+
+// batchProvider is a FeatureProvider implementing BatchEvaluator
+type batchProvider struct {
+	*MockFeatureProvider
+	*MockBatchEvaluator
+}
+
+func TestClient_EvaluateBatch(t *testing.T) {
+	requests := []FlagRequest{
+		{Key: "bool", Type: Boolean, DefaultValue: false},
+		{Key: "string", Type: String, DefaultValue: "default"},
+	}
+
+	t.Run("providers without batch support resolve flags one at a time", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "bool", false, gomock.Any()).
+			Return(BoolResolutionDetail{Value: true})
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), "string", "default", gomock.Any()).
+			Return(StringResolutionDetail{Value: "resolved"})
+
+		calls := 0
+		results, err := client.EvaluateBatch(context.Background(), requests, EvaluationContext{}, WithHooks(countingHook{calls: &calls}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(results) != 2 || results[0].Value != true || results[1].Value != "resolved" {
+			t.Errorf("expected the resolved values in request order, got %+v", results)
+		}
+		if calls != 6 {
+			t.Errorf("expected the before, after & finally hooks to run for each flag, got %d calls", calls)
+		}
+	})
+
+	t.Run("batch evaluators resolve all flags in a single call", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		provider := batchProvider{MockFeatureProvider: NewMockFeatureProvider(ctrl), MockBatchEvaluator: NewMockBatchEvaluator(ctrl)}
+		provider.MockFeatureProvider.EXPECT().Metadata().AnyTimes()
+		provider.MockFeatureProvider.EXPECT().Hooks().AnyTimes()
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Times(1).Return(provider, nil, EvaluationContext{}, nil)
+		client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+		batchRequests := append([]FlagRequest{
+			{Key: "mismatch", Type: Int, DefaultValue: int64(1)},
+			{Key: "before-error", Type: Boolean, DefaultValue: false},
+			{Key: "invalid-default", Type: Float, DefaultValue: "not a float"},
+		}, requests...)
+		provider.MockBatchEvaluator.EXPECT().BatchEvaluation(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, batch []BatchFlagRequest) []InterfaceResolutionDetail {
+				keys := make([]string, len(batch))
+				for i, request := range batch {
+					keys[i] = request.Key
+					if request.FlattenedContext[TargetingKey] != "user" {
+						t.Errorf("expected the evaluation context of flag %s to be flattened, got %v", request.Key, request.FlattenedContext)
+					}
+				}
+				if !reflect.DeepEqual(keys, []string{"mismatch", "bool", "string"}) {
+					t.Errorf("expected only the flags passing their before hooks to be resolved, got %v", keys)
+				}
+				return []InterfaceResolutionDetail{{Value: "not an int"}, {Value: true}, {Value: "resolved"}}
+			})
+
+		results, err := client.EvaluateBatch(context.Background(), batchRequests, NewEvaluationContext("user", nil),
+			WithHooks(keyFailingHook{key: "before-error"}))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		if len(results) != 5 || results[3].Value != true || results[4].Value != "resolved" {
+			t.Errorf("expected the resolved values in request order, got %+v", results)
+		}
+		for _, i := range []int{0, 1, 2} {
+			if results[i].Value != batchRequests[i].DefaultValue {
+				t.Errorf("expected the default value for flag %s, got %v", batchRequests[i].Key, results[i].Value)
+			}
+			if !strings.Contains(err.Error(), fmt.Sprintf("flag %s: ", batchRequests[i].Key)) {
+				t.Errorf("expected the error of flag %s, got %v", batchRequests[i].Key, err)
+			}
+		}
+		if results[0].ErrorCode != TypeMismatchCode {
+			t.Errorf("expected a resolved value of the wrong type to be a type mismatch, got %s", results[0].ErrorCode)
+		}
+	})
+
+	t.Run("cancelled contexts are not resolved by the provider", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		results, err := client.EvaluateBatch(ctx, requests, EvaluationContext{})
+		if !errors.Is(err, context.Canceled) || !errors.Is(err, GeneralError) {
+			t.Errorf("expected a GENERAL error matching context.Canceled, got %v", err)
+		}
+		for i, result := range results {
+			if result.Value != requests[i].DefaultValue || result.Reason != ErrorReason {
+				t.Errorf("expected the default value of flag %s with the error reason, got %+v", requests[i].Key, result)
+			}
+		}
+	})
+
+	t.Run("flags are resolved within the provider timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Return(slowProvider{delay: 500 * time.Millisecond}, nil, EvaluationContext{}, nil)
+		client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+		start := time.Now()
+		results, err := client.EvaluateBatch(context.Background(), requests[:1], EvaluationContext{}, WithProviderTimeout(10*time.Millisecond))
+		if !errors.Is(err, ProviderTimeoutError) || !errors.Is(err, GeneralError) {
+			t.Errorf("expected a GENERAL error matching ProviderTimeoutError, got %v", err)
+		}
+		if results[0].Value != false {
+			t.Errorf("expected the default value, got %v", results[0].Value)
+		}
+		if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+			t.Errorf("expected the batch to time out promptly, took %s", elapsed)
+		}
+	})
+
+	t.Run("batch evaluators resolve the flags within the provider timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		provider := batchProvider{MockFeatureProvider: NewMockFeatureProvider(ctrl), MockBatchEvaluator: NewMockBatchEvaluator(ctrl)}
+		provider.MockFeatureProvider.EXPECT().Metadata().AnyTimes()
+		provider.MockFeatureProvider.EXPECT().Hooks().AnyTimes()
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Times(1).Return(provider, nil, EvaluationContext{}, nil)
+		client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+		provider.MockBatchEvaluator.EXPECT().BatchEvaluation(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, batch []BatchFlagRequest) []InterfaceResolutionDetail {
+				<-ctx.Done()
+				return make([]InterfaceResolutionDetail, len(batch))
+			})
+
+		results, err := client.EvaluateBatch(context.Background(), requests, EvaluationContext{}, WithProviderTimeout(10*time.Millisecond))
+		if !errors.Is(err, ProviderTimeoutError) {
+			t.Errorf("expected an error matching ProviderTimeoutError, got %v", err)
+		}
+		for i, result := range results {
+			if result.Value != requests[i].DefaultValue || result.ErrorCode != GeneralCode {
+				t.Errorf("expected the default value of flag %s with a GENERAL error, got %+v", requests[i].Key, result)
+			}
+		}
+	})
+}
+
+// keyFailingHook fails the before stage of the flag with the given key
+type keyFailingHook struct {
+	UnimplementedHook
+	key string
+}
+
+func (h keyFailingHook) Before(_ context.Context, hookContext HookContext, _ HookHints) (*EvaluationContext, error) {
+	if hookContext.FlagKey() == h.key {
+		return nil, errors.New("forced")
+	}
+	return nil, nil
+}
+
+func TestEvaluationCancellation(t *testing.T) {
+	t.Run("cancelled before the provider call", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var details InterfaceEvaluationDetails
+		value, err := client.BooleanValue(ctx, "flag", true, EvaluationContext{},
+			WithHooks(finallyDetailsHook{details: &details, finally: new(bool)}))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the cancellation cause, got %v", err)
+		}
+		if value != true {
+			t.Error("expected the default value")
+		}
+		if details.ErrorCode != GeneralCode {
+			t.Errorf("expected the finally hooks to run with a %s error, got %v", GeneralCode, details.ErrorCode)
+		}
+	})
+
+	t.Run("cancelled during the provider call", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		release := make(chan struct{})
+		defer close(release)
+		called := make(chan struct{})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ string, _ bool, _ FlattenedContext) BoolResolutionDetail {
+				close(called)
+				select {
+				case <-release:
+					return BoolResolutionDetail{Value: false}
+				case <-ctx.Done():
+					return BoolResolutionDetail{Value: false, ProviderResolutionDetail: ProviderResolutionDetail{
+						ResolutionError: NewGeneralResolutionError("resolution aborted"),
+						Reason:          ErrorReason,
+					}}
+				}
+			})
+
+		cause := errors.New("request aborted")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		go func() {
+			<-called
+			cancel(cause)
+		}()
+
+		var details InterfaceEvaluationDetails
+		value, err := client.BooleanValue(ctx, "flag", true, EvaluationContext{},
+			WithHooks(finallyDetailsHook{details: &details, finally: new(bool)}))
+		if !errors.Is(err, cause) {
+			t.Errorf("expected the cancellation cause, got %v", err)
+		}
+		if value != true {
+			t.Error("expected the default value")
+		}
+		if details.ErrorCode != GeneralCode {
+			t.Errorf("expected the finally hooks to run with a %s error, got %v", GeneralCode, details.ErrorCode)
+		}
+	})
+}
+
+func TestEvaluationDuration(t *testing.T) {
+	const latency = 20 * time.Millisecond
+
+	sleepingProvider := func(mocks clientMocks) {
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, string, bool, FlattenedContext) BoolResolutionDetail {
+				time.Sleep(latency)
+				return BoolResolutionDetail{Value: true}
+			})
+	}
+
+	t.Run("measures the provider call", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		sleepingProvider(mocks)
+
+		var details InterfaceEvaluationDetails
+		evalDetails, err := client.BooleanValueDetails(context.Background(), "flag", false, EvaluationContext{},
+			WithHooks(finallyDetailsHook{details: &details, finally: new(bool)}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if evalDetails.EvaluationDuration < latency {
+			t.Errorf("expected an evaluation duration of at least %s, got %s", latency, evalDetails.EvaluationDuration)
+		}
+		if details.EvaluationDuration != evalDetails.EvaluationDuration {
+			t.Errorf("expected the finally hooks to receive the evaluation duration %s, got %s",
+				evalDetails.EvaluationDuration, details.EvaluationDuration)
+		}
+	})
+
+	t.Run("is zero when disabled", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		sleepingProvider(mocks)
+
+		evalDetails, err := client.BooleanValueDetails(context.Background(), "flag", false, EvaluationContext{},
+			WithoutEvaluationDuration())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if evalDetails.EvaluationDuration != 0 {
+			t.Errorf("expected no evaluation duration, got %s", evalDetails.EvaluationDuration)
+		}
+	})
+}
+
+func TestValueFunc(t *testing.T) {
+	evalCtx := NewEvaluationContext("user", map[string]interface{}{"plan": "premium"})
+	defaultFn := func(calls *int) func(EvaluationContext) string {
+		return func(evalCtx EvaluationContext) string {
+			*calls++
+			return "default for " + evalCtx.Attribute("plan").(string)
+		}
+	}
+
+	t.Run("does not compute the default on the happy path", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), "", gomock.Any()).
+			Return(StringResolutionDetail{Value: "resolved", ProviderResolutionDetail: ProviderResolutionDetail{Reason: TargetingMatchReason}})
+
+		var calls int
+		value, err := client.StringValueFunc(context.Background(), "flag", defaultFn(&calls), evalCtx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value != "resolved" || calls != 0 {
+			t.Errorf("expected the resolved value without computing the default, got %q after %d calls", value, calls)
+		}
+	})
+
+	t.Run("computes the default on the default reason", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(StringResolutionDetail{ProviderResolutionDetail: ProviderResolutionDetail{Reason: DefaultReason}})
+
+		var calls int
+		details, err := client.StringValueDetailsFunc(context.Background(), "flag", defaultFn(&calls), evalCtx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if details.Value != "default for premium" || details.Reason != DefaultReason || calls != 1 {
+			t.Errorf("expected the computed default with the %s reason, got %+v after %d calls", DefaultReason, details, calls)
+		}
+	})
+
+	t.Run("computes the default on error", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(StringResolutionDetail{ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: NewFlagNotFoundResolutionError("not found"),
+			}})
+
+		var calls int
+		details, err := client.StringValueDetailsFunc(context.Background(), "flag", defaultFn(&calls), evalCtx)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if details.Value != "default for premium" || details.Reason != ErrorReason || details.ErrorCode != FlagNotFoundCode {
+			t.Errorf("expected the computed default with the %s error, got %+v", FlagNotFoundCode, details)
+		}
+	})
+
+	t.Run("computes the default from the merged evaluation context", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		client.SetEvaluationContext(NewEvaluationContext("client-user", map[string]interface{}{"region": "eu"}))
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(StringResolutionDetail{ProviderResolutionDetail: ProviderResolutionDetail{Reason: DefaultReason}})
+
+		var merged EvaluationContext
+		_, err := client.StringValueFunc(context.Background(), "flag", func(evalCtx EvaluationContext) string {
+			merged = evalCtx
+			return ""
+		}, NewTargetlessEvaluationContext(map[string]interface{}{"plan": "premium"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if merged.TargetingKey() != "client-user" || merged.Attribute("region") != "eu" || merged.Attribute("plan") != "premium" {
+			t.Errorf("expected the client & invocation contexts to be merged, got %v", merged)
+		}
+	})
+}
+
+func TestWithTargetingKeyValidator(t *testing.T) {
+	t.Run("short circuits before the provider call", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI).
+			WithTargetingKeyValidator(NonBlankTargetingKey)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		var details InterfaceEvaluationDetails
+		finally := false
+		value, err := client.BooleanValue(context.Background(), "flag", true, NewEvaluationContext("  ", nil),
+			WithHooks(finallyDetailsHook{details: &details, finally: &finally}))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if value != true {
+			t.Error("expected the default value")
+		}
+		if details.ErrorCode != TargetingKeyMissingCode {
+			t.Errorf("expected the finally hooks to run with a %s error, got %v", TargetingKeyMissingCode, details.ErrorCode)
+		}
+	})
+
+	t.Run("wraps the validation error", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		validationErr := errors.New("not a UUID")
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI).
+			WithTargetingKeyValidator(func(string) error { return validationErr })
+
+		_, err := client.BooleanValue(context.Background(), "flag", true, NewEvaluationContext("user", nil))
+		if !errors.Is(err, validationErr) {
+			t.Errorf("expected the validation error, got %v", err)
+		}
+	})
+
+	t.Run("validates the targeting key set by before hooks", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI).
+			WithTargetingKeyValidator(NonBlankTargetingKey)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(BoolResolutionDetail{Value: false})
+
+		hook := NewMockHook(gomock.NewController(t))
+		targeted := NewEvaluationContext("user", nil)
+		hook.EXPECT().Before(gomock.Any(), gomock.Any(), gomock.Any()).Return(&targeted, nil)
+		hook.EXPECT().After(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		hook.EXPECT().Finally(gomock.Any(), gomock.Any(), gomock.Any())
+
+		value, err := client.BooleanValue(context.Background(), "flag", true, EvaluationContext{}, WithHooks(hook))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value != false {
+			t.Error("expected the resolved value")
+		}
+	})
+}
+
+func TestValueWithProvider(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 2)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	var details InterfaceEvaluationDetails
+	client.AddHooks(finallyDetailsHook{details: &details})
+
+	canary := NewMockFeatureProvider(gomock.NewController(t))
+	canary.EXPECT().Metadata().AnyTimes().Return(Metadata{Name: "canary"})
+	canary.EXPECT().Hooks().AnyTimes()
+	canary.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).
+		Return(BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}})
+	mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).
+		Return(BoolResolutionDetail{Value: false, ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}})
+
+	value, err := client.BooleanValueWithProvider(context.Background(), "flag", false, EvaluationContext{}, canary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value {
+		t.Error("expected the flag to be resolved by the override provider")
+	}
+	if details.Value != true {
+		t.Errorf("expected the client hooks to run with the resolution of the override provider, got %+v", details)
+	}
+
+	// the registered provider keeps resolving the flag of regular evaluations
+	value, err = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value {
+		t.Error("expected the flag to be resolved by the registered provider")
+	}
+}
+
+// errorRecordingHook records the error received by the error stage
+type errorRecordingHook struct {
+	UnimplementedHook
+	err *error
+}
+
+func (h errorRecordingHook) Error(_ context.Context, _ HookContext, err error, _ HookHints) {
+	*h.err = err
+}
+
+func TestStructuredResolutionError(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 1)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	details := map[string]interface{}{"upstream": 404}
+	mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", true, gomock.Any()).
+		Return(BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewFlagNotFoundResolutionError("flag is not defined").WithDetails(details),
+			Reason:          ErrorReason,
+		}})
+
+	var hookErr error
+	evalDetails, err := client.BooleanValueDetails(context.Background(), "flag", true, EvaluationContext{},
+		WithHooks(errorRecordingHook{err: &hookErr}))
+
+	for name, err := range map[string]error{"evaluation": err, "hook": hookErr, "details": evalDetails.Error()} {
+		if !errors.Is(err, FlagNotFoundError) {
+			t.Errorf("expected the %s error to match FlagNotFoundError, got %v", name, err)
+		}
+		if errors.Is(err, GeneralError) {
+			t.Errorf("expected the %s error not to match GeneralError", name)
+		}
+		var resolutionErr ResolutionError
+		if !errors.As(err, &resolutionErr) {
+			t.Fatalf("expected the %s error to be a ResolutionError, got %T", name, err)
+		}
+		if resolutionErr.Code() != FlagNotFoundCode || !reflect.DeepEqual(resolutionErr.Details(), details) {
+			t.Errorf("expected the %s error to keep its code & details, got %s %v", name, resolutionErr.Code(), resolutionErr.Details())
+		}
+	}
+	if !reflect.DeepEqual(evalDetails.ErrorDetails, details) {
+		t.Errorf("expected the evaluation details to carry the error details, got %v", evalDetails.ErrorDetails)
+	}
+	if (EvaluationDetails{}).Error() != nil {
+		t.Error("expected no error for a successful evaluation")
+	}
+}
+
+// variantForcingProvider resolves flags to the forced variants of its variants
+type variantForcingProvider struct {
+	NoopProvider
+	variants map[string]interface{}
+}
+
+func (p variantForcingProvider) ForceVariant(
+	_ context.Context, _ string, _ Type, variant string, defaultValue interface{}, _ FlattenedContext,
+) InterfaceResolutionDetail {
+	value, ok := p.variants[variant]
+	if !ok {
+		return InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewGeneralResolutionError("unknown variant"),
+			Reason:          ErrorReason,
+		}}
+	}
+	return InterfaceResolutionDetail{Value: value, ProviderResolutionDetail: ProviderResolutionDetail{
+		Reason:  StaticReason,
+		Variant: variant,
+	}}
+}
+
+func TestValueForceVariant(t *testing.T) {
+	t.Run("provider implementing VariantForcer", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 3)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		var details InterfaceEvaluationDetails
+		client.AddHooks(finallyDetailsHook{details: &details})
+		forcer := withProvider(variantForcingProvider{variants: map[string]interface{}{"on": true, "off": false, "label": "on"}})
+
+		value, err := client.BooleanValueForceVariant(context.Background(), "flag", "on", EvaluationContext{}, forcer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !value || details.Variant != "on" {
+			t.Errorf("expected the hooks to run with the forced variant, got %+v", details)
+		}
+
+		if _, err := client.BooleanValueForceVariant(context.Background(), "flag", "label", EvaluationContext{}, forcer); !errors.Is(err, TypeMismatchError) {
+			t.Errorf("expected a TYPE_MISMATCH error for a variant of another type, got %v", err)
+		}
+		if _, err := client.BooleanValueForceVariant(context.Background(), "flag", "unknown", EvaluationContext{}, forcer); err == nil {
+			t.Error("expected the error of the provider for an unknown variant")
+		}
+	})
+
+	t.Run("provider not implementing VariantForcer", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		value, err := client.StringValueForceVariant(context.Background(), "flag", "on", EvaluationContext{})
+		if !errors.Is(err, VariantForcingUnsupportedError) || !errors.Is(err, GeneralError) {
+			t.Errorf("expected a GENERAL error matching VariantForcingUnsupportedError, got %v", err)
+		}
+		if value != "" {
+			t.Errorf("expected the zero value, got %q", value)
+		}
+	})
+}
+
+// flagCheckingProvider is a provider implementing FlagChecker, holding the given flags
+type flagCheckingProvider struct {
+	NoopProvider
+	flags []string
+}
+
+func (p flagCheckingProvider) FlagExists(_ context.Context, flag string) (bool, error) {
+	return slices.Contains(p.flags, flag), nil
+}
+
+func TestClientFlagExists(t *testing.T) {
+	t.Run("provider implementing FlagChecker", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Times(2).
+			Return(flagCheckingProvider{flags: []string{"known"}}, nil, EvaluationContext{}, nil)
+		client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+		exists, err := client.FlagExists(context.Background(), "known")
+		if err != nil || !exists {
+			t.Errorf("expected the known flag to exist, got %v, %v", exists, err)
+		}
+		exists, err = client.FlagExists(context.Background(), "unknown")
+		if err != nil || exists {
+			t.Errorf("expected the unknown flag not to exist, got %v, %v", exists, err)
+		}
+	})
+
+	t.Run("provider not implementing FlagChecker", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		exists, err := client.FlagExists(context.Background(), "known")
+		if !errors.Is(err, FlagCheckingUnsupportedError) {
+			t.Errorf("expected an error matching FlagCheckingUnsupportedError, got %v", err)
+		}
+		if exists {
+			t.Error("expected the flag not to be reported as existing")
+		}
+	})
+
+	t.Run("provider not ready", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(NotReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).
+			Return(flagCheckingProvider{flags: []string{"known"}}, nil, EvaluationContext{}, nil)
+		client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+		if _, err := client.FlagExists(context.Background(), "known"); !errors.Is(err, ProviderNotReadyError) {
+			t.Errorf("expected ProviderNotReadyError, got %v", err)
+		}
+	})
+}
+
+func TestClientListFlags(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 1)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+	flags, err := client.ListFlags(context.Background())
+	if !errors.Is(err, FlagListingUnsupportedError) {
+		t.Errorf("expected an error matching FlagListingUnsupportedError, got %v", err)
+	}
+	if flags != nil {
+		t.Errorf("expected no flags, got %v", flags)
+	}
+}
+
+// slowProvider is a provider taking the given delay to resolve boolean flags to true, ignoring the context
+type slowProvider struct {
+	NoopProvider
+	delay time.Duration
+}
+
+func (p slowProvider) BooleanEvaluation(context.Context, string, bool, FlattenedContext) BoolResolutionDetail {
+	time.Sleep(p.delay)
+	return BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}}
+}
+
+func TestProviderTimeout(t *testing.T) {
+	newSlowClient := func(t *testing.T, delay time.Duration) *Client {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Return(slowProvider{delay: delay}, nil, EvaluationContext{}, nil)
+		return newClient("test-client", mockEvaluationApi, mockClientApi)
+	}
+
+	t.Run("context without deadline is bounded by the provider timeout", func(t *testing.T) {
+		client := newSlowClient(t, 500*time.Millisecond)
+
+		start := time.Now()
+		value, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}, WithProviderTimeout(10*time.Millisecond))
+		if !errors.Is(err, ProviderTimeoutError) || !errors.Is(err, GeneralError) {
+			t.Errorf("expected a GENERAL error matching ProviderTimeoutError, got %v", err)
+		}
+		if value != false {
+			t.Errorf("expected the default value, got %v", value)
+		}
+		if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+			t.Errorf("expected the evaluation to time out promptly, took %s", elapsed)
+		}
+	})
+
+	t.Run("deadline of the context is respected", func(t *testing.T) {
+		client := newSlowClient(t, 50*time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		value, err := client.BooleanValue(ctx, "flag", false, EvaluationContext{}, WithProviderTimeout(10*time.Millisecond))
+		if err != nil {
+			t.Fatalf("expected the deadline of the context to apply instead of the provider timeout, got %v", err)
+		}
+		if value != true {
+			t.Errorf("expected the resolved value, got %v", value)
+		}
+	})
+
+	t.Run("resolution within the provider timeout succeeds", func(t *testing.T) {
+		client := newSlowClient(t, 0)
+
+		value, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}, WithProviderTimeout(time.Second))
+		if err != nil || value != true {
+			t.Errorf("expected the resolved value, got %v, %v", value, err)
+		}
+	})
+}
+
+// cancellationObservingProvider is a provider blocking until the context of the resolution is done, reporting the
+// cancellation cause
+type cancellationObservingProvider struct {
+	NoopProvider
+	causes chan error
+}
+
+func (p cancellationObservingProvider) StringEvaluation(ctx context.Context, _ string, defaultValue string, _ FlattenedContext) StringResolutionDetail {
+	<-ctx.Done()
+	p.causes <- context.Cause(ctx)
+	return StringResolutionDetail{Value: "late", ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}}
+}
+
+func TestValueWithTimeout(t *testing.T) {
+	newTimeoutClient := func(t *testing.T, provider FeatureProvider) *Client {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Return(provider, nil, EvaluationContext{}, nil)
+		return newClient("test-client", mockEvaluationApi, mockClientApi)
+	}
+
+	t.Run("a slow provider yields the default value", func(t *testing.T) {
+		client := newTimeoutClient(t, slowProvider{delay: 500 * time.Millisecond})
+		var details InterfaceEvaluationDetails
+		var finally bool
+
+		start := time.Now()
+		value, err := client.BooleanValueWithTimeout(context.Background(), "flag", false, EvaluationContext{}, 10*time.Millisecond,
+			WithHooks(finallyDetailsHook{details: &details, finally: &finally}))
+		if !errors.Is(err, ProviderTimeoutError) || !errors.Is(err, GeneralError) {
+			t.Errorf("expected a GENERAL error matching ProviderTimeoutError, got %v", err)
+		}
+		if value != false {
+			t.Errorf("expected the default value, got %v", value)
+		}
+		if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+			t.Errorf("expected the evaluation to time out promptly, took %s", elapsed)
+		}
+		if details.Reason != ErrorReason || details.ErrorCode != GeneralCode || details.Value != false {
+			t.Errorf("expected the finally hooks to receive the default value with the ERROR reason, got %+v", details)
+		}
+	})
+
+	t.Run("the resolution is cancelled", func(t *testing.T) {
+		causes := make(chan error, 1)
+		client := newTimeoutClient(t, cancellationObservingProvider{causes: causes})
+
+		value, err := client.StringValueWithTimeout(context.Background(), "flag", "default", EvaluationContext{}, 10*time.Millisecond)
+		if !errors.Is(err, ProviderTimeoutError) || value != "default" {
+			t.Errorf("expected the default value with a timeout error, got %q, %v", value, err)
+		}
+		select {
+		case cause := <-causes:
+			if !errors.Is(cause, ProviderTimeoutError) {
+				t.Errorf("expected the resolution to be cancelled by the timeout, got %v", cause)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the context of the resolution to be cancelled")
+		}
+	})
+
+	t.Run("the timeout applies despite a later deadline of the context", func(t *testing.T) {
+		client := newTimeoutClient(t, slowProvider{delay: 500 * time.Millisecond})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		if _, err := client.BooleanValueWithTimeout(ctx, "flag", false, EvaluationContext{}, 10*time.Millisecond); !errors.Is(err, ProviderTimeoutError) {
+			t.Errorf("expected a timeout error, got %v", err)
+		}
+	})
+
+	t.Run("resolution within the timeout succeeds", func(t *testing.T) {
+		client := newTimeoutClient(t, slowProvider{})
+
+		value, err := client.BooleanValueWithTimeout(context.Background(), "flag", false, EvaluationContext{}, time.Second)
+		if err != nil || value != true {
+			t.Errorf("expected the resolved value, got %v, %v", value, err)
+		}
+	})
+}
+
+func TestObjectValueWeightedDefault(t *testing.T) {
+	fallbacks := []WeightedValue{
+		{Value: "control", Weight: 75},
+		{Value: "treatment", Weight: 25},
+		{Value: "never", Weight: 0},
+	}
+	const keys = 2000
+
+	mocks := hydratedMocksForClientTests(t, 2*keys+1)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "flag", nil, gomock.Any()).AnyTimes().
+		Return(InterfaceResolutionDetail{ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewGeneralResolutionError("backend down"),
+			Reason:          ErrorReason,
+		}})
+
+	t.Run("the same targeting key gets a stable fallback", func(t *testing.T) {
+		for i := 0; i < keys; i++ {
+			evalCtx := NewEvaluationContext(fmt.Sprintf("user-%d", i), nil)
+			first, err := client.ObjectValueWeightedDefault(context.Background(), "flag", fallbacks, evalCtx)
+			if err == nil {
+				t.Fatal("expected the error of the provider")
+			}
+			second, _ := client.ObjectValueWeightedDefault(context.Background(), "flag", fallbacks, evalCtx)
+			if first != second {
+				t.Fatalf("expected a stable fallback for user-%d, got %v and %v", i, first, second)
+			}
+		}
+	})
+
+	t.Run("fallbacks are distributed by weight", func(t *testing.T) {
+		counts := map[interface{}]int{}
+		for i := 0; i < keys; i++ {
+			counts[pickWeighted(fallbacks, fmt.Sprintf("flag/user-%d", i))]++
+		}
+		if counts["never"] != 0 {
+			t.Errorf("expected fallbacks without weight never to be picked, got %d", counts["never"])
+		}
+		if share := float64(counts["treatment"]) / keys; math.Abs(share-0.25) > 0.05 {
+			t.Errorf("expected about 25%% treatment, got %.1f%%", share*100)
+		}
+		if counts["control"]+counts["treatment"] != keys {
+			t.Errorf("expected every key to get a fallback, got %v", counts)
+		}
+	})
+
+	t.Run("no positive weight returns nil", func(t *testing.T) {
+		value, _ := client.ObjectValueWeightedDefault(context.Background(), "flag", []WeightedValue{{Value: "never"}}, NewEvaluationContext("user", nil))
+		if value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+}
+
+// panickingProvider is a provider panicking when resolving boolean flags
+type panickingProvider struct {
+	NoopProvider
+}
+
+func (panickingProvider) BooleanEvaluation(context.Context, string, bool, FlattenedContext) BoolResolutionDetail {
+	var variants map[string]bool
+	variants["on"] = true
+	return BoolResolutionDetail{}
+}
+
+// StringEvaluation panics once the context of the resolution is done
+func (panickingProvider) StringEvaluation(ctx context.Context, _ string, _ string, _ FlattenedContext) StringResolutionDetail {
+	<-ctx.Done()
+	panic("resolved past the deadline")
+}
+
+func TestProviderPanicRecovery(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	provider := panickingProvider{}
+	if err := SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewClient(t.Name())
+	events := make(chan EventDetails, 1)
+	onError := func(details EventDetails) {
+		events <- details
+	}
+	client.AddHandler(ProviderError, &onError)
+
+	t.Run("panic is recovered as a GENERAL error", func(t *testing.T) {
+		var hookErr error
+		var finallyDetails InterfaceEvaluationDetails
+		value, err := client.BooleanValue(context.Background(), "flag", true, EvaluationContext{}, WithHooks(
+			errorRecordingHook{err: &hookErr}, finallyDetailsHook{details: &finallyDetails}))
+
+		var panicErr *ProviderPanicError
+		if !errors.As(err, &panicErr) || !errors.Is(err, GeneralError) {
+			t.Fatalf("expected a GENERAL error caused by a ProviderPanicError, got %v", err)
+		}
+		if panicErr.Provider != "NoopProvider" || panicErr.Value == nil || !bytes.Contains(panicErr.Stack, []byte("panickingProvider.BooleanEvaluation")) {
+			t.Errorf("expected the panic error to carry the provider, value and stack of the panic, got %+v", panicErr)
+		}
+		if value != true {
+			t.Errorf("expected the default value, got %v", value)
+		}
+		if !errors.As(hookErr, &panicErr) {
+			t.Errorf("expected the error hooks to receive the panic error, got %v", hookErr)
+		}
+		if finallyDetails.ErrorCode != GeneralCode {
+			t.Errorf("expected the finally hooks to run with the GENERAL error, got %+v", finallyDetails)
+		}
+
+		select {
+		case details := <-events:
+			if details.ProviderName != "NoopProvider" || !strings.Contains(details.Message, "panicked") {
+				t.Errorf("expected a PROVIDER_ERROR event reporting the panic, got %+v", details)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected a PROVIDER_ERROR event")
+		}
+	})
+
+	t.Run("panics of providers resolving in the background are recovered", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err := client.BooleanValue(ctx, "flag", true, EvaluationContext{})
+		var panicErr *ProviderPanicError
+		if !errors.As(err, &panicErr) || !bytes.Contains(panicErr.Stack, []byte("panickingProvider.BooleanEvaluation")) {
+			t.Errorf("expected the panic error to carry the stack of the panic, got %v", err)
+		}
+		<-events
+
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := client.StringValue(ctx, "flag", "default", EvaluationContext{}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the evaluation to stop at the deadline, got %v", err)
+		}
+		select {
+		case details := <-events:
+			if !strings.Contains(details.Message, "resolved past the deadline") {
+				t.Errorf("expected a PROVIDER_ERROR event reporting the panic, got %+v", details)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected the panic past the deadline to be reported with a PROVIDER_ERROR event")
+		}
+	})
+
+	t.Run("panic propagates with recovery disabled", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic of the provider to propagate")
+			}
+		}()
+		_, _ = client.BooleanValue(context.Background(), "flag", true, EvaluationContext{}, WithProviderPanicRecovery(false))
+	})
+}
+
+func TestProviderDefinedReason(t *testing.T) {
+	for _, reason := range []Reason{SplitReason, "HOLDOUT"} {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		var hookDetails InterfaceEvaluationDetails
+		client.AddHooks(finallyDetailsHook{details: &hookDetails})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).
+			Return(BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{Reason: reason}})
+
+		details, err := client.BooleanValueDetails(context.Background(), "flag", false, EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if details.Reason != reason || hookDetails.Reason != reason {
+			t.Errorf("expected the reason %s to reach the caller and the hooks, got %s and %s", reason, details.Reason, hookDetails.Reason)
+		}
+		if details.Reason.IsStandard() != (reason == SplitReason) {
+			t.Errorf("expected only %s to be a standard reason", SplitReason)
+		}
+	}
+}
+
+func TestClientAddHandlerUnsubscribe(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 0)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+	ready := func(EventDetails) {}
+	gomock.InOrder(
+		mocks.clientHandlerAPI.EXPECT().AddClientHandler("test-client", ProviderReady, EventCallback(&ready)),
+		mocks.clientHandlerAPI.EXPECT().RemoveClientHandler("test-client", ProviderReady, EventCallback(&ready)).Times(1),
+	)
+	unsubscribe := client.AddHandler(ProviderReady, &ready)
+
+	unsubscribe()
+	unsubscribe()
+	if handlers := client.handlers[ProviderReady]; len(handlers) != 0 {
+		t.Errorf("expected the handler to be removed, got %v", handlers)
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 2)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).Times(2).
+		Return(BoolResolutionDetail{Value: true})
+
+	ready, changed := func(EventDetails) {}, func(EventDetails) {}
+	gomock.InOrder(
+		mocks.clientHandlerAPI.EXPECT().AddClientHandler("test-client", ProviderReady, EventCallback(&ready)),
+		mocks.clientHandlerAPI.EXPECT().AddClientHandler("test-client", ProviderConfigChange, EventCallback(&changed)),
+		mocks.clientHandlerAPI.EXPECT().RemoveClientHandler("test-client", ProviderConfigChange, EventCallback(&changed)),
+		mocks.clientHandlerAPI.EXPECT().RemoveClientHandler("test-client", ProviderReady, EventCallback(&ready)),
+	)
+	client.AddHandler(ProviderReady, &ready)
+	client.AddHandler(ProviderConfigChange, &changed)
+	client.RemoveHandler(ProviderConfigChange, &changed)
+
+	calls := 0
+	client.AddHooks(countingHook{calls: &calls})
+	if _, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls == 0 {
+		t.Fatal("expected the client's hooks to run before Close")
+	}
+
+	client.Close()
+	calls = 0
+	value, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+	if err != nil || !value {
+		t.Errorf("expected the flag to still be resolved after Close, got %v, %v", value, err)
+	}
+	if calls != 0 {
+		t.Errorf("expected the client's hooks not to run after Close, got %d calls", calls)
+	}
+	if hooks := client.Hooks(); len(hooks) != 0 {
+		t.Errorf("expected the hooks to be released, got %v", hooks)
+	}
+}
+
+// handlers run synchronously when the provider is already in the state of the event must be able to use the client
+func TestClientHandlerEvaluatingOnReady(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	if err := SetNamedProviderAndWait(t.Name(), NoopProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewClient(t.Name())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ready := func(EventDetails) {
+			_, _ = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+		}
+		unsubscribe := client.AddHandler(ProviderReady, &ready)
+		unsubscribe()
+		client.AddHandler(ProviderReady, &ready)
+		client.Close()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the handler evaluating a flag to not deadlock the client")
+	}
+}
+
+func TestClientEvaluationMetadata(t *testing.T) {
+	ctx := context.Background()
+	metadata := FlagMetadata{"version": "v2", "scope": "checkout"}
+	resolved := ProviderResolutionDetail{Reason: StaticReason, FlagMetadata: metadata}
+
+	t.Run("typed evaluations populate the flag metadata", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 5)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).
+			Return(BoolResolutionDetail{Value: true, ProviderResolutionDetail: resolved})
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), "flag", "", gomock.Any()).
+			Return(StringResolutionDetail{Value: "on", ProviderResolutionDetail: resolved})
+		mocks.providerAPI.EXPECT().FloatEvaluation(gomock.Any(), "flag", 0.0, gomock.Any()).
+			Return(FloatResolutionDetail{Value: 1, ProviderResolutionDetail: resolved})
+		mocks.providerAPI.EXPECT().IntEvaluation(gomock.Any(), "flag", int64(0), gomock.Any()).
+			Return(IntResolutionDetail{Value: 1, ProviderResolutionDetail: resolved})
+		mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "flag", nil, gomock.Any()).
+			Return(InterfaceResolutionDetail{Value: "on", ProviderResolutionDetail: resolved})
+
+		boolDetails, _ := client.BooleanValueDetails(ctx, "flag", false, EvaluationContext{})
+		stringDetails, _ := client.StringValueDetails(ctx, "flag", "", EvaluationContext{})
+		floatDetails, _ := client.FloatValueDetails(ctx, "flag", 0, EvaluationContext{})
+		intDetails, _ := client.IntValueDetails(ctx, "flag", 0, EvaluationContext{})
+		objectDetails, _ := client.ObjectValueDetails(ctx, "flag", nil, EvaluationContext{})
+
+		for flagType, details := range map[Type]EvaluationDetails{
+			Boolean: boolDetails.EvaluationDetails,
+			String:  stringDetails.EvaluationDetails,
+			Float:   floatDetails.EvaluationDetails,
+			Int:     intDetails.EvaluationDetails,
+			Object:  objectDetails.EvaluationDetails,
+		} {
+			if !reflect.DeepEqual(details.FlagMetadata, metadata) {
+				t.Errorf("expected the %s evaluation to have the flag metadata %v, got %v", flagType, metadata, details.FlagMetadata)
+			}
+		}
+	})
+
+	t.Run("failed evaluations have empty flag metadata", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 0)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		details, err := client.IntValueDetails(ctx, "\xff", 0, EvaluationContext{})
+		if err == nil {
+			t.Fatal("expected the evaluation of an invalid flag key to fail")
+		}
+		if details.FlagMetadata == nil || len(details.FlagMetadata) != 0 {
+			t.Errorf("expected empty flag metadata, got %#v", details.FlagMetadata)
+		}
+	})
+
+	t.Run("returns the flag metadata regardless of the flag type", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "flag", nil, gomock.Any()).
+			Return(InterfaceResolutionDetail{Value: true, ProviderResolutionDetail: resolved})
+
+		got, err := client.EvaluationMetadata(ctx, "flag", EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if version, _ := got.GetString("version"); version != "v2" {
+			t.Errorf("expected the version of the flag, got %v", got)
+		}
+	})
+
+	t.Run("resolves the flag regardless of its type on type checking providers", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mismatch := ProviderResolutionDetail{ResolutionError: NewTypeMismatchResolutionError("incorrect type"), Reason: ErrorReason}
+		gomock.InOrder(
+			mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "flag", nil, gomock.Any()).
+				Return(InterfaceResolutionDetail{ProviderResolutionDetail: mismatch}),
+			mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).
+				Return(BoolResolutionDetail{ProviderResolutionDetail: mismatch}),
+			mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), "flag", "", gomock.Any()).
+				Return(StringResolutionDetail{Value: "on", ProviderResolutionDetail: resolved}),
+		)
+
+		got, err := client.EvaluationMetadata(ctx, "flag", EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, metadata) {
+			t.Errorf("expected the flag metadata %v, got %v", metadata, got)
+		}
+	})
+
+	t.Run("returns the flag metadata of a failed resolution with its error", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "flag", nil, gomock.Any()).
+			Return(InterfaceResolutionDetail{ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: NewFlagNotFoundResolutionError("flag not found"),
+				Reason:          ErrorReason,
+				FlagMetadata:    metadata,
+			}})
+
+		got, err := client.EvaluationMetadata(ctx, "flag", EvaluationContext{})
+		if !errors.Is(err, FlagNotFoundError) {
+			t.Errorf("expected a FLAG_NOT_FOUND error, got %v", err)
+		}
+		if !reflect.DeepEqual(got, metadata) {
+			t.Errorf("expected the flag metadata %v, got %v", metadata, got)
+		}
+	})
+}
+
+// blockingTracker records the tracked event names, each tracking call waiting for release
+type blockingTracker struct {
+	NoopProvider
+	started chan string
+	release chan struct{}
+	tracked *atomic.Int32
+}
+
+func newBlockingTracker() blockingTracker {
+	return blockingTracker{started: make(chan string, 10), release: make(chan struct{}), tracked: new(atomic.Int32)}
+}
+
+func (p blockingTracker) Track(_ context.Context, trackingEventName string, _ EvaluationContext, _ TrackingEventDetails) {
+	p.started <- trackingEventName
+	<-p.release
+	p.tracked.Add(1)
+}
+
+func TestClientTrackAsync(t *testing.T) {
+	t.Run("events are delivered to the provider", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		tracker := newBlockingTracker()
+		close(tracker.release)
+		if err := SetNamedProviderAndWait(t.Name(), tracker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		queued := NewClient(t.Name()).TrackAsync(ctx, "checkout", EvaluationContext{}, NewTrackingEventDetails(1))
+		cancel()
+		if !queued {
+			t.Fatal("expected the event to be queued")
+		}
+
+		select {
+		case name := <-tracker.started:
+			if name != "checkout" {
+				t.Errorf("expected the checkout event, got %s", name)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the event to be delivered despite the cancelled context")
+		}
+	})
+
+	t.Run("events overflowing the queue are dropped", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		tracker := newBlockingTracker()
+		if err := SetNamedProviderAndWait(t.Name(), tracker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		SetTrackingQueueConfig(TrackingQueueConfig{Workers: 1, Size: 1})
+		client := NewClient(t.Name())
+		ctx := context.Background()
+
+		if !client.TrackAsync(ctx, "first", EvaluationContext{}, TrackingEventDetails{}) {
+			t.Fatal("expected the first event to be queued")
+		}
+		<-tracker.started // the worker is busy with the first event
+		if !client.TrackAsync(ctx, "second", EvaluationContext{}, TrackingEventDetails{}) {
+			t.Fatal("expected the second event to fill the queue")
+		}
+		if client.TrackAsync(ctx, "third", EvaluationContext{}, TrackingEventDetails{}) {
+			t.Error("expected the third event to be dropped")
+		}
+
+		close(tracker.release)
+		if err := ShutdownWithContext(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tracked := tracker.tracked.Load(); tracked != 2 {
+			t.Errorf("expected the queued events to be delivered on shutdown, got %d", tracked)
+		}
+		if client.TrackAsync(ctx, "after", EvaluationContext{}, TrackingEventDetails{}) != true {
+			t.Error("expected a new queue to accept events after shutdown")
+		}
+	})
+
+	t.Run("blocking overflow waits for the context", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		tracker := newBlockingTracker()
+		if err := SetNamedProviderAndWait(t.Name(), tracker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		SetTrackingQueueConfig(TrackingQueueConfig{Workers: 1, Size: 1, Overflow: BlockOnOverflow})
+		client := NewClient(t.Name())
+
+		client.TrackAsync(context.Background(), "first", EvaluationContext{}, TrackingEventDetails{})
+		<-tracker.started
+		client.TrackAsync(context.Background(), "second", EvaluationContext{}, TrackingEventDetails{})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if client.TrackAsync(ctx, "third", EvaluationContext{}, TrackingEventDetails{}) {
+			t.Error("expected the event not to be queued once the context is done")
+		}
+
+		// events still blocked in the provider fail the shutdown deadline
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancelShutdown()
+		if err := ShutdownWithContext(shutdownCtx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the tracking queue not to drain in time, got %v", err)
+		}
+		close(tracker.release)
+	})
+
+	t.Run("shutdown releases producers blocked on a full queue", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		tracker := newBlockingTracker()
+		defer close(tracker.release)
+		if err := SetNamedProviderAndWait(t.Name(), tracker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		SetTrackingQueueConfig(TrackingQueueConfig{Workers: 1, Size: 1, Overflow: BlockOnOverflow})
+		client := NewClient(t.Name())
+
+		client.TrackAsync(context.Background(), "first", EvaluationContext{}, TrackingEventDetails{})
+		<-tracker.started
+		client.TrackAsync(context.Background(), "second", EvaluationContext{}, TrackingEventDetails{})
+
+		blocked := make(chan bool)
+		go func() {
+			blocked <- client.TrackAsync(context.Background(), "third", EvaluationContext{}, TrackingEventDetails{})
+		}()
+		select {
+		case <-blocked:
+			t.Fatal("expected the producer to block on the full queue")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		shutdown := make(chan error)
+		go func() {
+			shutdown <- ShutdownWithContext(shutdownCtx)
+		}()
+
+		select {
+		case err := <-shutdown:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected the tracking queue not to drain in time, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the shutdown to respect its deadline")
+		}
+		select {
+		case queued := <-blocked:
+			if queued {
+				t.Error("expected the blocked event to be dropped once the queue is drained")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the blocked producer to be released by the shutdown")
+		}
+	})
+}
+
+func TestResolvedValueTypeValidation(t *testing.T) {
+	t.Run("values of the wrong type fail the evaluation before the after hooks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		provider := batchProvider{MockFeatureProvider: NewMockFeatureProvider(ctrl), MockBatchEvaluator: NewMockBatchEvaluator(ctrl)}
+		provider.MockFeatureProvider.EXPECT().Metadata().AnyTimes()
+		provider.MockFeatureProvider.EXPECT().Hooks().AnyTimes()
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Times(1).Return(provider, nil, EvaluationContext{}, nil)
+		client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+		metadata := FlagMetadata{"source": "batch"}
+		provider.MockBatchEvaluator.EXPECT().BatchEvaluation(gomock.Any(), gomock.Any()).Times(1).
+			Return([]InterfaceResolutionDetail{{
+				Value:                    "on",
+				ProviderResolutionDetail: ProviderResolutionDetail{Reason: TargetingMatchReason, FlagMetadata: metadata},
+			}})
+
+		var afterCalls int
+		var hookErr error
+		results, err := client.EvaluateBatch(context.Background(), []FlagRequest{{Key: "flag", Type: Boolean, DefaultValue: false}},
+			EvaluationContext{}, WithHooks(afterOnlyHook{countingHook{calls: &afterCalls}}, errorRecordingHook{err: &hookErr}))
+		if !errors.Is(err, TypeMismatchError) || !errors.Is(hookErr, TypeMismatchError) {
+			t.Errorf("expected a type mismatch for the evaluation & the error hooks, got %v & %v", err, hookErr)
+		}
+		if len(results) != 1 || results[0].Value != false || results[0].ErrorCode != TypeMismatchCode {
+			t.Fatalf("expected the default value with a type mismatch, got %+v", results)
+		}
+		if results[0].Reason != ErrorReason || !reflect.DeepEqual(results[0].FlagMetadata, metadata) {
+			t.Errorf("expected the error reason & the flag metadata of the resolution, got %s %v", results[0].Reason, results[0].FlagMetadata)
+		}
+		if afterCalls != 0 {
+			t.Errorf("expected the after hooks not to run, got %d calls", afterCalls)
+		}
+	})
+
+	t.Run("values of unknown flag types never match", func(t *testing.T) {
+		resolution := checkResolutionType(Type(42), InterfaceResolutionDetail{Value: "on"})
+		if resolution.ResolutionError.code != TypeMismatchCode {
+			t.Errorf("expected a type mismatch, got %v", resolution.Error())
+		}
+		if !strings.Contains(resolution.Error().Error(), "Type(42)") {
+			t.Errorf("expected the error to name the unknown type, got %v", resolution.Error())
+		}
+	})
+}
+
+func TestClientPreflattenedEvaluation(t *testing.T) {
+	t.Run("the flattened context reaches the provider unchanged", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		client.SetEvaluationContext(NewEvaluationContext("client-user", map[string]interface{}{"plan": "free"}))
+
+		flatCtx := FlattenedContext{TargetingKey: "user-1", "plan": "pro", "user.email": "user@example.com"}
+		var resolvedWith FlattenedContext
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ bool, flatCtx FlattenedContext) BoolResolutionDetail {
+				resolvedWith = flatCtx
+				return BoolResolutionDetail{Value: true}
+			})
+
+		var hookCtx HookContext
+		value, err := client.BooleanValuePreflattened(context.Background(), "flag", false, flatCtx,
+			WithHooks(hookContextRecordingHook{hookCtx: &hookCtx}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !value {
+			t.Error("expected the resolved value")
+		}
+		if reflect.ValueOf(resolvedWith).Pointer() != reflect.ValueOf(flatCtx).Pointer() {
+			t.Errorf("expected the provider to resolve the flag with the flattened context as is, got %v", resolvedWith)
+		}
+		if !reflect.DeepEqual(resolvedWith, FlattenedContext{TargetingKey: "user-1", "plan": "pro", "user.email": "user@example.com"}) {
+			t.Errorf("expected the flattened context not to be merged with the client context, got %v", resolvedWith)
+		}
+		if hookCtx.EvaluationContext().TargetingKey() != "user-1" || hookCtx.EvaluationContext().Attribute("plan") != "pro" {
+			t.Errorf("expected the hooks to see the flattened context, got %v", hookCtx.EvaluationContext())
+		}
+	})
+
+	t.Run("contexts amended by before hooks are flattened again", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		var resolvedWith FlattenedContext
+		mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), "flag", "default", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, flatCtx FlattenedContext) StringResolutionDetail {
+				resolvedWith = flatCtx
+				return StringResolutionDetail{Value: "resolved"}
+			})
+
+		flatCtx := FlattenedContext{TargetingKey: "user-1", "plan": "pro"}
+		hook := flattenedContextHook{seen: map[HookStage]FlattenedContext{}}
+		if _, err := client.StringValuePreflattened(context.Background(), "flag", "default", flatCtx, WithHooks(hook)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := FlattenedContext{TargetingKey: "user-1", "plan": "pro", "bucket": "beta"}
+		if !reflect.DeepEqual(resolvedWith, expected) {
+			t.Errorf("expected the provider to resolve the flag with the amended context %v, got %v", expected, resolvedWith)
+		}
+		if len(flatCtx) != 2 {
+			t.Errorf("expected the caller's flattened context to be left untouched, got %v", flatCtx)
+		}
+	})
+}
+
+// hookContextRecordingHook records the hook context of its before stage
+type hookContextRecordingHook struct {
+	UnimplementedHook
+	hookCtx *HookContext
+}
+
+func (h hookContextRecordingHook) Before(_ context.Context, hookContext HookContext, _ HookHints) (*EvaluationContext, error) {
+	*h.hookCtx = hookContext
+	return nil, nil
+}
+
+func BenchmarkPreflattenedEvaluation(b *testing.B) {
+	defer initSingleton()
+
+	client := NewClient(b.Name())
+	client.SetEvaluationContext(NewEvaluationContext("", map[string]interface{}{"app": "bench", "version": "1.0.0"}))
+	evalCtx := NewEvaluationContext("user-1", map[string]interface{}{
+		"plan": "pro", "region": "eu", "email": "user@example.com", "age": 42, "beta": true,
+	})
+	flatCtx := flattenContext(MergeEvaluationContexts(client.EvaluationContext(), evalCtx))
+
+	b.Run("merged", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx)
+		}
+	})
+
+	b.Run("preflattened", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = client.BooleanValuePreflattened(context.Background(), "foo", false, flatCtx)
+		}
+	})
+}
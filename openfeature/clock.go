@@ -0,0 +1,112 @@
+package openfeature
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the source of time of the SDK, used for cache expiry, hook, provider & initialization timeouts, health
+// checks and evaluation durations. It defaults to the wall clock, and can be replaced in tests with WithClock.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// NewTimer creates a Timer sending the current time on its channel after at least the duration has elapsed
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event timer created by a Clock, see time.Timer
+type Timer interface {
+	// C returns the channel the time is sent on when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, returning false if the timer already fired or was stopped
+	Stop() bool
+}
+
+// realClock is the wall clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// clockHolder wraps the clock, as atomic.Value requires a consistent concrete type
+type clockHolder struct {
+	clock Clock
+}
+
+var clock atomic.Value
+
+func init() {
+	clock.Store(clockHolder{clock: realClock{}})
+}
+
+// WithClock replaces the clock of the SDK, returning a function restoring the previous clock. A nil clock restores
+// the wall clock. It is intended for tests, e.g. with the FakeClock of the testing package, and affects the caches,
+// timeouts and health checks created afterwards.
+func WithClock(c Clock) (restore func()) {
+	if c == nil {
+		c = realClock{}
+	}
+	previous := clock.Swap(clockHolder{clock: c}).(clockHolder)
+	return func() {
+		clock.Store(previous)
+	}
+}
+
+// CurrentClock returns the clock of the SDK, see WithClock
+func CurrentClock() Clock {
+	return clock.Load().(clockHolder).clock
+}
+
+// since returns the time elapsed since start according to the clock of the SDK
+func since(start time.Time) time.Duration {
+	return CurrentClock().Now().Sub(start)
+}
+
+// withTimeoutCause is context.WithTimeoutCause driven by the clock of the SDK. Contexts bounded by a replaced clock
+// report the deadline according to that clock, and are cancelled with the cause once the clock's timer fires.
+func withTimeoutCause(ctx context.Context, timeout time.Duration, cause error) (context.Context, context.CancelFunc) {
+	c := CurrentClock()
+	if _, ok := c.(realClock); ok {
+		return context.WithTimeoutCause(ctx, timeout, cause)
+	}
+
+	deadline := c.Now().Add(timeout)
+	if parent, ok := ctx.Deadline(); ok && parent.Before(deadline) {
+		deadline = parent
+	}
+	cancelCtx, cancel := context.WithCancelCause(ctx)
+	timer := c.NewTimer(timeout)
+	go func() {
+		select {
+		case <-timer.C():
+			cancel(cause)
+		case <-cancelCtx.Done():
+			timer.Stop()
+		}
+	}()
+	return clockDeadlineContext{Context: cancelCtx, deadline: deadline}, func() { cancel(context.Canceled) }
+}
+
+// clockDeadlineContext is a context reporting a deadline according to a replaced clock of the SDK
+type clockDeadlineContext struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c clockDeadlineContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
@@ -0,0 +1,45 @@
+package openfeature
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldDiff is a field of the evaluation details differing between two evaluations, along with the field's value in
+// both of them
+type FieldDiff struct {
+	// Field is the name of the differing field of the evaluation details: "Value", "Variant" or "Reason"
+	Field string
+	A, B  interface{}
+}
+
+// Diff is the structured difference between two evaluations, listing the differing fields in the order value,
+// variant, reason. The Diff of equal evaluations is empty.
+type Diff []FieldDiff
+
+// String describes the differing fields, e.g. for logging the divergence of providers
+func (d Diff) String() string {
+	fields := make([]string, len(d))
+	for i, field := range d {
+		fields[i] = fmt.Sprintf("%s: %v != %v", field.Field, field.A, field.B)
+	}
+	return strings.Join(fields, ", ")
+}
+
+// CompareEvaluations compares the value, variant and reason of two evaluations of a flag, e.g. by the providers of a
+// shadow comparison, reporting whether the evaluations are equal and the Diff of the differing fields. Values are
+// compared with deep equality, so that object values of equal content are equal.
+func CompareEvaluations(a, b InterfaceEvaluationDetails) (bool, Diff) {
+	var diff Diff
+	if !reflect.DeepEqual(a.Value, b.Value) {
+		diff = append(diff, FieldDiff{Field: "Value", A: a.Value, B: b.Value})
+	}
+	if a.Variant != b.Variant {
+		diff = append(diff, FieldDiff{Field: "Variant", A: a.Variant, B: b.Variant})
+	}
+	if a.Reason != b.Reason {
+		diff = append(diff, FieldDiff{Field: "Reason", A: a.Reason, B: b.Reason})
+	}
+	return len(diff) == 0, diff
+}
@@ -0,0 +1,68 @@
+package openfeature
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareEvaluations(t *testing.T) {
+	details := func(value interface{}, variant string, reason Reason) InterfaceEvaluationDetails {
+		return InterfaceEvaluationDetails{
+			Value: value,
+			EvaluationDetails: EvaluationDetails{
+				FlagKey:          "flag",
+				ResolutionDetail: ResolutionDetail{Variant: variant, Reason: reason},
+			},
+		}
+	}
+
+	t.Run("equal evaluations", func(t *testing.T) {
+		equal, diff := CompareEvaluations(details(true, "on", StaticReason), details(true, "on", StaticReason))
+		if !equal || len(diff) != 0 {
+			t.Errorf("expected the evaluations to be equal, got %v", diff)
+		}
+	})
+
+	t.Run("object values are compared deeply", func(t *testing.T) {
+		a := details(map[string]interface{}{"limits": []interface{}{1, 2}}, "on", StaticReason)
+		b := details(map[string]interface{}{"limits": []interface{}{1, 2}}, "on", StaticReason)
+		if equal, diff := CompareEvaluations(a, b); !equal {
+			t.Errorf("expected object values of equal content to be equal, got %v", diff)
+		}
+
+		b = details(map[string]interface{}{"limits": []interface{}{1, 3}}, "on", StaticReason)
+		if equal, _ := CompareEvaluations(a, b); equal {
+			t.Error("expected object values of different content to differ")
+		}
+	})
+
+	t.Run("value differs", func(t *testing.T) {
+		equal, diff := CompareEvaluations(details("blue", "on", StaticReason), details("red", "on", StaticReason))
+		expected := Diff{{Field: "Value", A: "blue", B: "red"}}
+		if equal || !reflect.DeepEqual(diff, expected) {
+			t.Errorf("expected diff %v, got %v", expected, diff)
+		}
+	})
+
+	t.Run("reason differs", func(t *testing.T) {
+		equal, diff := CompareEvaluations(details(true, "on", StaticReason), details(true, "on", TargetingMatchReason))
+		expected := Diff{{Field: "Reason", A: StaticReason, B: TargetingMatchReason}}
+		if equal || !reflect.DeepEqual(diff, expected) {
+			t.Errorf("expected diff %v, got %v", expected, diff)
+		}
+		if diff.String() != "Reason: STATIC != TARGETING_MATCH" {
+			t.Errorf("unexpected description %q", diff.String())
+		}
+	})
+
+	t.Run("all fields differ", func(t *testing.T) {
+		_, diff := CompareEvaluations(details(true, "on", StaticReason), details(false, "off", DefaultReason))
+		fields := make([]string, len(diff))
+		for i, field := range diff {
+			fields[i] = field.Field
+		}
+		if expected := []string{"Value", "Variant", "Reason"}; !reflect.DeepEqual(fields, expected) {
+			t.Errorf("expected differing fields %v, got %v", expected, fields)
+		}
+	})
+}
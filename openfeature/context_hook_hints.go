@@ -0,0 +1,26 @@
+package openfeature
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature/internal"
+)
+
+// WithContextHookHints returns a copy of the context carrying the hook hints, given to the hooks of all evaluations
+// performed with the context, e.g. for a middleware to enable debug hints for a whole request without changing the
+// call sites. The hints are merged with those the context already carries, taking precedence for keys present in both.
+//
+// Context hints take precedence over the hints of the client and of the flag, see Client.AddHookHints and
+// Client.AddFlagHints, and the hints given at the call site with WithHookHints take precedence over context hints.
+func WithContextHookHints(ctx context.Context, hints HookHints) context.Context {
+	if carried := ContextHookHints(ctx); len(carried.mapOfHints) > 0 {
+		hints = carried.Merge(hints)
+	}
+	return context.WithValue(ctx, internal.HookHints, hints)
+}
+
+// ContextHookHints returns the hook hints carried by the context, empty hints if none, see WithContextHookHints
+func ContextHookHints(ctx context.Context) HookHints {
+	hints, _ := ctx.Value(internal.HookHints).(HookHints)
+	return hints
+}
@@ -0,0 +1,61 @@
+package openfeature
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestContextHookHints(t *testing.T) {
+	t.Run("nested contexts merge their hints", func(t *testing.T) {
+		ctx := WithContextHookHints(context.Background(), NewHookHints(map[string]interface{}{"debug": true, "level": "info"}))
+		nested := WithContextHookHints(ctx, NewHookHints(map[string]interface{}{"level": "trace"}))
+
+		expected := map[string]interface{}{"debug": true, "level": "trace"}
+		if hints := ContextHookHints(nested).Values(); !reflect.DeepEqual(hints, expected) {
+			t.Errorf("expected hints %v, got %v", expected, hints)
+		}
+		if level := ContextHookHints(ctx).Value("level"); level != "info" {
+			t.Errorf("expected the outer context to keep its hints, got %v", level)
+		}
+	})
+
+	t.Run("request-scoped hints reach the hooks", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		client := NewClient(t.Name())
+		var hints HookHints
+		client.AddHooks(hintsRecordingHook{hints: &hints})
+		client.AddHookHints(NewHookHints(map[string]interface{}{"team": "checkout", "level": "info"}))
+
+		request := WithContextHookHints(context.Background(), NewHookHints(map[string]interface{}{"level": "debug", "trace": true}))
+		tests := map[string]struct {
+			ctx      context.Context
+			options  []Option
+			expected map[string]interface{}
+		}{
+			"context hints take precedence over client hints": {
+				ctx:      request,
+				expected: map[string]interface{}{"team": "checkout", "level": "debug", "trace": true},
+			},
+			"call hints take precedence over context hints": {
+				ctx:      request,
+				options:  []Option{WithHookHints(NewHookHints(map[string]interface{}{"trace": false}))},
+				expected: map[string]interface{}{"team": "checkout", "level": "debug", "trace": false},
+			},
+			"context hints do not apply outside the context": {
+				ctx:      context.Background(),
+				expected: map[string]interface{}{"team": "checkout", "level": "info"},
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := client.BooleanValue(test.ctx, "foo", false, EvaluationContext{}, test.options...); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(hints.Values(), test.expected) {
+					t.Errorf("expected hints %v, got %v", test.expected, hints.Values())
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package openfeature
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// maxExactFloat is the largest integer magnitude a float64 can represent without loss of precision
+const maxExactFloat = 1 << 53
+
+// toInt64 converts integer values, and float values without a fractional part, to int64.
+// This covers the common shapes produced by JSON decoding, including json.Number, and by Go callers alike.
+// Returns false if the value is not numeric or cannot be converted without loss.
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case float32:
+		return floatToInt64(float64(v))
+	case float64:
+		return floatToInt64(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return floatToInt64(f)
+	default:
+		return 0, false
+	}
+}
+
+func floatToInt64(f float64) (int64, bool) {
+	if f != math.Trunc(f) || math.Abs(f) > maxExactFloat {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// toFloat64 converts float values, and integer values that fit into a float64 without loss, to float64.
+// Returns false if the value is not numeric or cannot be converted without loss.
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return toFloat64(i)
+		}
+		f, err := v.Float64()
+		return f, err == nil
+	}
+
+	i, ok := toInt64(value)
+	if !ok || i > maxExactFloat || i < -maxExactFloat {
+		return 0, false
+	}
+	return float64(i), true
+}
@@ -0,0 +1,21 @@
+package openfeature
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature/internal"
+)
+
+// WithCorrelationID returns a copy of the context carrying the correlation ID, e.g. the ID of the request being
+// served, so that hooks can attach it to the telemetry of the evaluations performed with the context. The built-in
+// logging and tracing hooks include it when present.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, internal.CorrelationID, id)
+}
+
+// CorrelationID returns the correlation ID carried by the context, reporting whether the context carries one, see
+// WithCorrelationID
+func CorrelationID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(internal.CorrelationID).(string)
+	return id, ok
+}
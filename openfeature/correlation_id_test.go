@@ -0,0 +1,30 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCorrelationID(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		ctx := WithCorrelationID(context.Background(), "request-42")
+
+		id, ok := CorrelationID(ctx)
+		if !ok || id != "request-42" {
+			t.Errorf("expected the correlation ID carried by the context, got %q, %v", id, ok)
+		}
+		if id, _ := CorrelationID(WithCorrelationID(ctx, "request-43")); id != "request-43" {
+			t.Errorf("expected the innermost correlation ID to win, got %q", id)
+		}
+	})
+
+	t.Run("absence", func(t *testing.T) {
+		if id, ok := CorrelationID(context.Background()); ok || id != "" {
+			t.Errorf("expected no correlation ID, got %q, %v", id, ok)
+		}
+		type otherKey string
+		if _, ok := CorrelationID(context.WithValue(context.Background(), otherKey("correlationID"), "request-42")); ok {
+			t.Error("expected correlation IDs stored under other keys to be ignored")
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package openfeature
+
+import (
+	"container/list"
+	"encoding/json"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultCacheMaxEntries bounds the evaluation cache if CacheConfig.MaxEntries is not set
+const defaultCacheMaxEntries = 1000
+
+// CacheConfig configures the evaluation cache of a client, see Client.WithEvaluationCache
+type CacheConfig struct {
+	// TTL is how long a resolution is cached for. A zero TTL keeps resolutions until they are evicted or invalidated.
+	TTL time.Duration
+	// MaxEntries bounds the number of cached resolutions, evicting the least recently used ones. Defaults to 1000.
+	MaxEntries int
+	// RunHooks runs the hooks for evaluations served from the cache, which skip the hooks by default
+	RunHooks bool
+}
+
+// cacheKey identifies a resolution by provider, flag and a hash of the default value & flattened evaluation context
+type cacheKey struct {
+	provider string
+	flag     string
+	flagType Type
+	hash     uint64
+}
+
+type cacheEntry struct {
+	key        cacheKey
+	resolution InterfaceResolutionDetail
+	expires    time.Time
+}
+
+// evaluationCache is a TTL & LRU bounded cache of flag resolutions, safe for concurrent use
+type evaluationCache struct {
+	config  CacheConfig
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+func newEvaluationCache(config CacheConfig) *evaluationCache {
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = defaultCacheMaxEntries
+	}
+	return &evaluationCache{
+		config:  config,
+		entries: map[cacheKey]*list.Element{},
+		lru:     list.New(),
+		now:     func() time.Time { return CurrentClock().Now() },
+	}
+}
+
+// newCacheKey builds the cache key of a flag evaluation. Evaluations with a default value or evaluation context
+// which cannot be encoded are not cacheable.
+func newCacheKey(provider string, flag string, flagType Type, defaultValue interface{}, flatCtx FlattenedContext) (cacheKey, bool) {
+	// maps are encoded with sorted keys, making the encoding deterministic
+	encoded, err := json.Marshal([]interface{}{defaultValue, flatCtx})
+	if err != nil {
+		return cacheKey{}, false
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write(encoded)
+	return cacheKey{provider: provider, flag: flag, flagType: flagType, hash: hash.Sum64()}, true
+}
+
+// get returns the cached resolution of the key, if it has not expired
+func (c *evaluationCache) get(key cacheKey) (InterfaceResolutionDetail, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return InterfaceResolutionDetail{}, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.remove(element)
+		return InterfaceResolutionDetail{}, false
+	}
+
+	c.lru.MoveToFront(element)
+	return entry.resolution, true
+}
+
+// set caches the resolution of the key, evicting the least recently used resolution if the cache is full
+func (c *evaluationCache) set(key cacheKey, resolution InterfaceResolutionDetail) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, resolution: resolution}
+	if c.config.TTL > 0 {
+		entry.expires = c.now().Add(c.config.TTL)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	if c.lru.Len() > c.config.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// invalidate removes the cached resolutions of the given flags, or all cached resolutions if no flag is given
+func (c *evaluationCache) invalidate(flags []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(flags) == 0 {
+		c.entries = map[cacheKey]*list.Element{}
+		c.lru.Init()
+		return
+	}
+
+	for key, element := range c.entries {
+		if slices.Contains(flags, key.flag) {
+			c.remove(element)
+		}
+	}
+}
+
+func (c *evaluationCache) remove(element *list.Element) {
+	delete(c.entries, element.Value.(*cacheEntry).key)
+	c.lru.Remove(element)
+}
@@ -0,0 +1,225 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestEvaluationCache(t *testing.T) {
+	key := func(flag string) cacheKey {
+		k, ok := newCacheKey("provider", flag, Boolean, false, FlattenedContext{"user": "a"})
+		if !ok {
+			t.Fatalf("expected flag %s to be cacheable", flag)
+		}
+		return k
+	}
+
+	t.Run("keys depend on the evaluation context", func(t *testing.T) {
+		a, _ := newCacheKey("provider", "foo", Boolean, false, FlattenedContext{"user": "a", "plan": "pro"})
+		b, _ := newCacheKey("provider", "foo", Boolean, false, FlattenedContext{"plan": "pro", "user": "a"})
+		c, _ := newCacheKey("provider", "foo", Boolean, false, FlattenedContext{"user": "b", "plan": "pro"})
+		if a != b {
+			t.Error("expected equal contexts to have the same key")
+		}
+		if a == c {
+			t.Error("expected different contexts to have different keys")
+		}
+		if _, ok := newCacheKey("provider", "foo", Boolean, false, FlattenedContext{"fn": func() {}}); ok {
+			t.Error("expected a context which cannot be encoded to not be cacheable")
+		}
+	})
+
+	t.Run("resolutions expire after the TTL", func(t *testing.T) {
+		now := time.Now()
+		cache := newEvaluationCache(CacheConfig{TTL: time.Minute})
+		cache.now = func() time.Time { return now }
+
+		cache.set(key("foo"), InterfaceResolutionDetail{Value: true})
+		if _, ok := cache.get(key("foo")); !ok {
+			t.Fatal("expected a cache hit")
+		}
+
+		now = now.Add(time.Minute)
+		if _, ok := cache.get(key("foo")); ok {
+			t.Error("expected the resolution to have expired")
+		}
+	})
+
+	t.Run("least recently used resolutions are evicted", func(t *testing.T) {
+		cache := newEvaluationCache(CacheConfig{MaxEntries: 2})
+		cache.set(key("foo"), InterfaceResolutionDetail{Value: true})
+		cache.set(key("bar"), InterfaceResolutionDetail{Value: true})
+		cache.get(key("foo"))
+		cache.set(key("baz"), InterfaceResolutionDetail{Value: true})
+
+		if _, ok := cache.get(key("bar")); ok {
+			t.Error("expected the least recently used resolution to be evicted")
+		}
+		for _, flag := range []string{"foo", "baz"} {
+			if _, ok := cache.get(key(flag)); !ok {
+				t.Errorf("expected flag %s to be cached", flag)
+			}
+		}
+	})
+
+	t.Run("resolutions are invalidated by flag", func(t *testing.T) {
+		cache := newEvaluationCache(CacheConfig{})
+		cache.set(key("foo"), InterfaceResolutionDetail{Value: true})
+		cache.set(key("bar"), InterfaceResolutionDetail{Value: true})
+
+		cache.invalidate([]string{"foo"})
+		if _, ok := cache.get(key("foo")); ok {
+			t.Error("expected flag foo to be invalidated")
+		}
+		if _, ok := cache.get(key("bar")); !ok {
+			t.Error("expected flag bar to remain cached")
+		}
+
+		cache.invalidate(nil)
+		if _, ok := cache.get(key("bar")); ok {
+			t.Error("expected all flags to be invalidated")
+		}
+	})
+}
+
+func TestClient_WithEvaluationCache(t *testing.T) {
+	setup := func(t *testing.T, evaluations int, config CacheConfig) (clientMocks, *Client, *EventCallback) {
+		mocks := hydratedMocksForClientTests(t, evaluations)
+		var invalidation EventCallback
+		mocks.clientHandlerAPI.EXPECT().AddClientHandler("test-client", ProviderConfigChange, gomock.Any()).
+			Do(func(_ string, _ EventType, callback EventCallback) { invalidation = callback })
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI).WithEvaluationCache(config)
+		return mocks, client, &invalidation
+	}
+	evalCtx := NewEvaluationContext("user", nil)
+
+	t.Run("cache hits skip the provider and the hooks", func(t *testing.T) {
+		mocks, client, _ := setup(t, 2, CacheConfig{})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(1).
+			Return(BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}})
+
+		calls := 0
+		for i := 0; i < 2; i++ {
+			details, err := client.BooleanValueDetails(context.Background(), "foo", false, evalCtx, WithHooks(countingHook{calls: &calls}))
+			if err != nil || details.Value != true {
+				t.Fatalf("expected true without error, got %v, %v", details.Value, err)
+			}
+			if expected := []Reason{StaticReason, CachedReason}[i]; details.Reason != expected {
+				t.Errorf("expected reason %s for evaluation %d, got %s", expected, i, details.Reason)
+			}
+		}
+		if calls != 3 {
+			t.Errorf("expected the hooks to only run for the cache miss, got %d calls", calls)
+		}
+	})
+
+	t.Run("batch evaluations use and fill the cache", func(t *testing.T) {
+		mocks, client, _ := setup(t, 3, CacheConfig{})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(1).
+			Return(BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}})
+		requests := []FlagRequest{{Key: "foo", Type: Boolean, DefaultValue: false}}
+
+		results, err := client.EvaluateBatch(context.Background(), requests, evalCtx)
+		if err != nil || results[0].Value != true || results[0].Reason != StaticReason {
+			t.Fatalf("expected true with the %s reason, got %+v, %v", StaticReason, results[0], err)
+		}
+		details, err := client.BooleanValueDetails(context.Background(), "foo", false, evalCtx)
+		if err != nil || details.Value != true || details.Reason != CachedReason {
+			t.Errorf("expected the batch resolution to be cached, got %+v, %v", details, err)
+		}
+		results, err = client.EvaluateBatch(context.Background(), requests, evalCtx)
+		if err != nil || results[0].Value != true || results[0].Reason != CachedReason || !results[0].FromCache {
+			t.Errorf("expected the batch to be served from the cache, got %+v, %v", results[0], err)
+		}
+	})
+
+	t.Run("hooks run on cache hits when enabled", func(t *testing.T) {
+		mocks, client, _ := setup(t, 2, CacheConfig{RunHooks: true})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(1).
+			Return(BoolResolutionDetail{Value: true})
+
+		calls := 0
+		for i := 0; i < 2; i++ {
+			_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx, WithHooks(countingHook{calls: &calls}))
+		}
+		if calls != 6 {
+			t.Errorf("expected the hooks to run for both evaluations, got %d calls", calls)
+		}
+	})
+
+	t.Run("cache hits are reported to the caller and the hooks", func(t *testing.T) {
+		mocks, client, _ := setup(t, 2, CacheConfig{TTL: time.Minute, RunHooks: true})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(1).
+			Return(BoolResolutionDetail{Value: true})
+
+		for i, expected := range []bool{false, true} {
+			var hookDetails InterfaceEvaluationDetails
+			details, err := client.BooleanValueDetails(context.Background(), "foo", false, evalCtx,
+				WithHooks(finallyDetailsHook{details: &hookDetails}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if details.FromCache != expected || hookDetails.FromCache != expected {
+				t.Errorf("expected FromCache %t for evaluation %d, got %t (hooks: %t)", expected, i, details.FromCache, hookDetails.FromCache)
+			}
+		}
+	})
+
+	t.Run("evaluations without cache are not reported as cache hits", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 2)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(2).
+			Return(BoolResolutionDetail{Value: true})
+
+		for i := 0; i < 2; i++ {
+			if details, _ := client.BooleanValueDetails(context.Background(), "foo", false, evalCtx); details.FromCache {
+				t.Errorf("expected evaluation %d not to be served from the cache", i)
+			}
+		}
+	})
+
+	t.Run("errors are not cached and the cache can be bypassed", func(t *testing.T) {
+		mocks, client, _ := setup(t, 3, CacheConfig{})
+		gomock.InOrder(
+			mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).
+				Return(BoolResolutionDetail{ProviderResolutionDetail: ProviderResolutionDetail{ResolutionError: NewGeneralResolutionError("forced")}}),
+			mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(2).
+				Return(BoolResolutionDetail{Value: true}),
+		)
+
+		_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx)
+		_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx, WithoutEvaluationCache())
+		_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx)
+	})
+
+	t.Run("configuration changes invalidate the changed flags", func(t *testing.T) {
+		mocks, client, invalidation := setup(t, 4, CacheConfig{})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(2).
+			Return(BoolResolutionDetail{Value: true})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "bar", false, gomock.Any()).Times(1).
+			Return(BoolResolutionDetail{Value: true})
+
+		_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx)
+		_, _ = client.BooleanValue(context.Background(), "bar", false, evalCtx)
+		(**invalidation)(EventDetails{ProviderEventDetails: ProviderEventDetails{FlagChanges: []string{"foo"}}})
+		_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx)
+		_, _ = client.BooleanValue(context.Background(), "bar", false, evalCtx)
+	})
+
+	t.Run("configuration changes without flag changes invalidate all flags", func(t *testing.T) {
+		mocks, client, invalidation := setup(t, 4, CacheConfig{})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Times(2).
+			Return(BoolResolutionDetail{Value: true})
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "bar", false, gomock.Any()).Times(2).
+			Return(BoolResolutionDetail{Value: true})
+
+		_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx)
+		_, _ = client.BooleanValue(context.Background(), "bar", false, evalCtx)
+		(**invalidation)(EventDetails{})
+		_, _ = client.BooleanValue(context.Background(), "foo", false, evalCtx)
+		_, _ = client.BooleanValue(context.Background(), "bar", false, evalCtx)
+	})
+}
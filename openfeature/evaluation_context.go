@@ -1,7 +1,12 @@
 package openfeature
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/open-feature/go-sdk/openfeature/internal"
 )
@@ -25,6 +30,49 @@ func (e EvaluationContext) TargetingKey() string {
 	return e.targetingKey
 }
 
+// StringAttribute returns the string attribute with the given key.
+// The boolean result is false if the attribute does not exist or is not a string.
+func (e EvaluationContext) StringAttribute(key string) (string, bool) {
+	v, ok := e.attributes[key].(string)
+	return v, ok
+}
+
+// BoolAttribute returns the boolean attribute with the given key.
+// The boolean result is false if the attribute does not exist or is not a boolean.
+func (e EvaluationContext) BoolAttribute(key string) (bool, bool) {
+	v, ok := e.attributes[key].(bool)
+	return v, ok
+}
+
+// IntAttribute returns the attribute with the given key as an int64.
+// Any integer type, as well as floats without a fractional part and json.Number (e.g. JSON decoded numbers), are
+// converted. The boolean result is false if the attribute does not exist or cannot be converted without loss.
+func (e EvaluationContext) IntAttribute(key string) (int64, bool) {
+	return toInt64(e.attributes[key])
+}
+
+// FloatAttribute returns the attribute with the given key as a float64.
+// Float types, json.Number, as well as integers that can be represented exactly, are converted.
+// The boolean result is false if the attribute does not exist or cannot be converted without loss.
+func (e EvaluationContext) FloatAttribute(key string) (float64, bool) {
+	return toFloat64(e.attributes[key])
+}
+
+// TimeAttribute returns the attribute with the given key as a time.Time.
+// Strings in the RFC 3339 format (e.g. JSON encoded times) are parsed.
+// The boolean result is false if the attribute does not exist or is neither a time.Time nor an RFC 3339 string.
+func (e EvaluationContext) TimeAttribute(key string) (time.Time, bool) {
+	switch v := e.attributes[key].(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
 // Attributes returns a copy of the EvaluationContext's attributes
 func (e EvaluationContext) Attributes() map[string]interface{} {
 	// copy attributes to new map to prevent mutation (maps are passed by reference)
@@ -36,6 +84,165 @@ func (e EvaluationContext) Attributes() map[string]interface{} {
 	return attrs
 }
 
+// Clone returns a deep copy of the EvaluationContext with the same targeting key. Attributes holding maps or slices
+// are copied recursively, so mutating them in the clone, e.g. in a hook returning a modified context, leaves the
+// original untouched. Other reference types such as pointers are shared.
+func (e EvaluationContext) Clone() EvaluationContext {
+	if e.attributes == nil {
+		return e
+	}
+
+	attrs := make(map[string]interface{}, len(e.attributes))
+	for key, value := range e.attributes {
+		attrs[key] = cloneAttribute(value)
+	}
+
+	return EvaluationContext{
+		targetingKey: e.targetingKey,
+		attributes:   attrs,
+	}
+}
+
+// marshaledEvaluationContext is the JSON representation of an EvaluationContext
+type marshaledEvaluationContext struct {
+	TargetingKey string                 `json:"targetingKey,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// MarshalJSON encodes the targeting key and the attributes of the evaluation context, e.g. to pass it across service
+// boundaries, as {"targetingKey": "...", "attributes": {...}}. Empty fields are omitted. The context.Context of an
+// evaluation, and the transaction context it carries, are not part of the EvaluationContext and never encoded.
+func (e EvaluationContext) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshaledEvaluationContext{
+		TargetingKey: e.targetingKey,
+		Attributes:   e.attributes,
+	})
+}
+
+// UnmarshalJSON decodes an evaluation context encoded by MarshalJSON, replacing the targeting key and attributes of
+// the evaluation context.
+//
+// Attribute types are preserved as faithfully as JSON allows: strings, booleans, nested objects (as
+// map[string]interface{}) and arrays (as []interface{}) round-trip as such. JSON does not distinguish integers from
+// floats, so numbers without a fractional part or exponent decode as int64, including floats with an integral value
+// such as 2.0, and any other number decodes as float64; IntAttribute and FloatAttribute convert between them. Times
+// decode as their RFC 3339 string, which TimeAttribute parses.
+func (e *EvaluationContext) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded marshaledEvaluationContext
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+
+	for key, value := range decoded.Attributes {
+		decoded.Attributes[key] = decodeJSONNumbers(value)
+	}
+	e.targetingKey = decoded.TargetingKey
+	e.attributes = decoded.Attributes
+	return nil
+}
+
+// decodeJSONNumbers converts the json.Number values of a decoded JSON value to int64 if they are integers, and to
+// float64 otherwise, recursing into objects and arrays
+func decodeJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = decodeJSONNumbers(nested)
+		}
+		return v
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = decodeJSONNumbers(nested)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// RedactedValue replaces the values of sensitive attributes in the views returned by RedactingContextView
+const RedactedValue = "[REDACTED]"
+
+// RedactingContextView returns a copy of the evaluation context whose sensitive attributes are masked with
+// RedactedValue, e.g. for hooks logging or tracing the context without leaking PII. Sensitive keys match attributes at
+// any depth of nested maps, and TargetingKey masks the targeting key. The given context is left untouched.
+func RedactingContextView(evalCtx EvaluationContext, sensitiveKeys []string) EvaluationContext {
+	if len(sensitiveKeys) == 0 {
+		return evalCtx
+	}
+
+	targetingKey := evalCtx.targetingKey
+	if targetingKey != "" && slices.Contains(sensitiveKeys, TargetingKey) {
+		targetingKey = RedactedValue
+	}
+	return EvaluationContext{
+		targetingKey: targetingKey,
+		attributes:   redactAttributes(evalCtx.attributes, sensitiveKeys),
+	}
+}
+
+// redactAttributes returns a copy of the attributes with the values of the sensitive keys masked, recursing into
+// nested maps
+func redactAttributes(attributes map[string]interface{}, sensitiveKeys []string) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		if slices.Contains(sensitiveKeys, key) {
+			redacted[key] = RedactedValue
+		} else if nested, ok := nestedMap(value); ok {
+			redacted[key] = redactAttributes(nested, sensitiveKeys)
+		} else {
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
+
+// cloneAttribute deep copies maps and slices, returning any other value as is
+func cloneAttribute(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() {
+			return value
+		}
+		cloned := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			cloned.SetMapIndex(iter.Key(), cloneReflected(iter.Value(), rv.Type().Elem()))
+		}
+		return cloned.Interface()
+	case reflect.Slice:
+		if rv.IsNil() {
+			return value
+		}
+		cloned := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			cloned.Index(i).Set(cloneReflected(rv.Index(i), rv.Type().Elem()))
+		}
+		return cloned.Interface()
+	default:
+		return value
+	}
+}
+
+// cloneReflected deep copies the reflected value, converting the copy back to the element type of its container
+func cloneReflected(value reflect.Value, elemType reflect.Type) reflect.Value {
+	if value.Kind() == reflect.Interface && value.IsNil() {
+		return reflect.Zero(elemType)
+	}
+	return reflect.ValueOf(cloneAttribute(value.Interface())).Convert(elemType)
+}
+
 // NewEvaluationContext constructs an EvaluationContext
 //
 // targetingKey - uniquely identifying the subject (end-user, or client service) of a flag evaluation
@@ -60,7 +267,120 @@ func NewTargetlessEvaluationContext(attributes map[string]interface{}) Evaluatio
 	return NewEvaluationContext("", attributes)
 }
 
-// NewTransactionContext constructs a TransactionContext
+// EvaluationContextBuilder assembles an EvaluationContext attribute by attribute, e.g. at call sites building the
+// context dynamically. Attributes set later override earlier ones with the same key.
+//
+// e.g.
+//
+//	evalCtx := NewEvaluationContextBuilder().
+//		WithTargetingKey("user-123").
+//		WithAttribute("plan", "pro").
+//		Build()
+type EvaluationContextBuilder struct {
+	targetingKey string
+	attributes   map[string]interface{}
+}
+
+// NewEvaluationContextBuilder constructs an empty EvaluationContextBuilder
+func NewEvaluationContextBuilder() *EvaluationContextBuilder {
+	return &EvaluationContextBuilder{attributes: map[string]interface{}{}}
+}
+
+// WithTargetingKey sets the targeting key of the built EvaluationContext
+func (b *EvaluationContextBuilder) WithTargetingKey(targetingKey string) *EvaluationContextBuilder {
+	b.targetingKey = targetingKey
+	return b
+}
+
+// WithAttribute sets the attribute of the built EvaluationContext
+func (b *EvaluationContextBuilder) WithAttribute(key string, value interface{}) *EvaluationContextBuilder {
+	b.attributes[key] = value
+	return b
+}
+
+// WithAttributes sets all the given attributes of the built EvaluationContext
+func (b *EvaluationContextBuilder) WithAttributes(attributes map[string]interface{}) *EvaluationContextBuilder {
+	for key, value := range attributes {
+		b.attributes[key] = value
+	}
+	return b
+}
+
+// WithContext sets the attributes of the given EvaluationContext, and its targeting key unless empty
+func (b *EvaluationContextBuilder) WithContext(evalCtx EvaluationContext) *EvaluationContextBuilder {
+	if evalCtx.targetingKey != "" {
+		b.targetingKey = evalCtx.targetingKey
+	}
+	return b.WithAttributes(evalCtx.attributes)
+}
+
+// Build returns the EvaluationContext. Later changes to the builder do not affect previously built
+// EvaluationContexts.
+func (b *EvaluationContextBuilder) Build() EvaluationContext {
+	return NewEvaluationContext(b.targetingKey, b.attributes)
+}
+
+// ContextDiff is the difference between two evaluation contexts, see DiffEvaluationContexts
+type ContextDiff struct {
+	// TargetingKey is the change of the targeting key, nil if the targeting keys are equal
+	TargetingKey *TargetingKeyChange
+	// Added are the attributes of the later context missing from the earlier context
+	Added map[string]interface{}
+	// Removed are the attributes of the earlier context missing from the later context, with their earlier values
+	Removed map[string]interface{}
+	// Changed are the attributes of both contexts whose values differ
+	Changed map[string]AttributeChange
+}
+
+// TargetingKeyChange is the change of the targeting key between two evaluation contexts
+type TargetingKeyChange struct {
+	Before string
+	After  string
+}
+
+// AttributeChange is the change of the value of an attribute between two evaluation contexts
+type AttributeChange struct {
+	Before interface{}
+	After  interface{}
+}
+
+// IsEmpty reports whether the diffed contexts are equal
+func (d ContextDiff) IsEmpty() bool {
+	return d.TargetingKey == nil && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffEvaluationContexts reports the targeting key change and the attributes added, removed and changed from the
+// before context to the after context, e.g. to log what a before hook changed. Attribute values are compared deeply,
+// a change nested in an attribute holding a map or a slice reports the attribute as changed as a whole.
+func DiffEvaluationContexts(before, after EvaluationContext) ContextDiff {
+	diff := ContextDiff{
+		Added:   map[string]interface{}{},
+		Removed: map[string]interface{}{},
+		Changed: map[string]AttributeChange{},
+	}
+	if before.targetingKey != after.targetingKey {
+		diff.TargetingKey = &TargetingKeyChange{Before: before.targetingKey, After: after.targetingKey}
+	}
+	for key, afterValue := range after.attributes {
+		beforeValue, ok := before.attributes[key]
+		switch {
+		case !ok:
+			diff.Added[key] = afterValue
+		case !reflect.DeepEqual(beforeValue, afterValue):
+			diff.Changed[key] = AttributeChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	for key, beforeValue := range before.attributes {
+		if _, ok := after.attributes[key]; !ok {
+			diff.Removed[key] = beforeValue
+		}
+	}
+	return diff
+}
+
+// WithTransactionContext constructs a TransactionContext. Evaluations merge the TransactionContext with the other
+// evaluation contexts in the order: API (global) < transaction < client < invocation < enrichers < before hooks, later
+// contexts overriding duplicate attributes
 //
 // ctx - the context to embed the EvaluationContext in
 // ec - the EvaluationContext to embed into the context
@@ -78,6 +398,54 @@ func MergeTransactionContext(ctx context.Context, ec EvaluationContext) context.
 	return WithTransactionContext(ctx, mergedTc)
 }
 
+// MergeEvaluationContexts merges the given evaluation contexts the way the SDK merges the API (global), transaction,
+// client, invocation and before hooks contexts of an evaluation. The contexts are given from lowest to highest
+// precedence: attributes of later contexts override those of earlier ones, and the last non-empty targeting key wins.
+// Use the Merge method of a TargetingKeyStrategy to select the targeting key differently.
+//
+// Attributes are merged shallowly, an attribute holding a map replaces the map of an earlier context as a whole.
+func MergeEvaluationContexts(contexts ...EvaluationContext) EvaluationContext {
+	return LastNonEmptyWins.Merge(contexts...)
+}
+
+// TargetingKeyStrategy selects the targeting key of merged evaluation contexts, see WithTargetingKeyStrategy
+type TargetingKeyStrategy int
+
+const (
+	// LastNonEmptyWins selects the targeting key of the highest precedence context with a non-empty targeting key, as
+	// the specification requires. It is the default.
+	LastNonEmptyWins TargetingKeyStrategy = iota
+	// LastWins selects the targeting key of the highest precedence context, even if empty, so that a context without
+	// targeting key clears the targeting key of lower precedence contexts.
+	LastWins
+)
+
+// Merge merges the given evaluation contexts like MergeEvaluationContexts, from lowest to highest precedence,
+// selecting the targeting key with the strategy
+func (s TargetingKeyStrategy) Merge(contexts ...EvaluationContext) EvaluationContext {
+	reversed := slices.Clone(contexts)
+	slices.Reverse(reversed)
+	return s.merge(reversed...)
+}
+
+// merge merges the given evaluation contexts from highest to lowest precedence, like mergeContexts, selecting the
+// targeting key with the strategy
+func (s TargetingKeyStrategy) merge(evaluationContexts ...EvaluationContext) EvaluationContext {
+	merged := mergeContexts(evaluationContexts...)
+	if s == LastWins && len(evaluationContexts) > 0 {
+		merged.targetingKey = evaluationContexts[0].targetingKey
+	}
+	return merged
+}
+
+// ContextEnricher attaches automatic attributes (e.g. hostname, region or SDK version) to the evaluation context of
+// every evaluation, see AddContextEnricher. The enricher receives the context.Context of the evaluation and its
+// evaluation context, merged from the API (global), transaction, client and invocation contexts.
+//
+// The returned evaluation context is merged over the given one: its attributes override those of the given context,
+// attributes it omits are kept, and its targeting key replaces the given one only if it is not empty.
+type ContextEnricher func(ctx context.Context, evalCtx EvaluationContext) EvaluationContext
+
 // TransactionContext extracts a EvaluationContext from the current
 // golang.org/x/net/context. if no EvaluationContext exist, it will construct
 // an empty EvaluationContext
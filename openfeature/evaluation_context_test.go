@@ -1,9 +1,13 @@
 package openfeature
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/open-feature/go-sdk/openfeature/internal"
@@ -250,3 +254,590 @@ func TestMergeTransactionContext(t *testing.T) {
 		)
 	}
 }
+
+// contextHook returns its evaluation context from the before stage
+type contextHook struct {
+	UnimplementedHook
+	evalCtx EvaluationContext
+}
+
+func (h contextHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	return &h.evalCtx, nil
+}
+
+// Evaluation contexts are merged in the order: API (global) < transaction < client < invocation < before hooks
+func TestEvaluationContextPrecedence(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	ctrl := gomock.NewController(t)
+
+	SetEvaluationContext(NewTargetlessEvaluationContext(map[string]interface{}{
+		"hook": "api", "invocation": "api", "client": "api", "transaction": "api", "api": "api",
+	}))
+	ctx := WithTransactionContext(context.Background(), NewEvaluationContext("transaction", map[string]interface{}{
+		"hook": "transaction", "invocation": "transaction", "client": "transaction", "transaction": "transaction",
+	}))
+
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+	if err := SetNamedProviderAndWait(t.Name(), mockProvider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	client := GetApiInstance().GetNamedClient(t.Name())
+	client.SetEvaluationContext(NewEvaluationContext("client", map[string]interface{}{
+		"hook": "client", "invocation": "client", "client": "client",
+	}))
+	invocationCtx := NewTargetlessEvaluationContext(map[string]interface{}{
+		"hook": "invocation", "invocation": "invocation",
+	})
+	hook := contextHook{evalCtx: NewTargetlessEvaluationContext(map[string]interface{}{"hook": "hook"})}
+
+	mockProvider.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), FlattenedContext{
+		TargetingKey:  "client",
+		"hook":        "hook",
+		"invocation":  "invocation",
+		"client":      "client",
+		"transaction": "transaction",
+		"api":         "api",
+	})
+
+	if _, err := client.StringValue(ctx, "foo", "bar", invocationCtx, WithHooks(hook)); err != nil {
+		t.Error(err)
+	}
+}
+
+// The client's evaluation context can be set concurrently with the client's evaluations
+func TestClientEvaluationContextConcurrency(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			client.SetEvaluationContext(NewEvaluationContext("client", map[string]interface{}{"generation": i}))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _ = client.BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+		}()
+	}
+	wg.Wait()
+
+	if client.EvaluationContext().TargetingKey() != "client" {
+		t.Errorf("expected the client's evaluation context to be set, got %v", client.EvaluationContext())
+	}
+}
+
+func TestMergeEvaluationContexts(t *testing.T) {
+	tests := map[string]struct {
+		contexts []EvaluationContext
+		expected EvaluationContext
+	}{
+		"no contexts": {
+			expected: EvaluationContext{},
+		},
+		"later attributes win": {
+			contexts: []EvaluationContext{
+				NewTargetlessEvaluationContext(map[string]interface{}{"a": 1, "b": 1}),
+				NewTargetlessEvaluationContext(map[string]interface{}{"b": 2, "c": 2}),
+			},
+			expected: NewTargetlessEvaluationContext(map[string]interface{}{"a": 1, "b": 2, "c": 2}),
+		},
+		"last non-empty targeting key wins": {
+			contexts: []EvaluationContext{
+				NewEvaluationContext("first", nil),
+				NewEvaluationContext("second", nil),
+				NewTargetlessEvaluationContext(nil),
+			},
+			expected: NewEvaluationContext("second", map[string]interface{}{}),
+		},
+		"empty targeting keys are kept empty": {
+			contexts: []EvaluationContext{
+				NewTargetlessEvaluationContext(nil),
+				NewTargetlessEvaluationContext(nil),
+			},
+			expected: NewTargetlessEvaluationContext(map[string]interface{}{}),
+		},
+		"nested maps are replaced rather than merged": {
+			contexts: []EvaluationContext{
+				NewTargetlessEvaluationContext(map[string]interface{}{"user": map[string]interface{}{"id": 1, "plan": "pro"}}),
+				NewTargetlessEvaluationContext(map[string]interface{}{"user": map[string]interface{}{"id": 2}}),
+			},
+			expected: NewTargetlessEvaluationContext(map[string]interface{}{"user": map[string]interface{}{"id": 2}}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			merged := MergeEvaluationContexts(test.contexts...)
+			if !reflect.DeepEqual(merged, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, merged)
+			}
+		})
+	}
+
+	t.Run("given contexts are not mutated", func(t *testing.T) {
+		first := NewTargetlessEvaluationContext(map[string]interface{}{"a": 1})
+		second := NewEvaluationContext("key", map[string]interface{}{"a": 2})
+
+		merged := MergeEvaluationContexts(first, second)
+		if merged.Attribute("a") != 2 {
+			t.Errorf("expected the later attribute, got %v", merged.Attribute("a"))
+		}
+		if first.Attribute("a") != 1 || first.TargetingKey() != "" {
+			t.Errorf("expected the first context to be left untouched, got %v", first)
+		}
+	})
+
+	t.Run("matches the evaluation merge order", func(t *testing.T) {
+		api := NewEvaluationContext("api", map[string]interface{}{"level": "api"})
+		client := NewEvaluationContext("client", map[string]interface{}{"level": "client"})
+		invocation := NewTargetlessEvaluationContext(map[string]interface{}{"level": "invocation"})
+
+		merged := MergeEvaluationContexts(api, client, invocation)
+		if expected := mergeContexts(invocation, client, api); !reflect.DeepEqual(merged, expected) {
+			t.Errorf("expected %v, got %v", expected, merged)
+		}
+	})
+}
+
+func TestEvaluationContext_Clone(t *testing.T) {
+	original := NewEvaluationContext("user", map[string]interface{}{
+		"plan":   "free",
+		"nested": map[string]interface{}{"region": "eu"},
+		"tags":   []interface{}{"beta", map[string]interface{}{"level": 1}},
+		"ids":    []string{"a", "b"},
+	})
+
+	clone := original.Clone()
+	if clone.TargetingKey() != "user" {
+		t.Errorf("expected targeting key %q, got %q", "user", clone.TargetingKey())
+	}
+	if !reflect.DeepEqual(clone.Attributes(), original.Attributes()) {
+		t.Fatalf("expected clone attributes %v, got %v", original.Attributes(), clone.Attributes())
+	}
+
+	attrs := clone.Attributes()
+	attrs["nested"].(map[string]interface{})["region"] = "us"
+	attrs["tags"].([]interface{})[0] = "alpha"
+	attrs["tags"].([]interface{})[1].(map[string]interface{})["level"] = 2
+	attrs["ids"].([]string)[0] = "z"
+
+	expected := map[string]interface{}{
+		"plan":   "free",
+		"nested": map[string]interface{}{"region": "eu"},
+		"tags":   []interface{}{"beta", map[string]interface{}{"level": 1}},
+		"ids":    []string{"a", "b"},
+	}
+	if !reflect.DeepEqual(original.Attributes(), expected) {
+		t.Errorf("expected mutating the clone to leave the original untouched, got %v", original.Attributes())
+	}
+
+	if empty := (EvaluationContext{}).Clone(); empty.attributes != nil || empty.TargetingKey() != "" {
+		t.Errorf("expected the clone of an empty context to be empty, got %v", empty)
+	}
+}
+
+func TestEvaluationContext_TypedAttributes(t *testing.T) {
+	const document = `{"plan": "premium", "beta": true, "age": 42, "score": 12.5, "big": 1e20, "signedUp": "2024-03-01T10:00:00Z"}`
+
+	decode := func(t *testing.T, useNumber bool) EvaluationContext {
+		t.Helper()
+		decoder := json.NewDecoder(bytes.NewBufferString(document))
+		if useNumber {
+			decoder.UseNumber()
+		}
+		var attributes map[string]interface{}
+		if err := decoder.Decode(&attributes); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return NewEvaluationContext("user", attributes)
+	}
+
+	for name, useNumber := range map[string]bool{"float64 numbers": false, "json.Number numbers": true} {
+		useNumber := useNumber
+		t.Run(name, func(t *testing.T) {
+			evalCtx := decode(t, useNumber)
+
+			if v, ok := evalCtx.StringAttribute("plan"); !ok || v != "premium" {
+				t.Errorf("expected (premium, true), got (%s, %t)", v, ok)
+			}
+			if v, ok := evalCtx.BoolAttribute("beta"); !ok || !v {
+				t.Errorf("expected (true, true), got (%t, %t)", v, ok)
+			}
+			if v, ok := evalCtx.IntAttribute("age"); !ok || v != 42 {
+				t.Errorf("expected (42, true), got (%d, %t)", v, ok)
+			}
+			if v, ok := evalCtx.FloatAttribute("age"); !ok || v != 42 {
+				t.Errorf("expected (42, true), got (%f, %t)", v, ok)
+			}
+			if v, ok := evalCtx.FloatAttribute("score"); !ok || v != 12.5 {
+				t.Errorf("expected (12.5, true), got (%f, %t)", v, ok)
+			}
+			for _, key := range []string{"score", "big", "plan", "missing"} {
+				if _, ok := evalCtx.IntAttribute(key); ok {
+					t.Errorf("expected attribute %s to not be convertible to int64", key)
+				}
+			}
+			expected := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+			if v, ok := evalCtx.TimeAttribute("signedUp"); !ok || !v.Equal(expected) {
+				t.Errorf("expected (%s, true), got (%s, %t)", expected, v, ok)
+			}
+		})
+	}
+
+	t.Run("wrong types and missing attributes", func(t *testing.T) {
+		evalCtx := NewEvaluationContext("user", map[string]interface{}{"plan": "premium", "when": time.Unix(0, 0)})
+
+		if _, ok := evalCtx.BoolAttribute("plan"); ok {
+			t.Error("expected wrong type to report not found")
+		}
+		if _, ok := evalCtx.StringAttribute("missing"); ok {
+			t.Error("expected missing attribute to report not found")
+		}
+		if _, ok := evalCtx.TimeAttribute("plan"); ok {
+			t.Error("expected a string not in the RFC 3339 format to report not found")
+		}
+		if v, ok := evalCtx.TimeAttribute("when"); !ok || !v.Equal(time.Unix(0, 0)) {
+			t.Errorf("expected the time.Time attribute, got (%s, %t)", v, ok)
+		}
+	})
+}
+
+func TestAddContextEnricher(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	ctrl := gomock.NewController(t)
+
+	type ctxKey struct{}
+	var order []string
+	AddContextEnricher(func(ctx context.Context, evalCtx EvaluationContext) EvaluationContext {
+		order = append(order, "host")
+		return NewTargetlessEvaluationContext(map[string]interface{}{
+			"hostname": "host-1", "region": "eu", "request": ctx.Value(ctxKey{}),
+		})
+	})
+	AddContextEnricher(func(_ context.Context, evalCtx EvaluationContext) EvaluationContext {
+		order = append(order, "region")
+		region, _ := evalCtx.StringAttribute("region")
+		return NewTargetlessEvaluationContext(map[string]interface{}{"region": region + "-west"})
+	})
+
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+	if err := SetNamedProviderAndWait(t.Name(), mockProvider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := GetApiInstance().GetNamedClient(t.Name())
+
+	t.Run("enrichers run in registration order and keep the targeting key", func(t *testing.T) {
+		order = nil
+		mockProvider.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), FlattenedContext{
+			TargetingKey: "user",
+			"plan":       "premium",
+			"hostname":   "host-1",
+			"region":     "eu-west",
+			"request":    "request-1",
+		})
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "request-1")
+		invocationCtx := NewEvaluationContext("user", map[string]interface{}{"plan": "premium", "region": "us"})
+		if _, err := client.StringValue(ctx, "foo", "bar", invocationCtx); err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(order, []string{"host", "region"}) {
+			t.Errorf("expected the enrichers to run in registration order, got %v", order)
+		}
+	})
+
+	t.Run("enrichers may explicitly set the targeting key", func(t *testing.T) {
+		AddContextEnricher(func(context.Context, EvaluationContext) EvaluationContext {
+			return NewEvaluationContext("service", nil)
+		})
+		mockProvider.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, defaultValue string, flatCtx FlattenedContext) StringResolutionDetail {
+				if flatCtx[TargetingKey] != "service" {
+					t.Errorf("expected the enriched targeting key, got %v", flatCtx[TargetingKey])
+				}
+				return StringResolutionDetail{Value: defaultValue}
+			})
+
+		if _, err := client.StringValue(context.Background(), "foo", "bar", NewEvaluationContext("user", nil)); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestTargetingKeyStrategy(t *testing.T) {
+	inherited := NewEvaluationContext("client-key", map[string]interface{}{"plan": "pro"})
+	invocation := NewTargetlessEvaluationContext(map[string]interface{}{"region": "eu"})
+
+	t.Run("last non-empty targeting key wins by default", func(t *testing.T) {
+		merged := MergeEvaluationContexts(inherited, invocation)
+		if merged.TargetingKey() != "client-key" {
+			t.Errorf("expected the inherited targeting key, got %q", merged.TargetingKey())
+		}
+		if !reflect.DeepEqual(merged, LastNonEmptyWins.Merge(inherited, invocation)) {
+			t.Error("expected MergeEvaluationContexts to merge with LastNonEmptyWins")
+		}
+	})
+
+	t.Run("empty targeting key clears the inherited key with LastWins", func(t *testing.T) {
+		merged := LastWins.Merge(inherited, invocation)
+		if merged.TargetingKey() != "" {
+			t.Errorf("expected the targeting key to be cleared, got %q", merged.TargetingKey())
+		}
+		if merged.Attribute("plan") != "pro" || merged.Attribute("region") != "eu" {
+			t.Errorf("expected the attributes of both contexts, got %v", merged.Attributes())
+		}
+	})
+
+	t.Run("evaluation option", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 2)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		client.SetEvaluationContext(inherited)
+
+		var targetingKeys []interface{}
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).Times(2).
+			DoAndReturn(func(_ context.Context, _ string, _ bool, flatCtx FlattenedContext) BoolResolutionDetail {
+				targetingKeys = append(targetingKeys, flatCtx[TargetingKey])
+				return BoolResolutionDetail{}
+			})
+
+		_, _ = client.BooleanValue(context.Background(), "flag", false, invocation)
+		_, _ = client.BooleanValue(context.Background(), "flag", false, invocation, WithTargetingKeyStrategy(LastWins))
+
+		if !reflect.DeepEqual(targetingKeys, []interface{}{"client-key", nil}) {
+			t.Errorf("expected the explicit empty invocation key to clear the client key with LastWins, got %v", targetingKeys)
+		}
+	})
+}
+
+func TestRedactingContextView(t *testing.T) {
+	evalCtx := NewEvaluationContext("user-1", map[string]interface{}{
+		"email": "user@example.com",
+		"plan":  "pro",
+		"billing": map[string]interface{}{
+			"email":   "billing@example.com",
+			"country": "NL",
+		},
+	})
+
+	t.Run("sensitive attributes are masked at any depth", func(t *testing.T) {
+		redacted := RedactingContextView(evalCtx, []string{"email"})
+
+		if redacted.TargetingKey() != "user-1" {
+			t.Errorf("expected the targeting key to pass through, got %s", redacted.TargetingKey())
+		}
+		if redacted.Attribute("email") != RedactedValue {
+			t.Errorf("expected email to be masked, got %v", redacted.Attribute("email"))
+		}
+		if redacted.Attribute("plan") != "pro" {
+			t.Errorf("expected plan to pass through, got %v", redacted.Attribute("plan"))
+		}
+		billing, ok := redacted.Attribute("billing").(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected billing to remain a map, got %T", redacted.Attribute("billing"))
+		}
+		if billing["email"] != RedactedValue || billing["country"] != "NL" {
+			t.Errorf("expected only the nested email to be masked, got %v", billing)
+		}
+	})
+
+	t.Run("targeting key is masked when listed", func(t *testing.T) {
+		redacted := RedactingContextView(evalCtx, []string{TargetingKey})
+
+		if redacted.TargetingKey() != RedactedValue {
+			t.Errorf("expected the targeting key to be masked, got %s", redacted.TargetingKey())
+		}
+		if redacted.Attribute("email") != "user@example.com" {
+			t.Errorf("expected email to pass through, got %v", redacted.Attribute("email"))
+		}
+	})
+
+	t.Run("original context is untouched", func(t *testing.T) {
+		RedactingContextView(evalCtx, []string{"email", TargetingKey})
+
+		if evalCtx.TargetingKey() != "user-1" || evalCtx.Attribute("email") != "user@example.com" {
+			t.Errorf("expected the original context to be untouched, got %v", evalCtx)
+		}
+		billing := evalCtx.Attribute("billing").(map[string]interface{})
+		if billing["email"] != "billing@example.com" {
+			t.Errorf("expected the original nested map to be untouched, got %v", billing)
+		}
+	})
+}
+
+func TestEvaluationContext_JSON(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	evalCtx := NewEvaluationContext("user-1", map[string]interface{}{
+		"plan":    "pro",
+		"beta":    true,
+		"seats":   int64(25),
+		"ratio":   0.75,
+		"created": created,
+		"billing": map[string]interface{}{"country": "NL", "cycle": int64(12)},
+		"tags":    []interface{}{"a", int64(1)},
+	})
+
+	t.Run("round trip preserves the targeting key and attributes", func(t *testing.T) {
+		data, err := json.Marshal(evalCtx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var decoded EvaluationContext
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if decoded.TargetingKey() != "user-1" {
+			t.Errorf("expected targeting key user-1, got %s", decoded.TargetingKey())
+		}
+		for _, key := range []string{"plan", "beta", "seats", "ratio", "billing", "tags"} {
+			if !reflect.DeepEqual(decoded.Attribute(key), evalCtx.Attribute(key)) {
+				t.Errorf("expected attribute %s to be %#v, got %#v", key, evalCtx.Attribute(key), decoded.Attribute(key))
+			}
+		}
+		if got, ok := decoded.TimeAttribute("created"); !ok || !got.Equal(created) {
+			t.Errorf("expected the time attribute to be parsed back, got %v", decoded.Attribute("created"))
+		}
+	})
+
+	t.Run("integral floats decode as integers", func(t *testing.T) {
+		data, err := json.Marshal(NewEvaluationContext("", map[string]interface{}{"score": 2.0}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var decoded EvaluationContext
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if decoded.Attribute("score") != int64(2) {
+			t.Errorf("expected int64(2), got %#v", decoded.Attribute("score"))
+		}
+		if score, ok := decoded.FloatAttribute("score"); !ok || score != 2.0 {
+			t.Errorf("expected FloatAttribute to convert the integer back, got %v", score)
+		}
+	})
+
+	t.Run("only the targeting key and attributes are encoded", func(t *testing.T) {
+		ctx := WithTransactionContext(context.Background(), NewEvaluationContext("user-1", map[string]interface{}{"plan": "pro"}))
+		data, err := json.Marshal(TransactionContext(ctx))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := `{"targetingKey":"user-1","attributes":{"plan":"pro"}}`; string(data) != expected {
+			t.Errorf("expected %s, got %s", expected, data)
+		}
+
+		data, err = json.Marshal(EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != "{}" {
+			t.Errorf("expected the empty context to encode as {}, got %s", data)
+		}
+	})
+}
+
+func TestEvaluationContextBuilder(t *testing.T) {
+	t.Run("later attributes override earlier ones", func(t *testing.T) {
+		evalCtx := NewEvaluationContextBuilder().
+			WithTargetingKey("user-1").
+			WithAttribute("plan", "free").
+			WithAttributes(map[string]interface{}{"plan": "pro", "region": "eu"}).
+			WithContext(NewEvaluationContext("", map[string]interface{}{"region": "us"})).
+			WithAttribute("beta", true).
+			Build()
+
+		expected := NewEvaluationContext("user-1", map[string]interface{}{"plan": "pro", "region": "us", "beta": true})
+		if !reflect.DeepEqual(evalCtx, expected) {
+			t.Errorf("expected %v, got %v", expected, evalCtx)
+		}
+	})
+
+	t.Run("a context overrides the targeting key unless empty", func(t *testing.T) {
+		builder := NewEvaluationContextBuilder().WithTargetingKey("user-1")
+		if key := builder.WithContext(NewTargetlessEvaluationContext(nil)).Build().TargetingKey(); key != "user-1" {
+			t.Errorf("expected the targeting key to be kept, got %q", key)
+		}
+		if key := builder.WithContext(NewEvaluationContext("user-2", nil)).Build().TargetingKey(); key != "user-2" {
+			t.Errorf("expected the targeting key to be overridden, got %q", key)
+		}
+	})
+
+	t.Run("built contexts are independent of the builder", func(t *testing.T) {
+		builder := NewEvaluationContextBuilder().WithTargetingKey("user-1").WithAttribute("plan", "free")
+		evalCtx := builder.Build()
+
+		builder.WithTargetingKey("user-2").WithAttribute("plan", "pro").WithAttribute("region", "eu")
+		if evalCtx.TargetingKey() != "user-1" || evalCtx.Attribute("plan") != "free" || evalCtx.Attribute("region") != nil {
+			t.Errorf("expected the built context to be unaffected by the builder, got %v", evalCtx)
+		}
+	})
+}
+
+func TestDiffEvaluationContexts(t *testing.T) {
+	before := NewEvaluationContext("user-1", map[string]interface{}{
+		"plan":   "free",
+		"region": "eu",
+		"legacy": true,
+		"user":   map[string]interface{}{"email": "user@example.com", "roles": []string{"admin"}},
+	})
+
+	t.Run("equal contexts", func(t *testing.T) {
+		if diff := DiffEvaluationContexts(before, before.Clone()); !diff.IsEmpty() {
+			t.Errorf("expected no difference, got %+v", diff)
+		}
+	})
+
+	t.Run("targeting key", func(t *testing.T) {
+		diff := DiffEvaluationContexts(before, NewEvaluationContext("user-2", before.Attributes()))
+		if diff.TargetingKey == nil || *diff.TargetingKey != (TargetingKeyChange{Before: "user-1", After: "user-2"}) {
+			t.Errorf("expected the targeting key change, got %v", diff.TargetingKey)
+		}
+		if len(diff.Added)+len(diff.Removed)+len(diff.Changed) != 0 {
+			t.Errorf("expected no attribute change, got %+v", diff)
+		}
+	})
+
+	t.Run("added, removed and changed attributes", func(t *testing.T) {
+		after := NewEvaluationContext("user-1", map[string]interface{}{
+			"plan":   "pro",
+			"region": "eu",
+			"beta":   true,
+			"user":   map[string]interface{}{"email": "user@example.com", "roles": []string{"admin"}},
+		})
+
+		diff := DiffEvaluationContexts(before, after)
+		if diff.TargetingKey != nil {
+			t.Errorf("expected no targeting key change, got %v", diff.TargetingKey)
+		}
+		if !reflect.DeepEqual(diff.Added, map[string]interface{}{"beta": true}) {
+			t.Errorf("expected the added attribute, got %v", diff.Added)
+		}
+		if !reflect.DeepEqual(diff.Removed, map[string]interface{}{"legacy": true}) {
+			t.Errorf("expected the removed attribute with its previous value, got %v", diff.Removed)
+		}
+		if !reflect.DeepEqual(diff.Changed, map[string]AttributeChange{"plan": {Before: "free", After: "pro"}}) {
+			t.Errorf("expected the changed attribute, got %v", diff.Changed)
+		}
+	})
+
+	t.Run("nested values are compared deeply", func(t *testing.T) {
+		after := before.Clone()
+		after.attributes["user"].(map[string]interface{})["roles"] = []string{"viewer"}
+
+		diff := DiffEvaluationContexts(before, after)
+		change, ok := diff.Changed["user"]
+		if !ok || len(diff.Changed) != 1 {
+			t.Fatalf("expected the nested change to change the attribute as a whole, got %v", diff.Changed)
+		}
+		if roles := change.After.(map[string]interface{})["roles"]; !reflect.DeepEqual(roles, []string{"viewer"}) {
+			t.Errorf("expected the changed nested value, got %v", roles)
+		}
+	})
+}
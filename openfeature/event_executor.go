@@ -24,6 +24,7 @@ type eventExecutor struct {
 	apiRegistry              map[EventType][]EventCallback
 	scopedRegistry           map[string]scopedCallback
 	eventChan                chan eventPayload
+	healthCheckConfig        HealthCheckConfig
 	once                     sync.Once
 	mu                       sync.Mutex
 }
@@ -65,8 +66,8 @@ type eventPayload struct {
 	handler FeatureProvider
 }
 
-// AddHandler adds an API(global) level handler
-func (e *eventExecutor) AddHandler(t EventType, c EventCallback) {
+// AddHandler adds an API(global) level handler, returning a function removing it
+func (e *eventExecutor) AddHandler(t EventType, c EventCallback) func() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
@@ -77,6 +78,7 @@ func (e *eventExecutor) AddHandler(t EventType, c EventCallback) {
 	}
 
 	e.emitOnRegistration(defaultDomain, e.defaultProviderReference, t, c)
+	return unsubscriber(func() { e.RemoveHandler(t, c) })
 }
 
 // RemoveHandler removes an API(global) level handler
@@ -173,6 +175,8 @@ func (e *eventExecutor) emitOnRegistration(domain string, providerReference prov
 		message = "provider is in ready state"
 	} else if state == ErrorState && eventType == ProviderError {
 		message = "provider is in error state"
+	} else if state == FatalState && eventType == ProviderError {
+		message = "provider is in fatal state"
 	} else if state == StaleState && eventType == ProviderStale {
 		message = "provider is in stale state"
 	}
@@ -180,6 +184,7 @@ func (e *eventExecutor) emitOnRegistration(domain string, providerReference prov
 	if message != "" {
 		(*callback)(EventDetails{
 			ProviderName: providerReference.featureProvider.Metadata().Name,
+			Domain:       domain,
 			ProviderEventDetails: ProviderEventDetails{
 				Message: message,
 			},
@@ -232,6 +237,10 @@ func (e *eventExecutor) startListeningAndShutdownOld(newProvider providerReferen
 
 	// check if this provider already actively handled - 1:N binding capability
 	if !isRunning(newProvider, e.activeSubscriptions) {
+		if checker, ok := newProvider.featureProvider.(HealthChecker); ok {
+			newProvider.healthPoller = newHealthPoller(newProvider.featureProvider, checker, e.healthCheckConfig)
+			go newProvider.healthPoller.poll(e.eventChan)
+		}
 		e.activeSubscriptions = append(e.activeSubscriptions, newProvider)
 
 		go func() {
@@ -265,6 +274,7 @@ func (e *eventExecutor) startListeningAndShutdownOld(newProvider providerReferen
 	// drop from active references
 	for i, r := range e.activeSubscriptions {
 		if oldReference.equals(r) {
+			r.healthPoller.halt()
 			e.activeSubscriptions = append(e.activeSubscriptions[:i], e.activeSubscriptions[i+1:]...)
 		}
 	}
@@ -285,6 +295,24 @@ func (e *eventExecutor) startListeningAndShutdownOld(newProvider providerReferen
 	}
 }
 
+// setHealthCheckConfig configures the health polling of providers registered afterwards
+func (e *eventExecutor) setHealthCheckConfig(config HealthCheckConfig) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.healthCheckConfig = config
+}
+
+// stopHealthChecks stops the health polling of all providers
+func (e *eventExecutor) stopHealthChecks() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, r := range e.activeSubscriptions {
+		r.healthPoller.halt()
+	}
+}
+
 // startEventListener trigger the event listening of this executor
 func (e *eventExecutor) startEventListener() {
 	e.once.Do(func() {
@@ -296,14 +324,45 @@ func (e *eventExecutor) startEventListener() {
 	})
 }
 
+// emitProviderEvent queues the event as if the provider emitted it, without waiting for its dispatch
+func (e *eventExecutor) emitProviderEvent(provider FeatureProvider, event Event) {
+	go func() {
+		e.eventChan <- eventPayload{event: event, handler: provider}
+	}()
+}
+
 // triggerEvent performs the actual event handling
 func (e *eventExecutor) triggerEvent(event Event, handler FeatureProvider) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	e.dispatchEvent(event, handler)
+}
+
+// triggerInitEvent stores the state resulting from the initialization of the provider of the domain and dispatches
+// the initialization event at once, so that handlers registered meanwhile either replay the resulting state on
+// registration or receive the event, never both
+func (e *eventExecutor) triggerInitEvent(domain string, state State, event Event, handler FeatureProvider) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.states.Store(domain, state)
+	e.dispatchEvent(event, handler)
+}
+
+// setState stores the state of the provider of the domain, without dispatching any event
+func (e *eventExecutor) setState(domain string, state State) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.states.Store(domain, state)
+}
+
+// dispatchEvent runs the handlers of the event, the executor lock must be held
+func (e *eventExecutor) dispatchEvent(event Event, handler FeatureProvider) {
 	// first run API handlers
 	for _, c := range e.apiRegistry[event.EventType] {
-		e.executeHandler(*c, event)
+		e.executeHandler(*c, defaultDomain, event)
 	}
 
 	// then run client handlers
@@ -312,9 +371,11 @@ func (e *eventExecutor) triggerEvent(event Event, handler FeatureProvider) {
 			continue
 		}
 
-		e.states.Store(domain, stateFromEvent(event))
+		if event.EventType != HookError {
+			e.states.Store(domain, stateFromEvent(event))
+		}
 		for _, c := range e.scopedRegistry[domain].callbacks[event.EventType] {
-			e.executeHandler(*c, event)
+			e.executeHandler(*c, domain, event)
 		}
 	}
 
@@ -322,8 +383,10 @@ func (e *eventExecutor) triggerEvent(event Event, handler FeatureProvider) {
 		return
 	}
 
-	// handling the default provider
-	e.states.Store(defaultDomain, stateFromEvent(event))
+	// handling the default provider, hook errors do not change its state
+	if event.EventType != HookError {
+		e.states.Store(defaultDomain, stateFromEvent(event))
+	}
 	// invoke default provider bound (no provider associated) handlers by filtering
 	for domain, registry := range e.scopedRegistry {
 		if _, ok := e.namedProviderReference[domain]; ok {
@@ -332,14 +395,14 @@ func (e *eventExecutor) triggerEvent(event Event, handler FeatureProvider) {
 		}
 
 		for _, c := range registry.callbacks[event.EventType] {
-			e.executeHandler(*c, event)
+			e.executeHandler(*c, domain, event)
 		}
 	}
 
 }
 
-// executeHandler is a helper which performs the actual invocation of the callback
-func (e *eventExecutor) executeHandler(f func(details EventDetails), event Event) {
+// executeHandler is a helper which performs the actual invocation of the callback registered for the domain
+func (e *eventExecutor) executeHandler(f func(details EventDetails), domain string, event Event) {
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -349,6 +412,7 @@ func (e *eventExecutor) executeHandler(f func(details EventDetails), event Event
 
 		f(EventDetails{
 			ProviderName: event.ProviderName,
+			Domain:       domain,
 			ProviderEventDetails: ProviderEventDetails{
 				Message:       event.Message,
 				FlagChanges:   event.FlagChanges,
@@ -358,6 +422,15 @@ func (e *eventExecutor) executeHandler(f func(details EventDetails), event Event
 	}()
 }
 
+// unsubscriber returns an unsubscribe function of an event handler, removing the handler with remove on the first
+// call only
+func unsubscriber(remove func()) func() {
+	var once sync.Once
+	return func() {
+		once.Do(remove)
+	}
+}
+
 // mapValues is a helper until we bump to a go version with maps.Values and slices.Collect
 func mapValues[K comparable, V any](m map[K]V) []V {
 	var values []V
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -1430,6 +1431,37 @@ func TestEventHandler_Registration(t *testing.T) {
 	})
 }
 
+func TestEventHandler_Unsubscribe(t *testing.T) {
+	executor := newEventExecutor()
+
+	calls := make(chan string, 2)
+	unsubscribed, kept := func(EventDetails) { calls <- "unsubscribed" }, func(EventDetails) { calls <- "kept" }
+	unsubscribe := executor.AddHandler(ProviderConfigChange, &unsubscribed)
+	executor.AddHandler(ProviderConfigChange, &kept)
+
+	unsubscribe()
+	unsubscribe()
+
+	if handlers := executor.apiRegistry[ProviderConfigChange]; len(handlers) != 1 || handlers[0] != EventCallback(&kept) {
+		t.Fatalf("expected only the other handler to remain, got %v", handlers)
+	}
+
+	executor.triggerEvent(Event{EventType: ProviderConfigChange}, NoopProvider{})
+	select {
+	case call := <-calls:
+		if call != "kept" {
+			t.Errorf("expected the unsubscribed handler not to run, got %s", call)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the remaining handler to run")
+	}
+	select {
+	case call := <-calls:
+		t.Errorf("expected a single handler to run, got %s", call)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
 func TestEventHandler_APIRemoval(t *testing.T) {
 	t.Run("API level removal", func(t *testing.T) {
 		executor := newEventExecutor()
@@ -1549,3 +1581,140 @@ func TestEventHandler_APIRemoval(t *testing.T) {
 		executor.RemoveClientHandler("a", ProviderReady, &h1)
 	})
 }
+
+func TestEventHandler_NamedHandlers(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	eventingImpl := &ProviderEventing{c: make(chan Event, 1)}
+	provider := struct {
+		FeatureProvider
+		EventHandler
+	}{
+		NoopProvider{},
+		eventingImpl,
+	}
+	if err := SetNamedProviderAndWait("named", provider); err != nil {
+		t.Fatalf("error setting up provider: %v", err)
+	}
+
+	globalEvents := make(chan EventDetails, 5)
+	namedEvents := make(chan EventDetails, 5)
+	otherEvents := make(chan EventDetails, 5)
+	globalCallback := func(details EventDetails) { globalEvents <- details }
+	namedCallback := func(details EventDetails) { namedEvents <- details }
+	otherCallback := func(details EventDetails) { otherEvents <- details }
+
+	AddHandler(ProviderStale, &globalCallback)
+	AddNamedHandler("named", ProviderStale, &namedCallback)
+	AddNamedHandler("other", ProviderStale, &otherCallback)
+
+	receive := func(events chan EventDetails) (EventDetails, bool) {
+		select {
+		case details := <-events:
+			return details, true
+		case <-time.After(200 * time.Millisecond):
+			return EventDetails{}, false
+		}
+	}
+
+	t.Run("global and named handlers both receive the event of the domain's provider", func(t *testing.T) {
+		eventingImpl.Invoke(Event{ProviderName: "NoopProvider", EventType: ProviderStale})
+
+		global, ok := receive(globalEvents)
+		if !ok {
+			t.Fatal("expected the global handler to receive the event")
+		}
+		if global.Domain != "" {
+			t.Errorf("expected the global handler to receive no domain, got %q", global.Domain)
+		}
+
+		named, ok := receive(namedEvents)
+		if !ok {
+			t.Fatal("expected the named handler to receive the event")
+		}
+		if named.Domain != "named" {
+			t.Errorf("expected the named handler to receive domain %q, got %q", "named", named.Domain)
+		}
+
+		if _, ok := receive(otherEvents); ok {
+			t.Error("expected the handler of another domain not to receive the event")
+		}
+	})
+
+	t.Run("removing a named handler keeps the global handler", func(t *testing.T) {
+		RemoveNamedHandler("named", ProviderStale, &namedCallback)
+		eventingImpl.Invoke(Event{ProviderName: "NoopProvider", EventType: ProviderStale})
+
+		if _, ok := receive(globalEvents); !ok {
+			t.Error("expected the global handler to receive the event")
+		}
+		if _, ok := receive(namedEvents); ok {
+			t.Error("expected the removed named handler not to receive the event")
+		}
+	})
+}
+
+// Handlers registered while the provider initializes receive the ready event exactly once, either on registration
+// or when the initialization completes
+func TestEventHandler_RegisterAfterReadyRace(t *testing.T) {
+	tests := map[string]struct {
+		setProvider func(domain string, provider FeatureProvider) error
+		addHandler  func(domain string, callback EventCallback)
+	}{
+		"API handlers": {
+			setProvider: func(_ string, provider FeatureProvider) error { return SetProvider(provider) },
+			addHandler:  func(_ string, callback EventCallback) { AddHandler(ProviderReady, callback) },
+		},
+		"named handlers": {
+			setProvider: SetNamedProvider,
+			addHandler:  func(domain string, callback EventCallback) { AddNamedHandler(domain, ProviderReady, callback) },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer t.Cleanup(initSingleton)
+
+			provider := struct {
+				FeatureProvider
+				StateHandler
+			}{
+				NoopProvider{},
+				&stateHandlerForTests{
+					initF: func(e EvaluationContext) error {
+						time.Sleep(5 * time.Millisecond)
+						return nil
+					},
+				},
+			}
+			if err := test.setProvider(t.Name(), provider); err != nil {
+				t.Fatalf("error setting up provider: %v", err)
+			}
+
+			const handlers = 100
+			counts := make([]atomic.Int32, handlers)
+			for i := 0; i < handlers; i++ {
+				count := &counts[i]
+				callback := func(EventDetails) { count.Add(1) }
+				test.addHandler(t.Name(), &callback)
+				time.Sleep(100 * time.Microsecond)
+			}
+
+			eventually(t, func() bool {
+				for i := range counts {
+					if counts[i].Load() == 0 {
+						return false
+					}
+				}
+				return true
+			}, time.Second, 10*time.Millisecond, "expected all handlers to receive the ready event")
+
+			time.Sleep(50 * time.Millisecond)
+			for i := range counts {
+				if count := counts[i].Load(); count != 1 {
+					t.Errorf("expected handler %d to be invoked once, got %d", i, count)
+				}
+			}
+		})
+	}
+}
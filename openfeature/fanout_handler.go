@@ -0,0 +1,55 @@
+package openfeature
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// FanoutHandler is an event handler dispatching each event to all of its callbacks, e.g. a logging subscriber and a
+// cache invalidation subscriber, registered once with AddHandler, AddNamedHandler or Client.AddHandler through its
+// Callback. The callbacks run one after the other in the order they were added, a callback panicking does not prevent
+// the others from running.
+type FanoutHandler struct {
+	mu        sync.RWMutex
+	callbacks []func(details EventDetails)
+	callback  EventCallback
+}
+
+// NewFanoutHandler creates a FanoutHandler dispatching the events to the callbacks
+func NewFanoutHandler(callbacks ...func(details EventDetails)) *FanoutHandler {
+	h := &FanoutHandler{callbacks: callbacks}
+	dispatch := h.dispatch
+	h.callback = &dispatch
+	return h
+}
+
+// Add adds callbacks, receiving the events dispatched from then on
+func (h *FanoutHandler) Add(callbacks ...func(details EventDetails)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks = append(h.callbacks, callbacks...)
+}
+
+// Callback returns the callback to register the handler with, the same on every call so that the handler can be
+// removed with it, e.g. with RemoveHandler
+func (h *FanoutHandler) Callback() EventCallback {
+	return h.callback
+}
+
+// dispatch runs every callback with the event details, isolating each callback's panics
+func (h *FanoutHandler) dispatch(details EventDetails) {
+	h.mu.RLock()
+	callbacks := h.callbacks
+	h.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					slog.Info("recovered from a panic in a fanout event callback", "panic", r)
+				}
+			}()
+			callback(details)
+		}()
+	}
+}
@@ -0,0 +1,65 @@
+package openfeature
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFanoutHandler(t *testing.T) {
+	t.Run("events are dispatched to all callbacks in order", func(t *testing.T) {
+		var received []string
+		record := func(name string) func(EventDetails) {
+			return func(details EventDetails) { received = append(received, name+":"+details.Message) }
+		}
+		handler := NewFanoutHandler(record("logging"))
+		handler.Add(record("cache"))
+
+		(*handler.Callback())(EventDetails{ProviderEventDetails: ProviderEventDetails{Message: "changed"}})
+
+		if expected := []string{"logging:changed", "cache:changed"}; !reflect.DeepEqual(received, expected) {
+			t.Errorf("expected the callbacks %v, got %v", expected, received)
+		}
+		if handler.Callback() != handler.Callback() {
+			t.Error("expected the same callback on every call")
+		}
+	})
+
+	t.Run("a panicking callback does not stop the others", func(t *testing.T) {
+		var calls int
+		count := func(EventDetails) { calls++ }
+		handler := NewFanoutHandler(count, func(EventDetails) { panic("callback failed") }, count)
+
+		(*handler.Callback())(EventDetails{})
+
+		if calls != 2 {
+			t.Errorf("expected the callbacks around the panicking one to run, got %d calls", calls)
+		}
+	})
+
+	t.Run("registered once with the SDK", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		if err := SetNamedProviderAndWait(t.Name(), NoopProvider{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		handler := NewFanoutHandler(func(EventDetails) { wg.Done() }, func(EventDetails) { wg.Done() })
+		// the handler is invoked on registration, as the provider is ready already
+		AddNamedHandler(t.Name(), ProviderReady, handler.Callback())
+		defer RemoveNamedHandler(t.Name(), ProviderReady, handler.Callback())
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected the event to reach every callback")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package openfeature
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultHealthCheckInterval is the interval providers are health checked at if HealthCheckConfig.Interval is not set
+const defaultHealthCheckInterval = 30 * time.Second
+
+// HealthCheckConfig configures the polling of providers implementing HealthChecker
+type HealthCheckConfig struct {
+	// Interval is the time between two health checks of a provider. Defaults to 30 seconds.
+	Interval time.Duration
+	// Jitter is the upper bound of a random duration added to every interval, spreading the health checks of providers
+	Jitter time.Duration
+}
+
+// healthPoller polls the health of a provider, emitting a ProviderStale event when the provider becomes unhealthy and a
+// ProviderReady event when it recovers
+type healthPoller struct {
+	checker  HealthChecker
+	provider FeatureProvider
+	config   HealthCheckConfig
+	stop     chan struct{}
+	once     sync.Once
+}
+
+func newHealthPoller(provider FeatureProvider, checker HealthChecker, config HealthCheckConfig) *healthPoller {
+	if config.Interval <= 0 {
+		config.Interval = defaultHealthCheckInterval
+	}
+	return &healthPoller{
+		checker:  checker,
+		provider: provider,
+		config:   config,
+		stop:     make(chan struct{}),
+	}
+}
+
+// poll health checks the provider until the poller is halted, sending the resulting events to the given channel
+func (p *healthPoller) poll(events chan<- eventPayload) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.stop
+		cancel()
+	}()
+
+	healthy := true
+	for {
+		timer := CurrentClock().NewTimer(p.wait())
+		select {
+		case <-p.stop:
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		err := p.checker.HealthCheck(ctx)
+		if (err == nil) == healthy {
+			continue
+		}
+		healthy = err == nil
+
+		event := Event{
+			ProviderName: p.provider.Metadata().Name,
+			EventType:    ProviderReady,
+			ProviderEventDetails: ProviderEventDetails{
+				Message: "Provider health check recovered",
+			},
+		}
+		if !healthy {
+			event.EventType = ProviderStale
+			event.Message = fmt.Sprintf("Provider health check failed, %v", err)
+		}
+
+		select {
+		case events <- eventPayload{event: event, handler: p.provider}:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// wait returns the duration until the next health check
+func (p *healthPoller) wait() time.Duration {
+	if p.config.Jitter <= 0 {
+		return p.config.Interval
+	}
+	return p.config.Interval + time.Duration(rand.Int63n(int64(p.config.Jitter)))
+}
+
+// halt stops the polling, it is safe to call multiple times
+func (p *healthPoller) halt() {
+	if p == nil {
+		return
+	}
+	p.once.Do(func() {
+		close(p.stop)
+	})
+}
@@ -0,0 +1,103 @@
+package openfeature
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flappingProvider fails its health checks as scripted, then stays healthy
+type flappingProvider struct {
+	NoopProvider
+	mu      sync.Mutex
+	results []error
+	checks  atomic.Int32
+}
+
+func (p *flappingProvider) HealthCheck(context.Context) error {
+	p.checks.Add(1)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.results) == 0 {
+		return nil
+	}
+	result := p.results[0]
+	p.results = p.results[1:]
+	return result
+}
+
+func TestHealthCheck(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	SetHealthCheckConfig(HealthCheckConfig{Interval: 5 * time.Millisecond, Jitter: time.Millisecond})
+	unhealthy := errors.New("unhealthy")
+	var stale, ready atomic.Int32
+	staleCallback := func(EventDetails) { stale.Add(1) }
+	readyCallback := func(EventDetails) { ready.Add(1) }
+	AddNamedHandler(t.Name(), ProviderStale, &staleCallback)
+	AddNamedHandler(t.Name(), ProviderReady, &readyCallback)
+
+	provider := &flappingProvider{results: []error{unhealthy, unhealthy, nil, unhealthy, nil}}
+	if err := SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("error setting up provider: %v", err)
+	}
+
+	// the ready handler is invoked on initialization, then on every recovery
+	eventually(t, func() bool {
+		return stale.Load() == 2 && ready.Load() == 3
+	}, time.Second, 5*time.Millisecond, "expected the provider to flap between stale and ready twice")
+
+	if state := ProviderStatus(t.Name()); state != ReadyState {
+		t.Errorf("expected the recovered provider to be %s, got %s", ReadyState, state)
+	}
+
+	Shutdown()
+	// allow a health check in flight to complete
+	time.Sleep(20 * time.Millisecond)
+	checks := provider.checks.Load()
+	time.Sleep(50 * time.Millisecond)
+	if provider.checks.Load() != checks {
+		t.Error("expected the health checks to stop on shutdown")
+	}
+}
+
+func TestHealthCheck_StopsWhenProviderIsReplaced(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	SetHealthCheckConfig(HealthCheckConfig{Interval: 5 * time.Millisecond})
+	provider := &flappingProvider{}
+	if err := SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("error setting up provider: %v", err)
+	}
+	eventually(t, func() bool {
+		return provider.checks.Load() > 0
+	}, time.Second, 5*time.Millisecond, "expected the provider to be health checked")
+
+	if err := SetNamedProviderAndWait(t.Name(), NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	checks := provider.checks.Load()
+	time.Sleep(50 * time.Millisecond)
+	if provider.checks.Load() != checks {
+		t.Error("expected the health checks to stop once the provider is replaced")
+	}
+}
+
+func TestHealthPoller_Wait(t *testing.T) {
+	poller := newHealthPoller(NoopProvider{}, &flappingProvider{}, HealthCheckConfig{})
+	if wait := poller.wait(); wait != defaultHealthCheckInterval {
+		t.Errorf("expected the default interval %s, got %s", defaultHealthCheckInterval, wait)
+	}
+
+	poller = newHealthPoller(NoopProvider{}, &flappingProvider{}, HealthCheckConfig{Interval: time.Second, Jitter: time.Second})
+	for i := 0; i < 100; i++ {
+		if wait := poller.wait(); wait < time.Second || wait >= 2*time.Second {
+			t.Fatalf("expected a wait between 1s and 2s, got %s", wait)
+		}
+	}
+}
@@ -1,10 +1,31 @@
 package openfeature
 
-import "context"
+import (
+	"cmp"
+	"context"
+	"slices"
+)
 
 // Hook allows application developers to add arbitrary behavior to the flag evaluation lifecycle.
 // They operate similarly to middleware in many web frameworks.
 // https://github.com/open-feature/spec/blob/main/specification/hooks.md
+//
+// The After stage runs only once the flag resolved successfully, the Error stage only if the evaluation failed, and
+// the Finally stage always runs last:
+//
+//   - success: Before, After, Finally;
+//   - a Before hook or the provider fails: Before, Error, Finally, the After stage is skipped;
+//   - an After hook fails: Before, After, Error, Finally;
+//   - the provider is not ready, or in a fatal state: Error, Finally.
+//
+// Every stage receives the context.Context given to the evaluation call, so hooks can read request scoped values,
+// honor cancellation and derive child contexts (e.g. with a deadline) for any work they perform.
+//
+// The stages of a hook are never called concurrently within a single flag evaluation: they run one after the other,
+// and the later stages of a hook whose stage was abandoned on timeout (see WithHookTimeout) wait for that stage to
+// complete, for at most the hook timeout, before they are skipped, as reported by the trace of EvaluateWithTrace. A
+// hook instance shared by concurrent evaluations, including the flags of an EvaluateBatch call evaluated in parallel
+// (see WithHookConcurrency), must be safe for concurrent use.
 type Hook interface {
 	Before(ctx context.Context, hookContext HookContext, hookHints HookHints) (*EvaluationContext, error)
 	After(ctx context.Context, hookContext HookContext, flagEvaluationDetails InterfaceEvaluationDetails, hookHints HookHints) error
@@ -12,6 +33,286 @@ type Hook interface {
 	Finally(ctx context.Context, hookContext HookContext, hookHints HookHints)
 }
 
+// HookStage identifies a stage of the flag evaluation life-cycle in which hooks are invoked
+type HookStage int
+
+const (
+	BeforeStage HookStage = iota
+	AfterStage
+	ErrorStage
+	FinallyStage
+)
+
+func (s HookStage) String() string {
+	return hookStageToString[s]
+}
+
+var hookStageToString = map[HookStage]string{
+	BeforeStage:  "before",
+	AfterStage:   "after",
+	ErrorStage:   "error",
+	FinallyStage: "finally",
+}
+
+// HookStages is an optional interface a Hook can implement to declare the stages it participates in.
+// The evaluation skips invoking the hook for any other stage. Hooks that do not implement HookStages are
+// invoked for all stages.
+type HookStages interface {
+	Stages() []HookStage
+}
+
+// hooksForStage returns the hooks participating in the given stage. The given slice is returned as is if no
+// hook opts out of the stage.
+func hooksForStage(hooks []evaluationHook, stage HookStage) []evaluationHook {
+	var filtered []evaluationHook
+	for i, h := range hooks {
+		participates := true
+		if staged, ok := h.hook.(HookStages); ok {
+			participates = slices.Contains(staged.Stages(), stage)
+		}
+
+		switch {
+		case !participates && filtered == nil:
+			filtered = make([]evaluationHook, i, len(hooks))
+			copy(filtered, hooks[:i])
+		case participates && filtered != nil:
+			filtered = append(filtered, h)
+		}
+	}
+
+	if filtered == nil {
+		return hooks
+	}
+	return filtered
+}
+
+// HookPriority is an optional interface a Hook can implement to control its position within each stage.
+//
+// Hooks are stable sorted by priority, higher values first, for the Before stage. The After, Error and Finally
+// stages sort lower values first, so a high priority hook runs first in Before and last in After, Error and
+// Finally. Hooks that do not implement HookPriority have a priority of 0, and hooks of equal priority keep the
+// spec defined order (API, Client, Invocation, Provider for Before and the reverse for the other stages).
+type HookPriority interface {
+	Priority() int
+}
+
+// hookPriority returns the priority of the given hook, defaulting to 0 if it does not implement HookPriority
+func hookPriority(hook Hook) int {
+	if h, ok := hook.(HookPriority); ok {
+		return h.Priority()
+	}
+	return 0
+}
+
+// sortHooksByPriority stable sorts hooks in place by priority, either in descending (Before stage) or ascending
+// (After, Error & Finally stages) order
+func sortHooksByPriority(hooks []evaluationHook, ascending bool) []evaluationHook {
+	slices.SortStableFunc(hooks, func(a, b evaluationHook) int {
+		if ascending {
+			return cmp.Compare(hookPriority(a.hook), hookPriority(b.hook))
+		}
+		return cmp.Compare(hookPriority(b.hook), hookPriority(a.hook))
+	})
+	return hooks
+}
+
+// removeHook returns a copy of the hooks without the given hook, reporting whether the hook was found. The given
+// slice is left untouched, evaluations in flight keep using it. Hooks registered as part of a HookSet are removed too.
+func removeHook(hooks []Hook, hook Hook) ([]Hook, bool) {
+	remaining := slices.DeleteFunc(slices.Clone(hooks), func(h Hook) bool {
+		return sameHook(unwrapHook(h), hook)
+	})
+	return remaining, len(remaining) != len(hooks)
+}
+
+// HookSet bundles related hooks, e.g. logging, metrics and tracing hooks shipped as one observability bundle, to
+// register and remove them as a unit. The hooks run in the order of the set.
+//
+// The Hints of the set are given to its hooks in every evaluation, merged with the hook hints of the evaluation,
+// which take precedence.
+type HookSet struct {
+	Name  string
+	Hooks []Hook
+	Hints HookHints
+}
+
+// hookSetMember is a hook registered as part of a HookSet
+type hookSetMember struct {
+	Hook
+	set   string
+	hints HookHints
+}
+
+// members returns the hooks of the set, each bound to the set
+func (s HookSet) members() []Hook {
+	members := make([]Hook, len(s.Hooks))
+	for i, hook := range s.Hooks {
+		members[i] = &hookSetMember{Hook: hook, set: s.Name, hints: s.Hints}
+	}
+	return members
+}
+
+// removeHookSet returns a copy of the hooks without the hooks of the named set, reporting whether any was found
+func removeHookSet(hooks []Hook, name string) ([]Hook, bool) {
+	remaining := slices.DeleteFunc(slices.Clone(hooks), func(h Hook) bool {
+		member, ok := h.(*hookSetMember)
+		return ok && member.set == name
+	})
+	return remaining, len(remaining) != len(hooks)
+}
+
+// unwrapHook returns the registered hook, unwrapping hooks registered as part of a HookSet
+func unwrapHook(hook Hook) Hook {
+	if member, ok := hook.(*hookSetMember); ok {
+		return member.Hook
+	}
+	return hook
+}
+
+// unwrapHooks returns a copy of the hooks, unwrapping hooks registered as part of a HookSet
+func unwrapHooks(hooks []Hook) []Hook {
+	unwrapped := make([]Hook, len(hooks))
+	for i, hook := range hooks {
+		unwrapped[i] = unwrapHook(hook)
+	}
+	return unwrapped
+}
+
+// sameHook reports whether both hooks are identical, hooks of non-comparable types never being identical
+func sameHook(a, b Hook) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// evaluationHook binds a hook to its HookData for the duration of a single flag evaluation
+type evaluationHook struct {
+	hook Hook
+	data HookData
+	// running is held while a stage of the hook is invoked, so that a stage abandoned on timeout and still running
+	// prevents the later stages of the hook from running concurrently with it
+	running chan struct{}
+	// setHints are the hints of the HookSet the hook is registered with, if any
+	setHints HookHints
+}
+
+// hints returns the hints given to the hook, merging the hints of its HookSet with the evaluation's hints
+func (h evaluationHook) hints(hints HookHints) HookHints {
+	if len(h.setHints.mapOfHints) == 0 {
+		return hints
+	}
+	return h.setHints.Merge(hints)
+}
+
+// acquire waits for the hook to be free to run the stage, reporting whether it is. A stage of the hook abandoned on
+// timeout and still running is waited for, for at most the hook timeout, after which the stage is skipped, and recorded
+// as skipped in the trace of the evaluation.
+func (h evaluationHook) acquire(stage HookStage, options EvaluationOptions) bool {
+	select {
+	case h.running <- struct{}{}:
+		return true
+	default:
+	}
+
+	start := CurrentClock().Now()
+	timer := CurrentClock().NewTimer(options.hookTimeout)
+	defer timer.Stop()
+	select {
+	case h.running <- struct{}{}:
+		return true
+	case <-timer.C():
+		options.recordSkipped(h, stage, start)
+		return false
+	}
+}
+
+// release frees the hook to run its next stage
+func (h evaluationHook) release() {
+	<-h.running
+}
+
+// bindHookData binds each of the given hooks to a new HookData
+func bindHookData(hooks []Hook) []evaluationHook {
+	bound := make([]evaluationHook, len(hooks))
+	for i, hook := range hooks {
+		bound[i] = evaluationHook{hook: hook, data: NewHookData(), running: make(chan struct{}, 1)}
+		if member, ok := hook.(*hookSetMember); ok {
+			bound[i].hook, bound[i].setHints = member.Hook, member.hints
+		}
+	}
+	return bound
+}
+
+// concatHooks joins the given hook slices into a newly allocated slice, leaving the inputs untouched
+func concatHooks(hookSlices ...[]evaluationHook) []evaluationHook {
+	size := 0
+	for _, hooks := range hookSlices {
+		size += len(hooks)
+	}
+
+	joined := make([]evaluationHook, 0, size)
+	for _, hooks := range hookSlices {
+		joined = append(joined, hooks...)
+	}
+	return joined
+}
+
+// FinallyWithDetailsHook is an optional interface a Hook can implement to receive the final evaluation details in
+// the Finally stage. The evaluation invokes FinallyWithDetails instead of Finally for hooks implementing it.
+//
+// The details carry the resolved value, or the default value if the evaluation failed. For failed evaluations the
+// reason is ERROR and the error code and message describe the failure, including failures that occurred before the
+// provider was called (e.g. in a Before hook), in which case the After stage never ran.
+type FinallyWithDetailsHook interface {
+	FinallyWithDetails(ctx context.Context, hookContext HookContext, flagEvaluationDetails InterfaceEvaluationDetails, hookHints HookHints)
+}
+
+// ShortCircuitHook is an optional interface a Hook can implement to supply the final resolution of an evaluation from
+// the Before stage (e.g. a kill-switch). The evaluation invokes BeforeWithShortCircuit instead of Before for hooks
+// implementing it.
+//
+// Returning a non nil ResolutionShortCircuit skips the Before stage of the remaining hooks as well as the provider,
+// and the evaluation proceeds to the After and Finally stages with the supplied resolution. A returned error takes
+// precedence over the short circuit, failing the Before stage as it does for Before.
+type ShortCircuitHook interface {
+	BeforeWithShortCircuit(ctx context.Context, hookContext HookContext, hookHints HookHints) (*EvaluationContext, *ResolutionShortCircuit, error)
+}
+
+// ErrorRecoveryHook is an optional interface a Hook can implement to recover failed evaluations with a fallback value
+// from the Error stage (e.g. a per flag fallback). The evaluation invokes ErrorWithFallback instead of Error for hooks
+// implementing it.
+//
+// Returning true recovers the evaluation: it returns the fallback value with the DEFAULT reason and without error,
+// which the Finally stage receives. The Error stage of the remaining hooks still runs with the original error. The
+// first fallback matching the flag type wins, fallbacks of another type are ignored.
+type ErrorRecoveryHook interface {
+	ErrorWithFallback(ctx context.Context, hookContext HookContext, err error, hookHints HookHints) (fallback interface{}, recovered bool)
+}
+
+// ResolutionShortCircuit is the final resolution of an evaluation supplied by a ShortCircuitHook. A value not matching
+// the flag type fails the evaluation with a TYPE_MISMATCH error.
+type ResolutionShortCircuit struct {
+	Value        interface{}
+	Variant      string
+	Reason       Reason
+	FlagMetadata FlagMetadata
+}
+
+// resolution returns the short circuit as the resolution of the evaluation
+func (s ResolutionShortCircuit) resolution() InterfaceResolutionDetail {
+	return InterfaceResolutionDetail{
+		Value: s.Value,
+		ProviderResolutionDetail: ProviderResolutionDetail{
+			Variant:      s.Variant,
+			Reason:       s.Reason,
+			FlagMetadata: s.FlagMetadata,
+		},
+	}
+}
+
 // HookHints contains a map of hints for hooks
 type HookHints struct {
 	mapOfHints map[string]interface{}
@@ -28,6 +329,80 @@ func (h HookHints) Value(key string) interface{} {
 	return h.mapOfHints[key]
 }
 
+// Values returns a copy of all hints
+func (h HookHints) Values() map[string]interface{} {
+	// copy hints to new map to prevent mutation (maps are passed by reference)
+	values := make(map[string]interface{}, len(h.mapOfHints))
+	for key, value := range h.mapOfHints {
+		values[key] = value
+	}
+	return values
+}
+
+// Merge returns new HookHints containing the hints of both the receiver and other, with values of other taking
+// precedence for keys present in both. Neither of the merged HookHints is modified.
+func (h HookHints) Merge(other HookHints) HookHints {
+	merged := make(map[string]interface{}, len(h.mapOfHints)+len(other.mapOfHints))
+	for key, value := range h.mapOfHints {
+		merged[key] = value
+	}
+	for key, value := range other.mapOfHints {
+		merged[key] = value
+	}
+
+	return HookHints{mapOfHints: merged}
+}
+
+// StringValue returns the string value at the given key.
+// The boolean result is false if the key does not exist or the value is not a string.
+func (h HookHints) StringValue(key string) (string, bool) {
+	v, ok := h.mapOfHints[key].(string)
+	return v, ok
+}
+
+// BoolValue returns the boolean value at the given key.
+// The boolean result is false if the key does not exist or the value is not a boolean.
+func (h HookHints) BoolValue(key string) (bool, bool) {
+	v, ok := h.mapOfHints[key].(bool)
+	return v, ok
+}
+
+// IntValue returns the value at the given key as an int64.
+// Any integer type, as well as floats without a fractional part (e.g. JSON decoded numbers), are converted.
+// The boolean result is false if the key does not exist or the value cannot be converted without loss.
+func (h HookHints) IntValue(key string) (int64, bool) {
+	return toInt64(h.mapOfHints[key])
+}
+
+// FloatValue returns the value at the given key as a float64.
+// Float types, as well as integers that can be represented exactly, are converted.
+// The boolean result is false if the key does not exist or the value cannot be converted without loss.
+func (h HookHints) FloatValue(key string) (float64, bool) {
+	return toFloat64(h.mapOfHints[key])
+}
+
+// HookData is a per hook, per evaluation store allowing a hook to share state between its stages, e.g. to end a
+// span in Finally that was started in Before. Every hook gets its own HookData for each flag evaluation, which is
+// discarded once the evaluation completes.
+type HookData struct {
+	data map[string]interface{}
+}
+
+// NewHookData constructs an empty HookData
+func NewHookData() HookData {
+	return HookData{data: map[string]interface{}{}}
+}
+
+// Set stores the value at the given key, replacing any previously stored value
+func (h HookData) Set(key string, value interface{}) {
+	h.data[key] = value
+}
+
+// Get returns the value stored at the given key, or nil if there is none
+func (h HookData) Get(key string) interface{} {
+	return h.data[key]
+}
+
 // HookContext defines the base level fields of a hook context
 type HookContext struct {
 	flagKey           string
@@ -36,6 +411,8 @@ type HookContext struct {
 	clientMetadata    ClientMetadata
 	providerMetadata  Metadata
 	evaluationContext EvaluationContext
+	flattenedContext  FlattenedContext
+	hookData          HookData
 }
 
 // FlagKey returns the hook context's flag key
@@ -68,6 +445,26 @@ func (h HookContext) EvaluationContext() EvaluationContext {
 	return h.evaluationContext
 }
 
+// FlattenedContext returns the flattened evaluation context the provider resolved the flag with, i.e. the evaluation
+// context merged from all levels and changed by the before hooks, as given to the provider. It is nil in the before
+// stage, and when the provider did not resolve the flag, e.g. on a cache hit or a before hook error.
+func (h HookContext) FlattenedContext() FlattenedContext {
+	if h.flattenedContext == nil {
+		return nil
+	}
+	flattened := make(FlattenedContext, len(h.flattenedContext))
+	for key, value := range h.flattenedContext {
+		flattened[key] = value
+	}
+	return flattened
+}
+
+// HookData returns the data store of the hook this HookContext is given to, shared across the hook's stages of the
+// current evaluation
+func (h HookContext) HookData() HookData {
+	return h.hookData
+}
+
 // NewHookContext constructs HookContext
 // Allows for simplified hook test cases while maintaining immutability
 func NewHookContext(
@@ -85,9 +482,78 @@ func NewHookContext(
 		clientMetadata:    clientMetadata,
 		providerMetadata:  providerMetadata,
 		evaluationContext: evaluationContext,
+		hookData:          NewHookData(),
 	}
 }
 
+// HookContextBuilder incrementally builds a HookContext, which is useful when a hook test case only cares about
+// some of the HookContext fields. Unset fields are left at their zero values.
+//
+// e.g.
+//
+//	hookCtx := NewHookContextBuilder().
+//		WithFlagKey("my-flag").
+//		WithEvaluationContext(NewEvaluationContext("user-123", nil)).
+//		Build()
+type HookContextBuilder struct {
+	hookContext HookContext
+}
+
+// NewHookContextBuilder constructs an empty HookContextBuilder
+func NewHookContextBuilder() *HookContextBuilder {
+	return &HookContextBuilder{}
+}
+
+// WithFlagKey sets the flag key of the built HookContext
+func (b *HookContextBuilder) WithFlagKey(flagKey string) *HookContextBuilder {
+	b.hookContext.flagKey = flagKey
+	return b
+}
+
+// WithFlagType sets the flag type of the built HookContext
+func (b *HookContextBuilder) WithFlagType(flagType Type) *HookContextBuilder {
+	b.hookContext.flagType = flagType
+	return b
+}
+
+// WithDefaultValue sets the default value of the built HookContext
+func (b *HookContextBuilder) WithDefaultValue(defaultValue interface{}) *HookContextBuilder {
+	b.hookContext.defaultValue = defaultValue
+	return b
+}
+
+// WithClientMetadata sets the client metadata of the built HookContext
+func (b *HookContextBuilder) WithClientMetadata(clientMetadata ClientMetadata) *HookContextBuilder {
+	b.hookContext.clientMetadata = clientMetadata
+	return b
+}
+
+// WithProviderMetadata sets the provider metadata of the built HookContext
+func (b *HookContextBuilder) WithProviderMetadata(providerMetadata Metadata) *HookContextBuilder {
+	b.hookContext.providerMetadata = providerMetadata
+	return b
+}
+
+// WithEvaluationContext sets the evaluation context of the built HookContext
+func (b *HookContextBuilder) WithEvaluationContext(evaluationContext EvaluationContext) *HookContextBuilder {
+	b.hookContext.evaluationContext = evaluationContext
+	return b
+}
+
+// WithFlattenedContext sets the flattened context of the built HookContext, as given to the provider
+func (b *HookContextBuilder) WithFlattenedContext(flattenedContext FlattenedContext) *HookContextBuilder {
+	b.hookContext.flattenedContext = flattenedContext
+	return b
+}
+
+// Build returns the HookContext, with a new HookData. Later changes to the builder do not affect previously built
+// HookContexts.
+func (b *HookContextBuilder) Build() HookContext {
+	hookContext := b.hookContext
+	hookContext.hookData = NewHookData()
+	return hookContext
+}
+
 // check at compile time that UnimplementedHook implements the Hook interface
 var _ Hook = UnimplementedHook{}
 
@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	of "github.com/open-feature/go-sdk/openfeature"
+)
+
+// ErrContextTooLarge is matched with errors.Is by the errors of evaluations whose context exceeds the limits of a
+// ContextSizeGuardHook
+var ErrContextTooLarge = errors.New("evaluation context exceeds the size limit")
+
+// ContextSizeGuardHook is a hook failing evaluations whose evaluation context exceeds a maximum number of
+// attributes and/or a maximum serialized size, protecting providers from contexts bloated e.g. by context enrichers.
+// The error of the before stage is routed through the error hooks as usual, and the provider is not called.
+type ContextSizeGuardHook struct {
+	of.UnimplementedHook
+	maxAttributes int
+	maxBytes      int
+}
+
+// ContextSizeGuardHookOption configures a ContextSizeGuardHook
+type ContextSizeGuardHookOption func(*ContextSizeGuardHook)
+
+// WithMaxAttributes limits the number of top level attributes of the evaluation context, the targeting key excluded
+func WithMaxAttributes(maxAttributes int) ContextSizeGuardHookOption {
+	return func(h *ContextSizeGuardHook) {
+		h.maxAttributes = maxAttributes
+	}
+}
+
+// WithMaxBytes limits the size of the JSON serialization of the evaluation context, its targeting key included.
+// Contexts which cannot be serialized exceed the limit.
+func WithMaxBytes(maxBytes int) ContextSizeGuardHookOption {
+	return func(h *ContextSizeGuardHook) {
+		h.maxBytes = maxBytes
+	}
+}
+
+// NewContextSizeGuardHook creates a ContextSizeGuardHook enforcing the limits of the options. Without options, or
+// with limits of zero, the evaluation context is not limited.
+func NewContextSizeGuardHook(opts ...ContextSizeGuardHookOption) *ContextSizeGuardHook {
+	hook := &ContextSizeGuardHook{}
+	for _, opt := range opts {
+		opt(hook)
+	}
+	return hook
+}
+
+func (h *ContextSizeGuardHook) Before(ctx context.Context, hookContext of.HookContext, hookHints of.HookHints) (*of.EvaluationContext, error) {
+	evalCtx := hookContext.EvaluationContext()
+	attributes := evalCtx.Attributes()
+	if h.maxAttributes > 0 && len(attributes) > h.maxAttributes {
+		return nil, fmt.Errorf("%w: %d attributes, the limit is %d", ErrContextTooLarge, len(attributes), h.maxAttributes)
+	}
+
+	if h.maxBytes > 0 {
+		serialized, err := json.Marshal(MarshaledEvaluationContext{
+			TargetingKey: evalCtx.TargetingKey(),
+			Attributes:   attributes,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: cannot determine the size: %w", ErrContextTooLarge, err)
+		}
+		if len(serialized) > h.maxBytes {
+			return nil, fmt.Errorf("%w: %d bytes, the limit is %d", ErrContextTooLarge, len(serialized), h.maxBytes)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,96 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+// countingProvider is an InMemoryProvider counting its boolean resolutions
+type countingProvider struct {
+	memprovider.InMemoryProvider
+	calls *int
+}
+
+func (p countingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	*p.calls++
+	return p.InMemoryProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func TestContextSizeGuardHook(t *testing.T) {
+	provider := countingProvider{
+		InMemoryProvider: memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+			"enabled": {
+				Key:            "enabled",
+				State:          memprovider.Enabled,
+				DefaultVariant: "on",
+				Variants:       map[string]interface{}{"on": true},
+			},
+		}),
+		calls: new(int),
+	}
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := openfeature.NewClient(t.Name())
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		hook    *ContextSizeGuardHook
+		evalCtx openfeature.EvaluationContext
+		exceeds bool
+	}{
+		"under the attribute limit": {
+			hook:    NewContextSizeGuardHook(WithMaxAttributes(2)),
+			evalCtx: openfeature.NewEvaluationContext("user", map[string]interface{}{"a": 1, "b": 2}),
+		},
+		"over the attribute limit": {
+			hook:    NewContextSizeGuardHook(WithMaxAttributes(2)),
+			evalCtx: openfeature.NewEvaluationContext("user", map[string]interface{}{"a": 1, "b": 2, "c": 3}),
+			exceeds: true,
+		},
+		"under the byte limit": {
+			hook:    NewContextSizeGuardHook(WithMaxBytes(100)),
+			evalCtx: openfeature.NewEvaluationContext("user", map[string]interface{}{"plan": "pro"}),
+		},
+		"over the byte limit": {
+			hook:    NewContextSizeGuardHook(WithMaxBytes(100)),
+			evalCtx: openfeature.NewEvaluationContext("user", map[string]interface{}{"blob": strings.Repeat("x", 100)}),
+			exceeds: true,
+		},
+		"unlimited": {
+			hook:    NewContextSizeGuardHook(),
+			evalCtx: openfeature.NewEvaluationContext("user", map[string]interface{}{"blob": strings.Repeat("x", 1000)}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			*provider.calls = 0
+			value, err := client.BooleanValue(ctx, "enabled", false, test.evalCtx, openfeature.WithHooks(test.hook))
+
+			if !test.exceeds {
+				if err != nil || value != true {
+					t.Errorf("expected the resolved value, got %v, %v", value, err)
+				}
+				if *provider.calls != 1 {
+					t.Errorf("expected the provider to be called once, got %d", *provider.calls)
+				}
+				return
+			}
+			if !errors.Is(err, ErrContextTooLarge) {
+				t.Errorf("expected an error matching ErrContextTooLarge, got %v", err)
+			}
+			if value != false {
+				t.Errorf("expected the default value, got %v", value)
+			}
+			if *provider.calls != 0 {
+				t.Errorf("expected the provider not to be called, got %d calls", *provider.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package hooks
+
+import (
+	"context"
+
+	of "github.com/open-feature/go-sdk/openfeature"
+)
+
+// interface guard to ensure that ErrorFallbackHook recovers failed evaluations
+var _ of.ErrorRecoveryHook = (*ErrorFallbackHook)(nil)
+
+// ErrorFallbackHook is a hook recovering the failed evaluations of the configured flags with their fallback value, so
+// that the fallback is declared once rather than at each call site. The recovered evaluations return the fallback with
+// the DEFAULT reason and without error, see openfeature.ErrorRecoveryHook.
+//
+// Evaluations of flags without fallback, and of flags whose fallback is not of the flag's type, keep their error.
+type ErrorFallbackHook struct {
+	of.UnimplementedHook
+	fallbacks map[string]interface{}
+}
+
+// NewErrorFallbackHook creates an ErrorFallbackHook with the given fallback values by flag key. Integer and float
+// fallbacks must be given as int64 and float64 respectively.
+func NewErrorFallbackHook(fallbacks map[string]interface{}) *ErrorFallbackHook {
+	copied := make(map[string]interface{}, len(fallbacks))
+	for flag, fallback := range fallbacks {
+		copied[flag] = fallback
+	}
+	return &ErrorFallbackHook{fallbacks: copied}
+}
+
+func (h *ErrorFallbackHook) ErrorWithFallback(ctx context.Context, hookContext of.HookContext, err error, hookHints of.HookHints) (interface{}, bool) {
+	fallback, ok := h.fallbacks[hookContext.FlagKey()]
+	return fallback, ok
+}
@@ -0,0 +1,64 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+func TestErrorFallbackHook(t *testing.T) {
+	provider := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"enabled": {
+			Key:            "enabled",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants:       map[string]interface{}{"on": true},
+		},
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := openfeature.NewClient(t.Name())
+	client.AddHooks(NewErrorFallbackHook(map[string]interface{}{
+		"missing":    true,
+		"mismatched": "not a boolean",
+		"enabled":    false,
+	}))
+	ctx := context.Background()
+
+	t.Run("configured flags recover with their fallback", func(t *testing.T) {
+		details, err := client.BooleanValueDetails(ctx, "missing", false, openfeature.EvaluationContext{})
+		if err != nil {
+			t.Fatalf("expected the evaluation to recover, got %v", err)
+		}
+		if details.Value != true || details.Reason != openfeature.DefaultReason {
+			t.Errorf("expected the fallback with the DEFAULT reason, got %+v", details)
+		}
+	})
+
+	t.Run("successful evaluations are left untouched", func(t *testing.T) {
+		value, err := client.BooleanValue(ctx, "enabled", false, openfeature.EvaluationContext{})
+		if err != nil || value != true {
+			t.Errorf("expected the resolved value, got %v, %v", value, err)
+		}
+	})
+
+	t.Run("unconfigured flags propagate the original error", func(t *testing.T) {
+		value, err := client.BooleanValue(ctx, "unconfigured", false, openfeature.EvaluationContext{})
+		if !errors.Is(err, openfeature.FlagNotFoundError) {
+			t.Errorf("expected the FLAG_NOT_FOUND error, got %v", err)
+		}
+		if value != false {
+			t.Errorf("expected the default value, got %v", value)
+		}
+	})
+
+	t.Run("fallbacks of another type are ignored", func(t *testing.T) {
+		if _, err := client.BooleanValue(ctx, "mismatched", false, openfeature.EvaluationContext{}); !errors.Is(err, openfeature.FlagNotFoundError) {
+			t.Errorf("expected the FLAG_NOT_FOUND error, got %v", err)
+		}
+	})
+}
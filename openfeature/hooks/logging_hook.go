@@ -11,19 +11,51 @@ const (
 	DOMAIN_KEY             = "domain"
 	PROVIDER_NAME_KEY      = "provider_name"
 	FLAG_KEY_KEY           = "flag_key"
+	FLAG_TYPE_KEY          = "flag_type"
 	DEFAULT_VALUE_KEY      = "default_value"
 	EVALUATION_CONTEXT_KEY = "evaluation_context"
+	HOOK_HINTS_KEY         = "hook_hints"
+	ERROR_CODE_KEY         = "error_code"
 	ERROR_MESSAGE_KEY      = "error_message"
 	REASON_KEY             = "reason"
 	VARIANT_KEY            = "variant"
 	VALUE_KEY              = "value"
+	CORRELATION_ID_KEY     = "correlation_id"
 )
 
 type LoggingHook struct {
 	includeEvaluationContext bool
+	includeHookHints         bool
+	redactedKeys             []string
 	logger                   *slog.Logger
 }
 
+// LoggingHookOption configures a LoggingHook
+type LoggingHookOption func(*LoggingHook)
+
+// WithEvaluationContext configures whether the evaluation context is included in log records.
+// The evaluation context may contain PII and is excluded by default.
+func WithEvaluationContext(include bool) LoggingHookOption {
+	return func(h *LoggingHook) {
+		h.includeEvaluationContext = include
+	}
+}
+
+// WithHookHints configures whether the hook hints are included in log records. Hook hints are excluded by default.
+func WithHookHints(include bool) LoggingHookOption {
+	return func(h *LoggingHook) {
+		h.includeHookHints = include
+	}
+}
+
+// WithRedactedAttributes masks the values of the given sensitive attributes of the evaluation context included in
+// log records, see openfeature.RedactingContextView. Nested attributes of the same keys are masked too.
+func WithRedactedAttributes(keys ...string) LoggingHookOption {
+	return func(h *LoggingHook) {
+		h.redactedKeys = append(h.redactedKeys, keys...)
+	}
+}
+
 func NewLoggingHook(includeEvaluationContext bool) (*LoggingHook, error) {
 	return NewCustomLoggingHook(includeEvaluationContext, slog.Default())
 }
@@ -35,33 +67,56 @@ func NewCustomLoggingHook(includeEvaluationContext bool, logger *slog.Logger) (*
 	}, nil
 }
 
+// NewLoggingHookWithOptions creates a LoggingHook writing to the given logger, or to slog.Default() if the logger is
+// nil. The evaluation context and hook hints are excluded from log records unless enabled through the options.
+// It is not named NewLoggingHook, whose signature is kept for backward compatibility.
+func NewLoggingHookWithOptions(logger *slog.Logger, opts ...LoggingHookOption) *LoggingHook {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	hook := &LoggingHook{logger: logger}
+	for _, opt := range opts {
+		opt(hook)
+	}
+	return hook
+}
+
 type MarshaledEvaluationContext struct {
 	TargetingKey string
 	Attributes   map[string]interface{}
 }
 
-func (l LoggingHook) buildArgs(hookContext of.HookContext) ([]interface{}, error) {
+func (l LoggingHook) buildArgs(ctx context.Context, hookContext of.HookContext, hookHints of.HookHints) ([]interface{}, error) {
 
 	args := []interface{}{
 		DOMAIN_KEY, hookContext.ClientMetadata().Domain(),
 		PROVIDER_NAME_KEY, hookContext.ProviderMetadata().Name,
 		FLAG_KEY_KEY, hookContext.FlagKey(),
+		FLAG_TYPE_KEY, hookContext.FlagType().String(),
 		DEFAULT_VALUE_KEY, hookContext.DefaultValue(),
 	}
+	if correlationID, ok := of.CorrelationID(ctx); ok {
+		args = append(args, CORRELATION_ID_KEY, correlationID)
+	}
 	if l.includeEvaluationContext {
+		evaluationContext := of.RedactingContextView(hookContext.EvaluationContext(), l.redactedKeys)
 		marshaledEvaluationContext := MarshaledEvaluationContext{
-			TargetingKey: hookContext.EvaluationContext().TargetingKey(),
-			Attributes:   hookContext.EvaluationContext().Attributes(),
+			TargetingKey: evaluationContext.TargetingKey(),
+			Attributes:   evaluationContext.Attributes(),
 		}
 		args = append(args, EVALUATION_CONTEXT_KEY, marshaledEvaluationContext)
 	}
+	if l.includeHookHints {
+		args = append(args, HOOK_HINTS_KEY, hookHints.Values())
+	}
 
 	return args, nil
 }
 
 func (h *LoggingHook) Before(ctx context.Context, hookContext of.HookContext,
 	hint of.HookHints) (*of.EvaluationContext, error) {
-	var args, err = h.buildArgs(hookContext)
+	var args, err = h.buildArgs(ctx, hookContext, hint)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +126,7 @@ func (h *LoggingHook) Before(ctx context.Context, hookContext of.HookContext,
 
 func (h *LoggingHook) After(ctx context.Context, hookContext of.HookContext,
 	flagEvaluationDetails of.InterfaceEvaluationDetails, hookHints of.HookHints) error {
-	var args, err = h.buildArgs(hookContext)
+	var args, err = h.buildArgs(ctx, hookContext, hookHints)
 	if err != nil {
 		return err
 	}
@@ -83,7 +138,7 @@ func (h *LoggingHook) After(ctx context.Context, hookContext of.HookContext,
 }
 
 func (h *LoggingHook) Error(ctx context.Context, hookContext of.HookContext, err error, hint of.HookHints) {
-	args, buildArgsErr := h.buildArgs(hookContext)
+	args, buildArgsErr := h.buildArgs(ctx, hookContext, hint)
 	if buildArgsErr != nil {
 		slog.Error("Error building args", "error", buildArgsErr)
 	}
@@ -92,5 +147,24 @@ func (h *LoggingHook) Error(ctx context.Context, hookContext of.HookContext, err
 }
 
 func (h *LoggingHook) Finally(ctx context.Context, hCtx of.HookContext, hint of.HookHints) {
+	args, buildArgsErr := h.buildArgs(ctx, hCtx, hint)
+	if buildArgsErr != nil {
+		slog.Error("Error building args", "error", buildArgsErr)
+	}
+	h.logger.Debug("Finally stage", args...)
+}
 
+// FinallyWithDetails logs the Finally stage along with the outcome of the evaluation
+func (h *LoggingHook) FinallyWithDetails(ctx context.Context, hCtx of.HookContext,
+	flagEvaluationDetails of.InterfaceEvaluationDetails, hint of.HookHints) {
+	args, buildArgsErr := h.buildArgs(ctx, hCtx, hint)
+	if buildArgsErr != nil {
+		slog.Error("Error building args", "error", buildArgsErr)
+	}
+	args = append(args, REASON_KEY, flagEvaluationDetails.Reason)
+	args = append(args, VARIANT_KEY, flagEvaluationDetails.Variant)
+	if flagEvaluationDetails.ErrorCode != "" {
+		args = append(args, ERROR_CODE_KEY, flagEvaluationDetails.ErrorCode)
+	}
+	h.logger.Debug("Finally stage", args...)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"testing"
 
@@ -235,3 +236,140 @@ func compare(expected map[string]map[string]any, ms map[string]map[string]any, t
 		}
 	}
 }
+
+func TestLoggingHookWithOptions(t *testing.T) {
+	hookCtx := openfeature.NewHookContextBuilder().
+		WithFlagKey("boolFlag").
+		WithFlagType(openfeature.Boolean).
+		WithDefaultValue(false).
+		WithClientMetadata(openfeature.NewClientMetadata("test-app")).
+		WithProviderMetadata(openfeature.Metadata{Name: "InMemoryProvider"}).
+		WithEvaluationContext(openfeature.NewEvaluationContext("target1", map[string]interface{}{"email": "user@example.com"})).
+		Build()
+	hookHints := openfeature.NewHookHints(map[string]interface{}{"trace": true})
+	details := openfeature.InterfaceEvaluationDetails{
+		Value: false,
+		EvaluationDetails: openfeature.EvaluationDetails{
+			FlagKey:  "boolFlag",
+			FlagType: openfeature.Boolean,
+			ResolutionDetail: openfeature.ResolutionDetail{
+				Reason:    openfeature.ErrorReason,
+				ErrorCode: openfeature.FlagNotFoundCode,
+			},
+		},
+	}
+
+	t.Run("evaluation context and hints are excluded by default", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		hook := NewLoggingHookWithOptions(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+		_, err := hook.Before(context.Background(), hookCtx, hookHints)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := hook.After(context.Background(), hookCtx, details, hookHints); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		hook.Error(context.Background(), hookCtx, errors.New("flag not found"), hookHints)
+		hook.FinallyWithDetails(context.Background(), hookCtx, details, hookHints)
+
+		ms := prepareOutput(buf, t)
+		for _, msg := range []string{"Before stage", "After stage", "Error stage", "Finally stage"} {
+			record, ok := ms[msg]
+			if !ok {
+				t.Fatalf("expected %s to be logged", msg)
+			}
+			if record[FLAG_TYPE_KEY] != "bool" {
+				t.Errorf("expected %s to log the flag type, got %v", msg, record[FLAG_TYPE_KEY])
+			}
+			if _, ok := record[EVALUATION_CONTEXT_KEY]; ok {
+				t.Errorf("expected %s to exclude the evaluation context", msg)
+			}
+			if _, ok := record[HOOK_HINTS_KEY]; ok {
+				t.Errorf("expected %s to exclude the hook hints", msg)
+			}
+		}
+
+		finally := ms["Finally stage"]
+		if finally[REASON_KEY] != string(openfeature.ErrorReason) || finally[ERROR_CODE_KEY] != string(openfeature.FlagNotFoundCode) {
+			t.Errorf("expected the finally stage to log the evaluation outcome, got %v", finally)
+		}
+		if _, ok := finally[VARIANT_KEY]; !ok {
+			t.Errorf("expected the finally stage to log the variant, got %v", finally)
+		}
+	})
+
+	t.Run("correlation ID is included when present", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		hook := NewLoggingHookWithOptions(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+		ctx := openfeature.WithCorrelationID(context.Background(), "request-42")
+		if _, err := hook.Before(ctx, hookCtx, hookHints); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		hook.Error(context.Background(), hookCtx, errors.New("forced"), hookHints)
+
+		ms := prepareOutput(buf, t)
+		if id := ms["Before stage"][CORRELATION_ID_KEY]; id != "request-42" {
+			t.Errorf("expected the correlation ID to be logged, got %v", id)
+		}
+		if id, ok := ms["Error stage"][CORRELATION_ID_KEY]; ok {
+			t.Errorf("expected no correlation ID without correlation ID in the context, got %v", id)
+		}
+	})
+
+	t.Run("evaluation context and hints are included when enabled", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		hook := NewLoggingHookWithOptions(
+			slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+			WithEvaluationContext(true),
+			WithHookHints(true),
+		)
+
+		_, err := hook.Before(context.Background(), hookCtx, hookHints)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		record := prepareOutput(buf, t)["Before stage"]
+		evaluationContext, ok := record[EVALUATION_CONTEXT_KEY].(map[string]any)
+		if !ok || evaluationContext["TargetingKey"] != "target1" {
+			t.Errorf("expected the evaluation context to be logged, got %v", record[EVALUATION_CONTEXT_KEY])
+		}
+		hints, ok := record[HOOK_HINTS_KEY].(map[string]any)
+		if !ok || hints["trace"] != true {
+			t.Errorf("expected the hook hints to be logged, got %v", record[HOOK_HINTS_KEY])
+		}
+	})
+
+	t.Run("sensitive attributes of the evaluation context are redacted", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		hook := NewLoggingHookWithOptions(
+			slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+			WithEvaluationContext(true),
+			WithRedactedAttributes("email"),
+		)
+
+		_, err := hook.Before(context.Background(), hookCtx, hookHints)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		record := prepareOutput(buf, t)["Before stage"]
+		evaluationContext, ok := record[EVALUATION_CONTEXT_KEY].(map[string]any)
+		if !ok || evaluationContext["TargetingKey"] != "target1" {
+			t.Fatalf("expected the evaluation context to be logged, got %v", record[EVALUATION_CONTEXT_KEY])
+		}
+		attributes, _ := evaluationContext["Attributes"].(map[string]any)
+		if attributes["email"] != openfeature.RedactedValue {
+			t.Errorf("expected the email to be redacted, got %v", attributes["email"])
+		}
+	})
+
+	t.Run("nil logger falls back to the default logger", func(t *testing.T) {
+		hook := NewLoggingHookWithOptions(nil)
+		if hook.logger != slog.Default() {
+			t.Errorf("expected the default logger, got %v", hook.logger)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"context"
+	"time"
+
+	of "github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/telemetry"
+)
+
+const (
+	// EvaluationRequestsTotal counts the flag evaluations
+	EvaluationRequestsTotal = "feature_flag.evaluation_requests_total"
+	// EvaluationErrorsTotal counts the flag evaluations resulting in an error
+	EvaluationErrorsTotal = "feature_flag.evaluation_error_total"
+	// EvaluationReasonsTotal counts the flag evaluations per resolution reason
+	EvaluationReasonsTotal = "feature_flag.evaluation_reason_total"
+	// EvaluationDuration is the histogram of flag evaluation latencies, in seconds
+	EvaluationDuration = "feature_flag.evaluation_duration"
+
+	// startHookDataKey is the HookData key the start time of the current evaluation is stored at
+	startHookDataKey = "start"
+)
+
+// MetricsRecorder records the metrics emitted by a MetricsHook. Implementations may forward the metrics to
+// OpenTelemetry instruments of the same name, or keep them in memory.
+type MetricsRecorder interface {
+	// IncrementCounter adds one to the named counter
+	IncrementCounter(ctx context.Context, name string, attributes map[string]string)
+	// RecordHistogram records the value in the named histogram
+	RecordHistogram(ctx context.Context, name string, value float64, attributes map[string]string)
+}
+
+// MetricsHook is a hook emitting flag evaluation metrics to a MetricsRecorder.
+//
+// Every evaluation increments EvaluationRequestsTotal in the Before stage, evaluation errors increment
+// EvaluationErrorsTotal in the Error stage, and the Finally stage increments EvaluationReasonsTotal for the resolution
+// reason and records the latency since the Before stage in EvaluationDuration. All metrics are keyed by flag key and
+// provider name.
+type MetricsHook struct {
+	of.UnimplementedHook
+	recorder MetricsRecorder
+}
+
+// NewMetricsHook creates a MetricsHook emitting its metrics to the given recorder
+func NewMetricsHook(recorder MetricsRecorder) *MetricsHook {
+	return &MetricsHook{recorder: recorder}
+}
+
+func (h *MetricsHook) Before(ctx context.Context, hookContext of.HookContext, hookHints of.HookHints) (*of.EvaluationContext, error) {
+	hookContext.HookData().Set(startHookDataKey, of.CurrentClock().Now())
+	h.recorder.IncrementCounter(ctx, EvaluationRequestsTotal, metricAttributes(hookContext))
+	return nil, nil
+}
+
+func (h *MetricsHook) Error(ctx context.Context, hookContext of.HookContext, err error, hookHints of.HookHints) {
+	h.recorder.IncrementCounter(ctx, EvaluationErrorsTotal, metricAttributes(hookContext))
+}
+
+func (h *MetricsHook) Finally(ctx context.Context, hookContext of.HookContext, hookHints of.HookHints) {
+	h.FinallyWithDetails(ctx, hookContext, of.InterfaceEvaluationDetails{}, hookHints)
+}
+
+func (h *MetricsHook) FinallyWithDetails(ctx context.Context, hookContext of.HookContext, flagEvaluationDetails of.InterfaceEvaluationDetails, hookHints of.HookHints) {
+	reason := flagEvaluationDetails.Reason
+	if reason == "" {
+		reason = of.UnknownReason
+	}
+	reasonAttributes := metricAttributes(hookContext)
+	reasonAttributes[telemetry.TelemetryReason] = string(reason)
+	h.recorder.IncrementCounter(ctx, EvaluationReasonsTotal, reasonAttributes)
+
+	// the start time is missing if a hook running ahead of the MetricsHook returned an error from its Before stage
+	start, ok := hookContext.HookData().Get(startHookDataKey).(time.Time)
+	if ok {
+		h.recorder.RecordHistogram(ctx, EvaluationDuration, of.CurrentClock().Now().Sub(start).Seconds(), metricAttributes(hookContext))
+	}
+}
+
+func metricAttributes(hookContext of.HookContext) map[string]string {
+	return map[string]string{
+		telemetry.TelemetryKey:      hookContext.FlagKey(),
+		telemetry.TelemetryProvider: hookContext.ProviderMetadata().Name,
+	}
+}
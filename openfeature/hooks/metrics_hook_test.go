@@ -0,0 +1,141 @@
+package hooks
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+	"github.com/open-feature/go-sdk/openfeature/telemetry"
+)
+
+// inMemoryRecorder is a MetricsRecorder keeping the recorded metrics in memory
+type inMemoryRecorder struct {
+	mu         sync.Mutex
+	counters   map[string][]map[string]string
+	histograms map[string][]float64
+}
+
+func newInMemoryRecorder() *inMemoryRecorder {
+	return &inMemoryRecorder{
+		counters:   map[string][]map[string]string{},
+		histograms: map[string][]float64{},
+	}
+}
+
+func (r *inMemoryRecorder) IncrementCounter(_ context.Context, name string, attributes map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[name] = append(r.counters[name], attributes)
+}
+
+func (r *inMemoryRecorder) RecordHistogram(_ context.Context, name string, value float64, _ map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.histograms[name] = append(r.histograms[name], value)
+}
+
+// panickingProvider is an InMemoryProvider panicking on boolean evaluations
+type panickingProvider struct {
+	memprovider.InMemoryProvider
+}
+
+func (p panickingProvider) BooleanEvaluation(context.Context, string, bool, openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	panic("provider failure")
+}
+
+func TestMetricsHook(t *testing.T) {
+	memoryProvider := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"boolFlag": {
+			Key:            "boolFlag",
+			State:          memprovider.Enabled,
+			DefaultVariant: "true",
+			Variants: map[string]interface{}{
+				"true":  true,
+				"false": false,
+			},
+		},
+	})
+	err := openfeature.SetNamedProviderAndWait("metrics-test", memoryProvider)
+	if err != nil {
+		t.Fatalf("error setting provider: %v", err)
+	}
+	err = openfeature.SetNamedProviderAndWait("metrics-panic-test", panickingProvider{memoryProvider})
+	if err != nil {
+		t.Fatalf("error setting provider: %v", err)
+	}
+
+	t.Run("successful evaluation", func(t *testing.T) {
+		recorder := newInMemoryRecorder()
+		client := openfeature.NewClient("metrics-test")
+		_, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(NewMetricsHook(recorder)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		assertCount(t, recorder, EvaluationRequestsTotal, 1)
+		assertCount(t, recorder, EvaluationErrorsTotal, 0)
+		assertCount(t, recorder, EvaluationReasonsTotal, 1)
+		attributes := recorder.counters[EvaluationReasonsTotal][0]
+		if attributes[telemetry.TelemetryKey] != "boolFlag" || attributes[telemetry.TelemetryProvider] != "InMemoryProvider" {
+			t.Errorf("expected flag key and provider name attributes, got %v", attributes)
+		}
+		if attributes[telemetry.TelemetryReason] != string(openfeature.StaticReason) {
+			t.Errorf("expected reason %s, got %s", openfeature.StaticReason, attributes[telemetry.TelemetryReason])
+		}
+		if len(recorder.histograms[EvaluationDuration]) != 1 {
+			t.Errorf("expected a single latency, got %v", recorder.histograms[EvaluationDuration])
+		}
+	})
+
+	t.Run("error evaluation", func(t *testing.T) {
+		recorder := newInMemoryRecorder()
+		client := openfeature.NewClient("metrics-test")
+		_, err := client.BooleanValue(context.Background(), "missing", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(NewMetricsHook(recorder)))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		assertCount(t, recorder, EvaluationRequestsTotal, 1)
+		assertCount(t, recorder, EvaluationErrorsTotal, 1)
+		assertCount(t, recorder, EvaluationReasonsTotal, 1)
+		if reason := recorder.counters[EvaluationReasonsTotal][0][telemetry.TelemetryReason]; reason != string(openfeature.ErrorReason) {
+			t.Errorf("expected reason %s, got %s", openfeature.ErrorReason, reason)
+		}
+		if len(recorder.histograms[EvaluationDuration]) != 1 {
+			t.Errorf("expected a single latency, got %v", recorder.histograms[EvaluationDuration])
+		}
+	})
+
+	t.Run("panicking provider", func(t *testing.T) {
+		recorder := newInMemoryRecorder()
+		client := openfeature.NewClient("metrics-panic-test")
+
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected the provider panic to propagate")
+				}
+			}()
+			_, _ = client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{},
+				openfeature.WithHooks(NewMetricsHook(recorder)), openfeature.WithProviderPanicRecovery(false))
+		}()
+
+		assertCount(t, recorder, EvaluationRequestsTotal, 1)
+		assertCount(t, recorder, EvaluationErrorsTotal, 0)
+		assertCount(t, recorder, EvaluationReasonsTotal, 1)
+		if len(recorder.histograms[EvaluationDuration]) != 1 {
+			t.Errorf("expected the latency to be recorded, got %v", recorder.histograms[EvaluationDuration])
+		}
+	})
+}
+
+func assertCount(t *testing.T, recorder *inMemoryRecorder, name string, expected int) {
+	t.Helper()
+	if got := len(recorder.counters[name]); got != expected {
+		t.Errorf("expected %s to be incremented %d times, got %d", name, expected, got)
+	}
+}
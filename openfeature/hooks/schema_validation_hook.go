@@ -0,0 +1,245 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	of "github.com/open-feature/go-sdk/openfeature"
+)
+
+// ErrSchemaViolation is matched with errors.Is by the errors of evaluations whose resolved value is rejected by the
+// validator of its flag in a SchemaValidationHook
+var ErrSchemaViolation = errors.New("flag value does not match its schema")
+
+// ValueValidator validates the resolved value of a flag, returning an error describing why an invalid value is
+// rejected
+type ValueValidator func(value interface{}) error
+
+// SchemaValidationHook is a hook validating the resolved values of the configured flags in the After stage, catching
+// malformed flag configurations before they reach the application. A rejected value fails the evaluation with an
+// error matching ErrSchemaViolation: the default value is returned with the ERROR reason, and the error hooks run.
+//
+// Evaluations of flags without validator are left untouched.
+type SchemaValidationHook struct {
+	of.UnimplementedHook
+	validators map[string]ValueValidator
+}
+
+// NewSchemaValidationHook creates a SchemaValidationHook with the given validators by flag key, see JSONSchema to
+// validate values against a JSON schema
+func NewSchemaValidationHook(validators map[string]ValueValidator) *SchemaValidationHook {
+	copied := make(map[string]ValueValidator, len(validators))
+	for flag, validator := range validators {
+		copied[flag] = validator
+	}
+	return &SchemaValidationHook{validators: copied}
+}
+
+func (h *SchemaValidationHook) After(ctx context.Context, hookContext of.HookContext, flagEvaluationDetails of.InterfaceEvaluationDetails, hookHints of.HookHints) error {
+	validate, ok := h.validators[hookContext.FlagKey()]
+	if !ok {
+		return nil
+	}
+	if err := validate(flagEvaluationDetails.Value); err != nil {
+		return fmt.Errorf("%w: flag %s: %w", ErrSchemaViolation, hookContext.FlagKey(), err)
+	}
+	return nil
+}
+
+// JSONSchema compiles the JSON schema document into a ValueValidator. Values are validated in their JSON form, so
+// structs are validated by their JSON encoding.
+//
+// The type, properties, required, additionalProperties, items, enum, minimum and maximum keywords are supported,
+// along with the $schema, $id, title, description, default and examples annotations. Schemas using other keywords
+// are rejected rather than partially enforced.
+func JSONSchema(schema []byte) (ValueValidator, error) {
+	compiled, err := decodeSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	return func(value interface{}) error {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("value cannot be encoded to JSON: %w", err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return fmt.Errorf("value cannot be decoded from JSON: %w", err)
+		}
+		return compiled.validate("$", decoded)
+	}, nil
+}
+
+// jsonSchema is the supported subset of JSON schema
+type jsonSchema struct {
+	Type                 schemaTypes            `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *additionalProperties  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+
+	// annotations, which do not constrain values
+	Schema      string        `json:"$schema"`
+	ID          string        `json:"$id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Default     interface{}   `json:"default"`
+	Examples    []interface{} `json:"examples"`
+}
+
+// decodeSchema decodes a schema, rejecting unsupported keywords
+func decodeSchema(data []byte) (*jsonSchema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var schema jsonSchema
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// schemaTypes are the types of a schema, given as a single type or an array of types
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %w", err)
+	}
+	*t = multiple
+	return nil
+}
+
+// additionalProperties either allows or forbids the properties not listed by a schema, or constrains them with a
+// schema
+type additionalProperties struct {
+	allowed bool
+	schema  *jsonSchema
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	schema, err := decodeSchema(data)
+	if err != nil {
+		return fmt.Errorf("additionalProperties must be a boolean or a schema: %w", err)
+	}
+	a.allowed, a.schema = true, schema
+	return nil
+}
+
+// validate validates the JSON decoded value found at the path against the schema
+func (s *jsonSchema) validate(path string, value interface{}) error {
+	if len(s.Type) > 0 && !hasSchemaType(s.Type, value) {
+		return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(s.Type, " or "), jsonType(value))
+	}
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		return fmt.Errorf("%s: %v is not one of the allowed values", path, value)
+	}
+
+	switch value := value.(type) {
+	case float64:
+		if s.Minimum != nil && value < *s.Minimum {
+			return fmt.Errorf("%s: %v is below the minimum of %v", path, value, *s.Minimum)
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			return fmt.Errorf("%s: %v is above the maximum of %v", path, value, *s.Maximum)
+		}
+	case map[string]interface{}:
+		for _, property := range s.Required {
+			if _, ok := value[property]; !ok {
+				return fmt.Errorf("%s: missing required property %s", path, property)
+			}
+		}
+		for property, propertyValue := range value {
+			propertyPath := path + "." + property
+			if propertySchema, ok := s.Properties[property]; ok {
+				if err := propertySchema.validate(propertyPath, propertyValue); err != nil {
+					return err
+				}
+				continue
+			}
+			if s.AdditionalProperties == nil {
+				continue
+			}
+			if !s.AdditionalProperties.allowed {
+				return fmt.Errorf("%s: property is not allowed", propertyPath)
+			}
+			if s.AdditionalProperties.schema != nil {
+				if err := s.AdditionalProperties.schema.validate(propertyPath, propertyValue); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return nil
+		}
+		for i, item := range value {
+			if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasSchemaType reports whether the JSON decoded value is of one of the types, integers being numbers too
+func hasSchemaType(types []string, value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON schema type of the JSON decoded value
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+func TestSchemaValidationHook(t *testing.T) {
+	validator, err := JSONSchema([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["color", "size"],
+		"properties": {
+			"color": {"type": "string", "enum": ["red", "blue"]},
+			"size": {"type": "integer", "minimum": 1, "maximum": 10},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"additionalProperties": false
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	provider := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"valid": {
+			Key:            "valid",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants: map[string]interface{}{
+				"on": map[string]interface{}{"color": "red", "size": 3, "tags": []string{"new"}},
+			},
+		},
+		"invalid": {
+			Key:            "invalid",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants: map[string]interface{}{
+				"on": map[string]interface{}{"color": "green", "size": 3},
+			},
+		},
+		"unchecked": {
+			Key:            "unchecked",
+			State:          memprovider.Enabled,
+			DefaultVariant: "on",
+			Variants:       map[string]interface{}{"on": "anything"},
+		},
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := openfeature.NewClient(t.Name())
+	hook := NewSchemaValidationHook(map[string]ValueValidator{"valid": validator, "invalid": validator})
+	defaultValue := map[string]interface{}{"color": "blue", "size": 1}
+
+	t.Run("valid payload", func(t *testing.T) {
+		details, err := client.ObjectValueDetails(context.Background(), "valid", defaultValue, openfeature.EvaluationContext{},
+			openfeature.WithHooks(hook))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if details.Reason == openfeature.ErrorReason || details.Value.(map[string]interface{})["color"] != "red" {
+			t.Errorf("expected the resolved value, got %v with reason %s", details.Value, details.Reason)
+		}
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		details, err := client.ObjectValueDetails(context.Background(), "invalid", defaultValue, openfeature.EvaluationContext{},
+			openfeature.WithHooks(hook))
+		if !errors.Is(err, ErrSchemaViolation) {
+			t.Fatalf("expected a schema violation, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "$.color") {
+			t.Errorf("expected the error to locate the violation, got %v", err)
+		}
+		if details.Reason != openfeature.ErrorReason || !reflect.DeepEqual(details.Value, defaultValue) {
+			t.Errorf("expected the default value with the error reason, got %v with reason %s", details.Value, details.Reason)
+		}
+	})
+
+	t.Run("flags without validator are left untouched", func(t *testing.T) {
+		value, err := client.StringValue(context.Background(), "unchecked", "default", openfeature.EvaluationContext{},
+			openfeature.WithHooks(hook))
+		if err != nil || value != "anything" {
+			t.Errorf("expected the resolved value, got %q, %v", value, err)
+		}
+	})
+}
+
+func TestJSONSchema(t *testing.T) {
+	tests := map[string]struct {
+		schema string
+		value  interface{}
+		valid  bool
+	}{
+		"matching type":          {schema: `{"type": "string"}`, value: "on", valid: true},
+		"mismatching type":       {schema: `{"type": "string"}`, value: true},
+		"one of several types":   {schema: `{"type": ["string", "null"]}`, value: nil, valid: true},
+		"integers are numbers":   {schema: `{"type": "number"}`, value: 42, valid: true},
+		"fractions not integers": {schema: `{"type": "integer"}`, value: 4.2},
+		"missing property":       {schema: `{"required": ["a"]}`, value: map[string]interface{}{"b": 1}},
+		"additional property":    {schema: `{"properties": {"a": {}}, "additionalProperties": false}`, value: map[string]interface{}{"b": 1}},
+		"additional schema":      {schema: `{"additionalProperties": {"type": "integer"}}`, value: map[string]interface{}{"b": "1"}},
+		"invalid item":           {schema: `{"items": {"type": "integer"}}`, value: []interface{}{1, "2"}},
+		"struct by json encoding": {schema: `{"required": ["name"]}`, value: struct {
+			Name string `json:"name"`
+		}{"x"}, valid: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			validate, err := JSONSchema([]byte(test.schema))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := validate(test.value); (err == nil) != test.valid {
+				t.Errorf("expected valid %v, got %v", test.valid, err)
+			}
+		})
+	}
+
+	t.Run("unsupported keywords are rejected", func(t *testing.T) {
+		if _, err := JSONSchema([]byte(`{"type": "string", "pattern": "^a"}`)); err == nil {
+			t.Error("expected an error for an unsupported keyword")
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package hooks
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	of "github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// flagTypeAttribute is the span attribute holding the type of the evaluated flag
+	flagTypeAttribute = "feature_flag.type"
+	// spanHookDataKey is the HookData key the span of the current evaluation is stored at
+	spanHookDataKey = "span"
+	// correlationIDAttribute is the span attribute holding the correlation ID of the evaluation, see
+	// openfeature.WithCorrelationID
+	correlationIDAttribute = "correlation_id"
+	// contextAttributePrefix prefixes the span attributes holding the attributes of the evaluation context
+	contextAttributePrefix = "feature_flag.context."
+)
+
+// TracingHook is a hook creating an OpenTelemetry span for every flag evaluation.
+//
+// The span is started in the Before stage as a child of any span carried by the evaluation call context, is annotated
+// with the resolved variant and reason in the After stage, records the error in the Error stage and is ended in the
+// Finally stage. The correlation ID of the evaluation call context, if any, is recorded as the "correlation_id"
+// attribute, see openfeature.WithCorrelationID. Hooks cannot replace the context passed to the provider, so the span
+// is not propagated to the provider's resolution.
+type TracingHook struct {
+	of.UnimplementedHook
+	tracer            trace.Tracer
+	contextAttributes bool
+	redactedKeys      []string
+}
+
+// TracingHookOption configures a TracingHook
+type TracingHookOption func(*TracingHook)
+
+// WithContextAttributes records the attributes of the evaluation context on the span, keyed by
+// "feature_flag.context." followed by the attribute, with nested maps flattened into dotted keys. The values of the
+// given sensitive attributes are masked, see openfeature.RedactingContextView. The evaluation context may contain PII
+// and is not recorded by default.
+func WithContextAttributes(redactedKeys ...string) TracingHookOption {
+	return func(h *TracingHook) {
+		h.contextAttributes = true
+		h.redactedKeys = append(h.redactedKeys, redactedKeys...)
+	}
+}
+
+// NewTracingHook creates a TracingHook starting its spans with the given tracer, allowing the caller to control the
+// instrumentation scope
+func NewTracingHook(tracer trace.Tracer) *TracingHook {
+	return &TracingHook{tracer: tracer}
+}
+
+// NewTracingHookWithOptions creates a TracingHook starting its spans with the given tracer, configured with the
+// options
+func NewTracingHookWithOptions(tracer trace.Tracer, opts ...TracingHookOption) *TracingHook {
+	hook := NewTracingHook(tracer)
+	for _, opt := range opts {
+		opt(hook)
+	}
+	return hook
+}
+
+func (h *TracingHook) Before(ctx context.Context, hookContext of.HookContext, hookHints of.HookHints) (*of.EvaluationContext, error) {
+	_, span := h.tracer.Start(ctx, telemetry.FlagEvaluationEventName,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attribute.String(telemetry.TelemetryKey, hookContext.FlagKey()),
+			attribute.String(flagTypeAttribute, hookContext.FlagType().String()),
+			attribute.String(telemetry.TelemetryProvider, hookContext.ProviderMetadata().Name),
+		),
+	)
+	if correlationID, ok := of.CorrelationID(ctx); ok {
+		span.SetAttributes(attribute.String(correlationIDAttribute, correlationID))
+	}
+	if h.contextAttributes {
+		span.SetAttributes(contextAttributes(of.RedactingContextView(hookContext.EvaluationContext(), h.redactedKeys))...)
+	}
+	hookContext.HookData().Set(spanHookDataKey, span)
+	return nil, nil
+}
+
+func (h *TracingHook) After(ctx context.Context, hookContext of.HookContext, flagEvaluationDetails of.InterfaceEvaluationDetails, hookHints of.HookHints) error {
+	span, ok := spanFromHookData(hookContext)
+	if !ok {
+		return nil
+	}
+
+	reason := flagEvaluationDetails.Reason
+	if reason == "" {
+		reason = of.UnknownReason
+	}
+	span.SetAttributes(
+		attribute.String(telemetry.TelemetryVariant, flagEvaluationDetails.Variant),
+		attribute.String(telemetry.TelemetryReason, strings.ToLower(string(reason))),
+	)
+	return nil
+}
+
+func (h *TracingHook) Error(ctx context.Context, hookContext of.HookContext, err error, hookHints of.HookHints) {
+	span, ok := spanFromHookData(hookContext)
+	if !ok {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func (h *TracingHook) Finally(ctx context.Context, hookContext of.HookContext, hookHints of.HookHints) {
+	span, ok := spanFromHookData(hookContext)
+	if !ok {
+		return
+	}
+
+	span.End()
+}
+
+// contextAttributes returns the span attributes of the evaluation context, sorted by key
+func contextAttributes(evalCtx of.EvaluationContext) []attribute.KeyValue {
+	flattened := of.FlattenNested(evalCtx.Attributes())
+	if evalCtx.TargetingKey() != "" {
+		flattened[of.TargetingKey] = evalCtx.TargetingKey()
+	}
+
+	keys := make([]string, 0, len(flattened))
+	for key := range flattened {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]attribute.KeyValue, len(keys))
+	for i, key := range keys {
+		attributes[i] = telemetry.KeyValue(contextAttributePrefix+key, flattened[key])
+	}
+	return attributes
+}
+
+// spanFromHookData returns the span started in the Before stage, if any. The span is missing if a hook running ahead
+// of the TracingHook returned an error from its Before stage.
+func spanFromHookData(hookContext of.HookContext) (trace.Span, bool) {
+	span, ok := hookContext.HookData().Get(spanHookDataKey).(trace.Span)
+	return span, ok
+}
@@ -0,0 +1,265 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+	"github.com/open-feature/go-sdk/openfeature/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// fakeTracer is a trace.Tracer recording the spans it starts
+type fakeTracer struct {
+	noop.Tracer
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &fakeSpan{
+		name:       name,
+		parent:     trace.SpanContextFromContext(ctx),
+		attributes: map[attribute.Key]attribute.Value{},
+	}
+	span.SetAttributes(config.Attributes()...)
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// fakeSpan is a trace.Span recording its attributes, errors, status and whether it was ended
+type fakeSpan struct {
+	noop.Span
+	name        string
+	spanContext trace.SpanContext
+	parent      trace.SpanContext
+	attributes  map[attribute.Key]attribute.Value
+	errors      []error
+	status      codes.Code
+	ended       bool
+}
+
+func (s *fakeSpan) SpanContext() trace.SpanContext {
+	return s.spanContext
+}
+
+func (s *fakeSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attributes[attr.Key] = attr.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *fakeSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *fakeSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+// failingBeforeHook fails the evaluation in its Before stage
+type failingBeforeHook struct {
+	openfeature.UnimplementedHook
+}
+
+func (failingBeforeHook) Before(context.Context, openfeature.HookContext, openfeature.HookHints) (*openfeature.EvaluationContext, error) {
+	return nil, errors.New("forced")
+}
+
+func TestTracingHook(t *testing.T) {
+	memoryProvider := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"boolFlag": {
+			Key:            "boolFlag",
+			State:          memprovider.Enabled,
+			DefaultVariant: "true",
+			Variants: map[string]interface{}{
+				"true":  true,
+				"false": false,
+			},
+		},
+	})
+	err := openfeature.SetNamedProviderAndWait("tracing-test", memoryProvider)
+	if err != nil {
+		t.Fatalf("error setting provider: %v", err)
+	}
+	client := openfeature.NewClient("tracing-test")
+
+	t.Run("span is annotated with the resolution and ended", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		_, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(NewTracingHook(tracer)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		span := singleSpan(t, tracer)
+		if span.name != telemetry.FlagEvaluationEventName {
+			t.Errorf("expected span name %s, got %s", telemetry.FlagEvaluationEventName, span.name)
+		}
+		expected := map[string]string{
+			telemetry.TelemetryKey:      "boolFlag",
+			flagTypeAttribute:           "bool",
+			telemetry.TelemetryProvider: "InMemoryProvider",
+			telemetry.TelemetryVariant:  "true",
+			telemetry.TelemetryReason:   "static",
+		}
+		for key, value := range expected {
+			if got := span.attributes[attribute.Key(key)].AsString(); got != value {
+				t.Errorf("expected attribute %s to be %s, got %s", key, value, got)
+			}
+		}
+		if len(span.errors) != 0 || span.status == codes.Error {
+			t.Errorf("expected no error to be recorded, got %v", span.errors)
+		}
+		if !span.ended {
+			t.Error("expected span to be ended")
+		}
+	})
+
+	t.Run("evaluation error is recorded", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		_, err := client.BooleanValue(context.Background(), "missing", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(NewTracingHook(tracer)))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		span := singleSpan(t, tracer)
+		if len(span.errors) != 1 || span.status != codes.Error {
+			t.Errorf("expected the error to be recorded, got %v with status %v", span.errors, span.status)
+		}
+		if !span.ended {
+			t.Error("expected span to be ended")
+		}
+	})
+
+	t.Run("span is ended when a later before hook fails", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		_, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(NewTracingHook(tracer), failingBeforeHook{}))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		span := singleSpan(t, tracer)
+		if len(span.errors) != 1 || span.status != codes.Error {
+			t.Errorf("expected the error to be recorded, got %v with status %v", span.errors, span.status)
+		}
+		if !span.ended {
+			t.Error("expected span to be ended")
+		}
+	})
+
+	t.Run("no span is started when an earlier before hook fails", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		_, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(failingBeforeHook{}, NewTracingHook(tracer)))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		if len(tracer.spans) != 0 {
+			t.Errorf("expected no span, got %d", len(tracer.spans))
+		}
+	})
+
+	t.Run("correlation ID of the call context is recorded", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		_, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(NewTracingHook(tracer)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := singleSpan(t, tracer).attributes[correlationIDAttribute]; ok {
+			t.Error("expected no correlation ID attribute without correlation ID")
+		}
+
+		tracer = &fakeTracer{}
+		_, err = client.BooleanValue(openfeature.WithCorrelationID(context.Background(), "request-42"), "boolFlag", false,
+			openfeature.EvaluationContext{}, openfeature.WithHooks(NewTracingHook(tracer)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := singleSpan(t, tracer).attributes[correlationIDAttribute].AsString(); got != "request-42" {
+			t.Errorf("expected the correlation ID to be recorded, got %q", got)
+		}
+	})
+
+	t.Run("span is a child of the span in the call context", func(t *testing.T) {
+		parentCtx := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{1},
+		})
+		ctx := trace.ContextWithSpan(context.Background(), &fakeSpan{spanContext: parentCtx})
+
+		tracer := &fakeTracer{}
+		_, err := client.BooleanValue(ctx, "boolFlag", false, openfeature.EvaluationContext{},
+			openfeature.WithHooks(NewTracingHook(tracer)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		span := singleSpan(t, tracer)
+		if !span.parent.Equal(parentCtx) {
+			t.Errorf("expected parent span context %v, got %v", parentCtx, span.parent)
+		}
+	})
+
+	t.Run("evaluation context is recorded with sensitive attributes masked", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		evalCtx := openfeature.NewEvaluationContext("user-1", map[string]interface{}{
+			"plan": "pro",
+			"user": map[string]interface{}{"email": "user@example.com", "age": 42},
+		})
+		_, err := client.BooleanValue(context.Background(), "boolFlag", false, evalCtx,
+			openfeature.WithHooks(NewTracingHookWithOptions(tracer, WithContextAttributes("email"))))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		span := singleSpan(t, tracer)
+		expected := map[string]attribute.Value{
+			"feature_flag.context.targetingKey": attribute.StringValue("user-1"),
+			"feature_flag.context.plan":         attribute.StringValue("pro"),
+			"feature_flag.context.user.email":   attribute.StringValue(openfeature.RedactedValue),
+			"feature_flag.context.user.age":     attribute.IntValue(42),
+		}
+		for key, value := range expected {
+			if got := span.attributes[attribute.Key(key)]; got != value {
+				t.Errorf("expected attribute %s to be %v, got %v", key, value.Emit(), got.Emit())
+			}
+		}
+	})
+
+	t.Run("evaluation context is not recorded by default", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		evalCtx := openfeature.NewEvaluationContext("user-1", map[string]interface{}{"plan": "pro"})
+		_, err := client.BooleanValue(context.Background(), "boolFlag", false, evalCtx,
+			openfeature.WithHooks(NewTracingHook(tracer)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		span := singleSpan(t, tracer)
+		if _, ok := span.attributes["feature_flag.context.plan"]; ok {
+			t.Error("expected the evaluation context not to be recorded")
+		}
+	})
+}
+
+func singleSpan(t *testing.T, tracer *fakeTracer) *fakeSpan {
+	t.Helper()
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected a single span, got %d", len(tracer.spans))
+	}
+	return tracer.spans[0]
+}
@@ -3,7 +3,12 @@ package openfeature
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -196,6 +201,28 @@ func TestRequirement_4_3_2(t *testing.T) {
 	})
 }
 
+// hookContextMatcher matches a HookContext regardless of the HookData bound to it
+type hookContextMatcher struct {
+	expected HookContext
+}
+
+func eqHookContext(expected HookContext) gomock.Matcher {
+	return hookContextMatcher{expected: expected}
+}
+
+func (m hookContextMatcher) Matches(x interface{}) bool {
+	hookCtx, ok := x.(HookContext)
+	if !ok {
+		return false
+	}
+	hookCtx.hookData = m.expected.hookData
+	return reflect.DeepEqual(hookCtx, m.expected)
+}
+
+func (m hookContextMatcher) String() string {
+	return fmt.Sprintf("is equal to %+v (ignoring hook data)", m.expected)
+}
+
 // Any `evaluation context` returned from a `before` hook MUST be passed to subsequent `before` hooks (via `HookContext`).
 func TestRequirement_4_3_3(t *testing.T) {
 	defer t.Cleanup(initSingleton)
@@ -232,7 +259,7 @@ func TestRequirement_4_3_3(t *testing.T) {
 		evaluationContext: evalCtx,
 	}
 	hook1EvalCtxResult := &EvaluationContext{targetingKey: "mockHook1"}
-	mockHook1.EXPECT().Before(gomock.Any(), hook1Ctx, gomock.Any()).Return(hook1EvalCtxResult, nil)
+	mockHook1.EXPECT().Before(gomock.Any(), eqHookContext(hook1Ctx), gomock.Any()).Return(hook1EvalCtxResult, nil)
 	mockProvider.EXPECT().StringEvaluation(gomock.Any(), flagKey, defaultValue, map[string]interface{}{
 		"is":         "a test",
 		TargetingKey: "mockHook1",
@@ -241,7 +268,7 @@ func TestRequirement_4_3_3(t *testing.T) {
 	// assert that the evaluation context returned by the first hook is passed into the second hook
 	hook2Ctx := hook1Ctx
 	hook2Ctx.evaluationContext = *hook1EvalCtxResult
-	mockHook2.EXPECT().Before(gomock.Any(), hook2Ctx, gomock.Any())
+	mockHook2.EXPECT().Before(gomock.Any(), eqHookContext(hook2Ctx), gomock.Any())
 
 	mockHook1.EXPECT().After(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	mockHook1.EXPECT().Finally(gomock.Any(), gomock.Any(), gomock.Any())
@@ -655,7 +682,7 @@ func TestRequirement_4_4_2(t *testing.T) {
 			t.Errorf("error setting up provider %v", err)
 		}
 
-		mockProvider.EXPECT().Hooks().Return([]Hook{mockProviderHook}).Times(2)
+		mockProvider.EXPECT().Hooks().Return([]Hook{mockProviderHook})
 
 		client := GetApiInstance().GetNamedClient(t.Name())
 		client.AddHooks(mockClientHook)
@@ -707,7 +734,7 @@ func TestRequirement_4_4_2(t *testing.T) {
 		client := GetApiInstance().GetNamedClient(t.Name())
 		client.AddHooks(mockClientHook)
 
-		mockProvider.EXPECT().Hooks().Return([]Hook{mockProviderHook}).Times(2)
+		mockProvider.EXPECT().Hooks().Return([]Hook{mockProviderHook})
 
 		mockAPIHook.EXPECT().Before(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("forced"))
 
@@ -961,3 +988,1625 @@ func TestRequirement_4_5_3(t *testing.T) {
 		t.Errorf("expected to retrieve the hint from the underlying map")
 	}
 }
+
+// recordingHook records the stages it is invoked in, in order, for hook ordering tests
+type recordingHook struct {
+	UnimplementedHook
+	name  string
+	calls *[]string
+}
+
+func (h recordingHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	*h.calls = append(*h.calls, "before:"+h.name)
+	return nil, nil
+}
+
+func (h recordingHook) After(context.Context, HookContext, InterfaceEvaluationDetails, HookHints) error {
+	*h.calls = append(*h.calls, "after:"+h.name)
+	return nil
+}
+
+func (h recordingHook) Finally(context.Context, HookContext, HookHints) {
+	*h.calls = append(*h.calls, "finally:"+h.name)
+}
+
+// prioritizedHook is a recordingHook implementing HookPriority
+type prioritizedHook struct {
+	recordingHook
+	priority int
+}
+
+func (h prioritizedHook) Priority() int {
+	return h.priority
+}
+
+func TestHookPriority(t *testing.T) {
+	t.Run("higher priority hooks run first in before and last in after & finally", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		var calls []string
+		AddHooks(recordingHook{name: "api", calls: &calls})
+
+		client := NewClient(t.Name())
+		client.AddHooks(prioritizedHook{recordingHook: recordingHook{name: "logging", calls: &calls}, priority: 10})
+
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(prioritizedHook{recordingHook: recordingHook{name: "auth", calls: &calls}, priority: -5}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{
+			"before:logging", "before:api", "before:auth",
+			"after:auth", "after:api", "after:logging",
+			"finally:auth", "finally:api", "finally:logging",
+		}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("expected hook order %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("hooks of equal priority keep the spec defined order", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		var calls []string
+		AddHooks(prioritizedHook{recordingHook: recordingHook{name: "api", calls: &calls}, priority: 1})
+
+		client := NewClient(t.Name())
+		client.AddHooks(
+			prioritizedHook{recordingHook: recordingHook{name: "client", calls: &calls}, priority: 1},
+			recordingHook{name: "default", calls: &calls},
+		)
+
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(prioritizedHook{recordingHook: recordingHook{name: "invocation", calls: &calls}, priority: 1}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{
+			"before:api", "before:client", "before:invocation", "before:default",
+			"after:default", "after:invocation", "after:client", "after:api",
+			"finally:default", "finally:invocation", "finally:client", "finally:api",
+		}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("expected hook order %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("extreme and equal priorities are ordered", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		var calls []string
+		client := NewClient(t.Name())
+		client.AddHooks(
+			prioritizedHook{recordingHook: recordingHook{name: "min", calls: &calls}, priority: math.MinInt},
+			prioritizedHook{recordingHook: recordingHook{name: "max", calls: &calls}, priority: math.MaxInt},
+			recordingHook{name: "default", calls: &calls},
+			prioritizedHook{recordingHook: recordingHook{name: "max2", calls: &calls}, priority: math.MaxInt},
+		)
+
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{
+			"before:max", "before:max2", "before:default", "before:min",
+			"after:min", "after:default", "after:max", "after:max2",
+			"finally:min", "finally:default", "finally:max", "finally:max2",
+		}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("expected hook order %v, got %v", expected, calls)
+		}
+	})
+
+	t.Run("registered hook slices are not reordered", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		var calls []string
+		low := recordingHook{name: "low", calls: &calls}
+		high := prioritizedHook{recordingHook: recordingHook{name: "high", calls: &calls}, priority: 1}
+
+		client := NewClient(t.Name())
+		client.AddHooks(low, high)
+
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if client.hooks[0] != Hook(low) || client.hooks[1] != Hook(high) {
+			t.Errorf("expected client hooks to keep their registration order")
+		}
+	})
+}
+
+func TestHookHintsTypedAccessors(t *testing.T) {
+	hookHints := NewHookHints(map[string]interface{}{
+		"string":         "value",
+		"bool":           true,
+		"int":            int(12),
+		"int64":          int64(12),
+		"uint8":          uint8(12),
+		"jsonInt":        float64(12),
+		"jsonFraction":   float64(12.5),
+		"float32":        float32(1.5),
+		"largeInt":       int64(1<<53 + 1),
+		"unconvertible":  "12",
+		"unsignedTooBig": uint64(math.MaxUint64),
+	})
+
+	t.Run("string", func(t *testing.T) {
+		if v, ok := hookHints.StringValue("string"); !ok || v != "value" {
+			t.Errorf("expected (value, true), got (%s, %t)", v, ok)
+		}
+		if _, ok := hookHints.StringValue("bool"); ok {
+			t.Error("expected wrong type to report not found")
+		}
+		if _, ok := hookHints.StringValue("missing"); ok {
+			t.Error("expected missing key to report not found")
+		}
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		if v, ok := hookHints.BoolValue("bool"); !ok || !v {
+			t.Errorf("expected (true, true), got (%t, %t)", v, ok)
+		}
+		if _, ok := hookHints.BoolValue("string"); ok {
+			t.Error("expected wrong type to report not found")
+		}
+		if _, ok := hookHints.BoolValue("missing"); ok {
+			t.Error("expected missing key to report not found")
+		}
+	})
+
+	t.Run("int", func(t *testing.T) {
+		for _, key := range []string{"int", "int64", "uint8", "jsonInt"} {
+			if v, ok := hookHints.IntValue(key); !ok || v != 12 {
+				t.Errorf("expected (12, true) for key %s, got (%d, %t)", key, v, ok)
+			}
+		}
+		for _, key := range []string{"jsonFraction", "unconvertible", "unsignedTooBig", "missing"} {
+			if _, ok := hookHints.IntValue(key); ok {
+				t.Errorf("expected key %s to not be convertible to int64", key)
+			}
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		for key, expected := range map[string]float64{"jsonFraction": 12.5, "float32": 1.5, "int": 12, "uint8": 12} {
+			if v, ok := hookHints.FloatValue(key); !ok || v != expected {
+				t.Errorf("expected (%f, true) for key %s, got (%f, %t)", expected, key, v, ok)
+			}
+		}
+		for _, key := range []string{"largeInt", "unconvertible", "missing"} {
+			if _, ok := hookHints.FloatValue(key); ok {
+				t.Errorf("expected key %s to not be convertible to float64", key)
+			}
+		}
+	})
+}
+
+func TestHookContextBuilder(t *testing.T) {
+	t.Run("unset fields default to zero values", func(t *testing.T) {
+		hookCtx := NewHookContextBuilder().WithFlagKey("foo").Build()
+
+		expected := HookContext{flagKey: "foo", hookData: NewHookData()}
+		if !reflect.DeepEqual(hookCtx, expected) {
+			t.Errorf("expected %+v, got %+v", expected, hookCtx)
+		}
+	})
+
+	t.Run("all fields are set", func(t *testing.T) {
+		evalCtx := NewEvaluationContext("user", map[string]interface{}{"foo": "bar"})
+
+		hookCtx := NewHookContextBuilder().
+			WithFlagKey("foo").
+			WithFlagType(String).
+			WithDefaultValue("bar").
+			WithClientMetadata(NewClientMetadata("client")).
+			WithProviderMetadata(Metadata{Name: "provider"}).
+			WithEvaluationContext(evalCtx).
+			Build()
+
+		expected := NewHookContext("foo", String, "bar", NewClientMetadata("client"), Metadata{Name: "provider"}, evalCtx)
+		if !reflect.DeepEqual(hookCtx, expected) {
+			t.Errorf("expected %+v, got %+v", expected, hookCtx)
+		}
+	})
+
+	t.Run("built context is independent of the builder", func(t *testing.T) {
+		builder := NewHookContextBuilder().WithFlagKey("foo")
+		hookCtx := builder.Build()
+		builder.WithFlagKey("bar")
+
+		if hookCtx.FlagKey() != "foo" {
+			t.Errorf("expected built flag key to remain foo, got %s", hookCtx.FlagKey())
+		}
+	})
+}
+
+// stagedHook is a recordingHook implementing HookStages
+type stagedHook struct {
+	recordingHook
+	stages []HookStage
+}
+
+func (h stagedHook) Stages() []HookStage {
+	return h.stages
+}
+
+func TestHookStages(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	var calls []string
+	client := NewClient(t.Name())
+	client.AddHooks(
+		stagedHook{recordingHook: recordingHook{name: "after-only", calls: &calls}, stages: []HookStage{AfterStage}},
+		recordingHook{name: "all", calls: &calls},
+		stagedHook{recordingHook: recordingHook{name: "none", calls: &calls}},
+		stagedHook{recordingHook: recordingHook{name: "before-finally", calls: &calls}, stages: []HookStage{BeforeStage, FinallyStage}},
+	)
+
+	_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"before:all", "before:before-finally",
+		"after:after-only", "after:all",
+		"finally:all", "finally:before-finally",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected hook calls %v, got %v", expected, calls)
+	}
+}
+
+// countingHook counts the stage invocations it receives
+type countingHook struct {
+	UnimplementedHook
+	calls *int
+}
+
+func (h countingHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	*h.calls++
+	return nil, nil
+}
+
+func (h countingHook) After(context.Context, HookContext, InterfaceEvaluationDetails, HookHints) error {
+	*h.calls++
+	return nil
+}
+
+func (h countingHook) Error(context.Context, HookContext, error, HookHints) {
+	*h.calls++
+}
+
+func (h countingHook) Finally(context.Context, HookContext, HookHints) {
+	*h.calls++
+}
+
+// afterOnlyHook is a countingHook participating in the After stage only
+type afterOnlyHook struct {
+	countingHook
+}
+
+var afterOnlyStages = []HookStage{AfterStage}
+
+func (h afterOnlyHook) Stages() []HookStage {
+	return afterOnlyStages
+}
+
+func BenchmarkSingleStageHooks(b *testing.B) {
+	const hookCount = 50
+
+	run := func(b *testing.B, newHook func(calls *int) Hook) {
+		defer initSingleton()
+
+		calls := 0
+		client := NewClient(b.Name())
+		for i := 0; i < hookCount; i++ {
+			client.AddHooks(newHook(&calls))
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = client.BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+		}
+		b.ReportMetric(float64(calls)/float64(b.N), "hook-calls/op")
+	}
+
+	b.Run("all stages", func(b *testing.B) {
+		run(b, func(calls *int) Hook { return countingHook{calls: calls} })
+	})
+
+	b.Run("after stage only", func(b *testing.B) {
+		run(b, func(calls *int) Hook { return afterOnlyHook{countingHook{calls: calls}} })
+	})
+}
+
+func TestHookHintsMerge(t *testing.T) {
+	t.Run("other takes precedence", func(t *testing.T) {
+		base := NewHookHints(map[string]interface{}{"foo": "base", "bar": "base"})
+		override := NewHookHints(map[string]interface{}{"foo": "override", "baz": "override"})
+
+		merged := base.Merge(override)
+
+		expected := map[string]interface{}{"foo": "override", "bar": "base", "baz": "override"}
+		if !reflect.DeepEqual(merged.mapOfHints, expected) {
+			t.Errorf("expected %v, got %v", expected, merged.mapOfHints)
+		}
+		if base.Value("foo") != "base" || base.Value("baz") != nil {
+			t.Errorf("expected receiver to be unchanged, got %v", base.mapOfHints)
+		}
+		if override.Value("bar") != nil {
+			t.Errorf("expected other to be unchanged, got %v", override.mapOfHints)
+		}
+	})
+
+	t.Run("nil hints are treated as empty", func(t *testing.T) {
+		hints := NewHookHints(map[string]interface{}{"foo": "bar"})
+
+		if v := (HookHints{}).Merge(hints).Value("foo"); v != "bar" {
+			t.Errorf("expected bar, got %v", v)
+		}
+		if v := hints.Merge(HookHints{}).Value("foo"); v != "bar" {
+			t.Errorf("expected bar, got %v", v)
+		}
+		if merged := (HookHints{}).Merge(HookHints{}); merged.mapOfHints == nil || len(merged.mapOfHints) != 0 {
+			t.Errorf("expected empty hints, got %v", merged.mapOfHints)
+		}
+	})
+
+	t.Run("merged hints do not alias the inputs", func(t *testing.T) {
+		hints := NewHookHints(map[string]interface{}{"foo": "bar"})
+		merged := hints.Merge(HookHints{})
+		merged.mapOfHints["foo"] = "mutated"
+
+		if hints.Value("foo") != "bar" {
+			t.Errorf("expected receiver to be unchanged, got %v", hints.Value("foo"))
+		}
+	})
+}
+
+type hookCtxKey struct{}
+
+// contextRecordingHook records the value stored under hookCtxKey in the context received by each stage
+type contextRecordingHook struct {
+	values map[string]interface{}
+	err    error
+}
+
+func (h contextRecordingHook) Before(ctx context.Context, _ HookContext, _ HookHints) (*EvaluationContext, error) {
+	h.values["before"] = ctx.Value(hookCtxKey{})
+	return nil, h.err
+}
+
+func (h contextRecordingHook) After(ctx context.Context, _ HookContext, _ InterfaceEvaluationDetails, _ HookHints) error {
+	h.values["after"] = ctx.Value(hookCtxKey{})
+	return nil
+}
+
+func (h contextRecordingHook) Error(ctx context.Context, _ HookContext, _ error, _ HookHints) {
+	h.values["error"] = ctx.Value(hookCtxKey{})
+}
+
+func (h contextRecordingHook) Finally(ctx context.Context, _ HookContext, _ HookHints) {
+	h.values["finally"] = ctx.Value(hookCtxKey{})
+}
+
+func TestHookStagesReceiveEvaluationCallContext(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	ctx := context.WithValue(context.Background(), hookCtxKey{}, "request-scoped")
+	client := NewClient(t.Name())
+
+	t.Run("success path", func(t *testing.T) {
+		hook := contextRecordingHook{values: map[string]interface{}{}}
+
+		_, err := client.BooleanValue(ctx, "foo", false, EvaluationContext{}, WithHooks(hook))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[string]interface{}{"before": "request-scoped", "after": "request-scoped", "finally": "request-scoped"}
+		if !reflect.DeepEqual(hook.values, expected) {
+			t.Errorf("expected %v, got %v", expected, hook.values)
+		}
+	})
+
+	t.Run("error path", func(t *testing.T) {
+		hook := contextRecordingHook{values: map[string]interface{}{}, err: errors.New("forced")}
+
+		_, err := client.BooleanValue(ctx, "foo", false, EvaluationContext{}, WithHooks(hook))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		expected := map[string]interface{}{"before": "request-scoped", "error": "request-scoped", "finally": "request-scoped"}
+		if !reflect.DeepEqual(hook.values, expected) {
+			t.Errorf("expected %v, got %v", expected, hook.values)
+		}
+	})
+}
+
+// slowHook blocks in the configured stage until the given channel is closed, ignoring context cancellation
+type slowHook struct {
+	UnimplementedHook
+	stage   HookStage
+	release chan struct{}
+}
+
+func (h slowHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	if h.stage == BeforeStage {
+		<-h.release
+	}
+	return nil, nil
+}
+
+func (h slowHook) Finally(context.Context, HookContext, HookHints) {
+	if h.stage == FinallyStage {
+		<-h.release
+	}
+}
+
+func (h slowHook) Error(context.Context, HookContext, error, HookHints) {
+	if h.stage == ErrorStage {
+		<-h.release
+	}
+}
+
+// beforeOnlySlowHook is a slowHook taking part in the before stage only
+type beforeOnlySlowHook struct {
+	slowHook
+}
+
+func (h beforeOnlySlowHook) Stages() []HookStage {
+	return []HookStage{BeforeStage}
+}
+
+func TestWithHookTimeout(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+
+	t.Run("a slow before hook does not stall the evaluation", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		values := map[string]interface{}{}
+		recorder := contextRecordingHook{values: values}
+
+		start := time.Now()
+		_, err := client.BooleanValue(context.Background(), "foo", true, EvaluationContext{},
+			WithHooks(recorder, slowHook{stage: BeforeStage, release: release}),
+			WithHookTimeout(10*time.Millisecond))
+		if time.Since(start) > time.Second {
+			t.Fatal("expected the evaluation to be bounded by the hook timeout")
+		}
+
+		if !errors.Is(err, HookTimeoutError) {
+			t.Errorf("expected a HookTimeoutError, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the error to wrap context.DeadlineExceeded, got %v", err)
+		}
+		for _, stage := range []string{"before", "error", "finally"} {
+			if _, ok := values[stage]; !ok {
+				t.Errorf("expected %s stage of the remaining hook to run", stage)
+			}
+		}
+		if _, ok := values["after"]; ok {
+			t.Error("expected after stage to be skipped")
+		}
+	})
+
+	t.Run("a hook abandoned as the evaluation is cancelled reports the cancellation", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		cause := errors.New("request aborted")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+		_, err := client.BooleanValue(ctx, "foo", true, EvaluationContext{},
+			WithHooks(beforeOnlySlowHook{slowHook{stage: BeforeStage, release: release}}), WithHookTimeout(time.Minute))
+		if errors.Is(err, HookTimeoutError) {
+			t.Errorf("expected no HookTimeoutError before the hook timeout elapsed, got %v", err)
+		}
+		if !errors.Is(err, cause) {
+			t.Errorf("expected the error to wrap the cause of the cancellation, got %v", err)
+		}
+	})
+
+	t.Run("a slow finally hook does not stop the remaining finally hooks", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		values := map[string]interface{}{}
+
+		// finally hooks run in reverse order, the slow hook runs before the recorder
+		_, err := client.BooleanValue(context.Background(), "foo", true, EvaluationContext{},
+			WithHooks(contextRecordingHook{values: values}, slowHook{stage: FinallyStage, release: release}),
+			WithHookTimeout(10*time.Millisecond))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, ok := values["finally"]; !ok {
+			t.Error("expected finally stage of the remaining hook to run")
+		}
+	})
+
+	t.Run("hooks completing within the timeout are unaffected", func(t *testing.T) {
+		values := map[string]interface{}{}
+
+		value, err := client.BooleanValue(context.Background(), "foo", true, EvaluationContext{},
+			WithHooks(contextRecordingHook{values: values}), WithHookTimeout(time.Second))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !value {
+			t.Error("expected the provider value to be returned")
+		}
+		if len(values) != 3 {
+			t.Errorf("expected before, after & finally stages to run, got %v", values)
+		}
+	})
+}
+
+// concurrencyHook records the number of before stages running at once
+type concurrencyHook struct {
+	UnimplementedHook
+	mu       *sync.Mutex
+	calls    *int
+	inFlight *int
+	peak     *int
+}
+
+func (h concurrencyHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	h.mu.Lock()
+	*h.calls++
+	*h.inFlight++
+	*h.peak = max(*h.peak, *h.inFlight)
+	h.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	h.mu.Lock()
+	*h.inFlight--
+	h.mu.Unlock()
+	return nil, nil
+}
+
+// abandonedHook blocks in the before stage until released, recording whether its later stages are called
+type abandonedHook struct {
+	UnimplementedHook
+	release chan struct{}
+	later   *atomic.Bool
+}
+
+func (h abandonedHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	<-h.release
+	return nil, nil
+}
+
+func (h abandonedHook) Error(context.Context, HookContext, error, HookHints) {
+	h.later.Store(true)
+}
+
+func (h abandonedHook) Finally(context.Context, HookContext, HookHints) {
+	h.later.Store(true)
+}
+
+// lingeringHook overruns its before stage, returning once the context of the stage is cancelled, counting the calls
+// of its later stages
+type lingeringHook struct {
+	UnimplementedHook
+	later *atomic.Int32
+}
+
+func (h lingeringHook) Before(ctx context.Context, _ HookContext, _ HookHints) (*EvaluationContext, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (h lingeringHook) Error(context.Context, HookContext, error, HookHints) {
+	h.later.Add(1)
+}
+
+func (h lingeringHook) Finally(context.Context, HookContext, HookHints) {
+	h.later.Add(1)
+}
+
+func TestHookConcurrency(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+
+	t.Run("bounds the flags of a batch evaluated in parallel", func(t *testing.T) {
+		var calls, inFlight, peak int
+		hook := concurrencyHook{mu: &sync.Mutex{}, calls: &calls, inFlight: &inFlight, peak: &peak}
+		requests := make([]FlagRequest, 8)
+		for i := range requests {
+			requests[i] = FlagRequest{Key: fmt.Sprintf("flag-%d", i), Type: Boolean, DefaultValue: true}
+		}
+
+		results, err := client.EvaluateBatch(context.Background(), requests, EvaluationContext{},
+			WithHooks(hook), WithHookConcurrency(3))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, result := range results {
+			if result.FlagKey != requests[i].Key {
+				t.Errorf("expected the results in the order of the requests, got %s at %d", result.FlagKey, i)
+			}
+		}
+		if calls != len(requests) {
+			t.Errorf("expected the hook to run for each of the %d flags, got %d", len(requests), calls)
+		}
+		if peak > 3 {
+			t.Errorf("expected at most 3 flags evaluated in parallel, got %d", peak)
+		}
+		if peak < 2 {
+			t.Errorf("expected flags to be evaluated in parallel, got %d at once", peak)
+		}
+	})
+
+	t.Run("evaluates the flags of a batch sequentially by default", func(t *testing.T) {
+		var calls, inFlight, peak int
+		hook := concurrencyHook{mu: &sync.Mutex{}, calls: &calls, inFlight: &inFlight, peak: &peak}
+		requests := []FlagRequest{
+			{Key: "foo", Type: Boolean, DefaultValue: true},
+			{Key: "bar", Type: Boolean, DefaultValue: true},
+		}
+
+		_, err := client.EvaluateBatch(context.Background(), requests, EvaluationContext{}, WithHooks(hook))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 2 || peak != 1 {
+			t.Errorf("expected the flags to be evaluated one at a time, got %d at once", peak)
+		}
+	})
+
+	t.Run("the later stages of a hook abandoned on timeout wait for it", func(t *testing.T) {
+		var later atomic.Int32
+
+		_, err := client.BooleanValue(context.Background(), "foo", true, EvaluationContext{},
+			WithHooks(lingeringHook{later: &later}), WithHookTimeout(50*time.Millisecond))
+		if !errors.Is(err, HookTimeoutError) {
+			t.Errorf("expected a HookTimeoutError, got %v", err)
+		}
+		if later.Load() != 2 {
+			t.Errorf("expected the error & finally stages to run once the abandoned stage returned, got %d", later.Load())
+		}
+	})
+
+	t.Run("a hook still running an abandoned stage is skipped in the later stages", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		var later atomic.Bool
+
+		_, trace, err := client.EvaluateWithTrace(context.Background(), FlagRequest{Key: "foo", Type: Boolean, DefaultValue: true},
+			EvaluationContext{}, WithHooks(abandonedHook{release: release, later: &later}), WithHookTimeout(10*time.Millisecond))
+		if !errors.Is(err, HookTimeoutError) {
+			t.Errorf("expected a HookTimeoutError, got %v", err)
+		}
+		if later.Load() {
+			t.Error("expected the error & finally stages of the abandoned hook to be skipped")
+		}
+
+		// the skipped stages are traced
+		if len(trace) != 3 {
+			t.Fatalf("expected the trace of the before, error & finally stages, got %+v", trace)
+		}
+		if !errors.Is(trace[0].Err, HookTimeoutError) || trace[0].Skipped {
+			t.Errorf("expected the before stage to be traced with its timeout, got %+v", trace[0])
+		}
+		for i, stage := range []HookStage{ErrorStage, FinallyStage} {
+			if entry := trace[i+1]; entry.Stage != stage || !entry.Skipped || entry.Err != nil {
+				t.Errorf("expected the %s stage to be traced as skipped, got %+v", stage, entry)
+			}
+		}
+	})
+}
+
+// finallyDetailsHook records the evaluation details received by FinallyWithDetails
+type finallyDetailsHook struct {
+	UnimplementedHook
+	beforeErr error
+	details   *InterfaceEvaluationDetails
+	finally   *bool
+}
+
+func (h finallyDetailsHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	return nil, h.beforeErr
+}
+
+func (h finallyDetailsHook) Finally(context.Context, HookContext, HookHints) {
+	*h.finally = true
+}
+
+func (h finallyDetailsHook) FinallyWithDetails(_ context.Context, _ HookContext, details InterfaceEvaluationDetails, _ HookHints) {
+	*h.details = details
+}
+
+func TestFinallyWithDetailsHook(t *testing.T) {
+	t.Run("receives the resolved details", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(BoolResolutionDetail{
+				Value:                    true,
+				ProviderResolutionDetail: ProviderResolutionDetail{Variant: "on", Reason: TargetingMatchReason},
+			})
+
+		var details InterfaceEvaluationDetails
+		var finally bool
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(finallyDetailsHook{details: &details, finally: &finally}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if details.Value != true || details.Variant != "on" || details.Reason != TargetingMatchReason {
+			t.Errorf("expected resolved details, got %+v", details)
+		}
+		if finally {
+			t.Error("expected Finally to not be invoked for hooks implementing FinallyWithDetails")
+		}
+	})
+
+	t.Run("receives the provider error", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(BoolResolutionDetail{
+				Value:                    true,
+				ProviderResolutionDetail: ProviderResolutionDetail{ResolutionError: NewFlagNotFoundResolutionError("not found")},
+			})
+
+		var details InterfaceEvaluationDetails
+		var finally bool
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(finallyDetailsHook{details: &details, finally: &finally}))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		if details.Value != false || details.Reason != ErrorReason || details.ErrorCode != FlagNotFoundCode {
+			t.Errorf("expected default value with FLAG_NOT_FOUND error, got %+v", details)
+		}
+	})
+
+	t.Run("receives the before hook error when after never ran", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		var details InterfaceEvaluationDetails
+		var finally bool
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(finallyDetailsHook{beforeErr: errors.New("forced"), details: &details, finally: &finally}))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		if details.Value != false || details.Reason != ErrorReason || details.ErrorCode != GeneralCode {
+			t.Errorf("expected default value with GENERAL error, got %+v", details)
+		}
+		if details.ErrorMessage != err.Error() {
+			t.Errorf("expected error message %q, got %q", err.Error(), details.ErrorMessage)
+		}
+	})
+}
+
+// shortCircuitHook supplies the final resolution of the evaluation from BeforeWithShortCircuit
+type shortCircuitHook struct {
+	UnimplementedHook
+	shortCircuit *ResolutionShortCircuit
+}
+
+func (h shortCircuitHook) BeforeWithShortCircuit(context.Context, HookContext, HookHints) (*EvaluationContext, *ResolutionShortCircuit, error) {
+	return nil, h.shortCircuit, nil
+}
+
+func TestShortCircuitHook(t *testing.T) {
+	t.Run("skips the provider and the remaining before hooks", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		// the provider mock fails the test if the flag is resolved
+
+		skipped := NewMockHook(gomock.NewController(t))
+		skipped.EXPECT().After(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, _ HookContext, details InterfaceEvaluationDetails, _ HookHints) {
+				if details.Value != true {
+					t.Errorf("expected after hook to receive the short circuit value, got %v", details.Value)
+				}
+			})
+		skipped.EXPECT().Finally(gomock.Any(), gomock.Any(), gomock.Any())
+
+		var details InterfaceEvaluationDetails
+		var finally bool
+		evalDetails, err := client.BooleanValueDetails(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(
+				finallyDetailsHook{details: &details, finally: &finally},
+				shortCircuitHook{shortCircuit: &ResolutionShortCircuit{Value: true, Variant: "kill-switch", Reason: TargetingMatchReason}},
+				skipped,
+			))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if evalDetails.Value != true || evalDetails.Variant != "kill-switch" || evalDetails.Reason != TargetingMatchReason {
+			t.Errorf("expected the short circuit resolution, got %+v", evalDetails)
+		}
+		if details.Value != true || details.Reason != TargetingMatchReason {
+			t.Errorf("expected finally hook to receive the short circuit resolution, got %+v", details)
+		}
+	})
+
+	t.Run("resolves the flag without a short circuit", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(BoolResolutionDetail{Value: true})
+
+		value, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(shortCircuitHook{}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value != true {
+			t.Errorf("expected the provider's value, got %v", value)
+		}
+	})
+
+	t.Run("skips the provider in batch evaluations", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		results, err := client.EvaluateBatch(context.Background(), []FlagRequest{{Key: "foo", Type: Boolean, DefaultValue: false}},
+			EvaluationContext{}, WithHooks(shortCircuitHook{shortCircuit: &ResolutionShortCircuit{Value: true, Reason: CachedReason}}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if results[0].Value != true || results[0].Reason != CachedReason {
+			t.Errorf("expected the short circuit resolution, got %+v", results[0])
+		}
+	})
+
+	t.Run("fails on a value not matching the flag type", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		evalDetails, err := client.BooleanValueDetails(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(shortCircuitHook{shortCircuit: &ResolutionShortCircuit{Value: "on"}}))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if evalDetails.Value != false || evalDetails.ErrorCode != TypeMismatchCode {
+			t.Errorf("expected default value with TYPE_MISMATCH error, got %+v", evalDetails)
+		}
+	})
+}
+
+// hookDataHook stores a value in its HookData in Before and records what each later stage reads back
+type hookDataHook struct {
+	UnimplementedHook
+	value string
+	seen  map[HookStage]interface{}
+}
+
+func (h hookDataHook) Before(_ context.Context, hookContext HookContext, _ HookHints) (*EvaluationContext, error) {
+	h.seen[BeforeStage] = hookContext.HookData().Get("value")
+	hookContext.HookData().Set("value", h.value)
+	return nil, nil
+}
+
+func (h hookDataHook) After(_ context.Context, hookContext HookContext, _ InterfaceEvaluationDetails, _ HookHints) error {
+	h.seen[AfterStage] = hookContext.HookData().Get("value")
+	return nil
+}
+
+func (h hookDataHook) Finally(_ context.Context, hookContext HookContext, _ HookHints) {
+	h.seen[FinallyStage] = hookContext.HookData().Get("value")
+}
+
+func TestHookData(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 2)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	first := hookDataHook{value: "first", seen: map[HookStage]interface{}{}}
+	second := hookDataHook{value: "second", seen: map[HookStage]interface{}{}}
+	client.AddHooks(first)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{}, WithHooks(second))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, h := range []hookDataHook{first, second} {
+			if h.seen[BeforeStage] != nil {
+				t.Errorf("expected %s hook data to be empty in before stage, got %v", h.value, h.seen[BeforeStage])
+			}
+			for _, stage := range []HookStage{AfterStage, FinallyStage} {
+				if h.seen[stage] != h.value {
+					t.Errorf("expected %s hook to read %q in %s stage, got %v", h.value, h.value, stage, h.seen[stage])
+				}
+			}
+		}
+	}
+}
+
+// hookStageError is a custom error type returned by failingHook
+type hookStageError struct {
+	stage HookStage
+}
+
+func (e hookStageError) Error() string {
+	return fmt.Sprintf("%s stage failed", e.stage)
+}
+
+// failingHook fails the given stage with a hookStageError, joined with any additional errors
+type failingHook struct {
+	UnimplementedHook
+	stage  HookStage
+	joined []error
+}
+
+func (h failingHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	if h.stage == BeforeStage {
+		return nil, errors.Join(append([]error{hookStageError{stage: BeforeStage}}, h.joined...)...)
+	}
+	return nil, nil
+}
+
+func (h failingHook) After(context.Context, HookContext, InterfaceEvaluationDetails, HookHints) error {
+	if h.stage == AfterStage {
+		return errors.Join(append([]error{hookStageError{stage: AfterStage}}, h.joined...)...)
+	}
+	return nil
+}
+
+func TestHookErrorsAreJoined(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+
+	for _, stage := range []HookStage{BeforeStage, AfterStage} {
+		t.Run(fmt.Sprintf("%s hook error and error hook timeouts are surfaced", stage), func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+			customErr := errors.New("custom")
+
+			_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+				WithHooks(failingHook{stage: stage, joined: []error{customErr}}, slowHook{stage: ErrorStage, release: release}),
+				WithHookTimeout(10*time.Millisecond))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			var resolutionErr ResolutionError
+			if !errors.As(err, &resolutionErr) || resolutionErr.code != GeneralCode {
+				t.Errorf("expected a GENERAL resolution error, got %v", err)
+			}
+			var stageErr hookStageError
+			if !errors.As(err, &stageErr) || stageErr.stage != stage {
+				t.Errorf("expected the %s hook error, got %v", stage, err)
+			}
+			if !errors.Is(err, customErr) {
+				t.Errorf("expected the joined hook error, got %v", err)
+			}
+			if !errors.Is(err, HookTimeoutError) {
+				t.Errorf("expected the error hook timeout, got %v", err)
+			}
+		})
+
+		t.Run(fmt.Sprintf("error hooks receive the %s hook error returned to the caller", stage), func(t *testing.T) {
+			var hookErr error
+			_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+				WithHooks(failingHook{stage: stage}, errorRecordingHook{err: &hookErr}))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !reflect.DeepEqual(hookErr, err) {
+				t.Errorf("expected the error hooks to receive the returned error %v, got %v", err, hookErr)
+			}
+		})
+	}
+}
+
+// panickingHook panics in the given stage
+type panickingHook struct {
+	UnimplementedHook
+	stage HookStage
+}
+
+func (h panickingHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	if h.stage == BeforeStage {
+		panic("before")
+	}
+	return nil, nil
+}
+
+func (h panickingHook) After(context.Context, HookContext, InterfaceEvaluationDetails, HookHints) error {
+	if h.stage == AfterStage {
+		panic("after")
+	}
+	return nil
+}
+
+func (h panickingHook) Error(context.Context, HookContext, error, HookHints) {
+	if h.stage == ErrorStage {
+		panic("error")
+	}
+}
+
+func (h panickingHook) Finally(context.Context, HookContext, HookHints) {
+	if h.stage == FinallyStage {
+		panic("finally")
+	}
+}
+
+func TestWithHookPanicRecovery(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+
+	for _, stage := range []HookStage{BeforeStage, AfterStage} {
+		t.Run(fmt.Sprintf("a panic in the %s stage is routed through the error stage", stage), func(t *testing.T) {
+			var details InterfaceEvaluationDetails
+			var finally bool
+			_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+				WithHooks(panickingHook{stage: stage}, finallyDetailsHook{details: &details, finally: &finally}))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			var panicErr *HookPanicError
+			if !errors.As(err, &panicErr) {
+				t.Fatalf("expected a HookPanicError, got %v", err)
+			}
+			if panicErr.Stage != stage || panicErr.Value != stage.String() || len(panicErr.Stack) == 0 {
+				t.Errorf("expected the panic of the %s stage with its stack, got %+v", stage, panicErr)
+			}
+			if details.Reason != ErrorReason {
+				t.Errorf("expected finally hooks to receive the error, got %+v", details)
+			}
+		})
+	}
+
+	t.Run("a panic in the error stage is surfaced", func(t *testing.T) {
+		var details InterfaceEvaluationDetails
+		var finally bool
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(failingHook{stage: BeforeStage}, panickingHook{stage: ErrorStage}, finallyDetailsHook{details: &details, finally: &finally}))
+
+		var panicErr *HookPanicError
+		if !errors.As(err, &panicErr) || panicErr.Stage != ErrorStage {
+			t.Errorf("expected the error stage panic to be surfaced, got %v", err)
+		}
+		if details.Reason != ErrorReason {
+			t.Errorf("expected finally hooks to receive the error, got %+v", details)
+		}
+	})
+
+	t.Run("a panic in the finally stage does not fail the evaluation", func(t *testing.T) {
+		value, err := client.BooleanValue(context.Background(), "foo", true, EvaluationContext{},
+			WithHooks(panickingHook{stage: FinallyStage}))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if value != true {
+			t.Errorf("expected the default value, got %t", value)
+		}
+	})
+
+	t.Run("a panic is recovered when the stage has a timeout", func(t *testing.T) {
+		_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(panickingHook{stage: BeforeStage}), WithHookTimeout(time.Second))
+
+		var panicErr *HookPanicError
+		if !errors.As(err, &panicErr) {
+			t.Errorf("expected a HookPanicError, got %v", err)
+		}
+	})
+
+	t.Run("panics propagate when recovery is disabled", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "before" {
+				t.Errorf("expected the hook panic to propagate, got %v", r)
+			}
+		}()
+		_, _ = client.BooleanValue(context.Background(), "foo", false, EvaluationContext{},
+			WithHooks(panickingHook{stage: BeforeStage}), WithHookPanicRecovery(false))
+	})
+}
+
+// hintsRecordingHook records the hook hints received by the before stage
+type hintsRecordingHook struct {
+	UnimplementedHook
+	hints *HookHints
+}
+
+func (h hintsRecordingHook) Before(_ context.Context, _ HookContext, hints HookHints) (*EvaluationContext, error) {
+	*h.hints = hints
+	return nil, nil
+}
+
+func TestAddFlagHints(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+	var hints HookHints
+	client.AddHooks(hintsRecordingHook{hints: &hints})
+	client.AddFlagHints("flaky", NewHookHints(map[string]interface{}{"verbose": true, "level": "debug"}))
+
+	t.Run("call-site hints take precedence over flag hints", func(t *testing.T) {
+		_, err := client.BooleanValue(context.Background(), "flaky", false, EvaluationContext{},
+			WithHookHints(NewHookHints(map[string]interface{}{"level": "trace"})))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string]interface{}{"verbose": true, "level": "trace"}
+		if !reflect.DeepEqual(hints.Values(), expected) {
+			t.Errorf("expected hints %v, got %v", expected, hints.Values())
+		}
+	})
+
+	t.Run("flag hints do not leak to other flags", func(t *testing.T) {
+		_, err := client.BooleanValue(context.Background(), "stable", false, EvaluationContext{},
+			WithHookHints(NewHookHints(map[string]interface{}{"level": "info"})))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string]interface{}{"level": "info"}
+		if !reflect.DeepEqual(hints.Values(), expected) {
+			t.Errorf("expected hints %v, got %v", expected, hints.Values())
+		}
+	})
+
+	t.Run("hints added for the same flag are merged", func(t *testing.T) {
+		client.AddFlagHints("flaky", NewHookHints(map[string]interface{}{"level": "warn"}))
+		if _, err := client.BooleanValue(context.Background(), "flaky", false, EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string]interface{}{"verbose": true, "level": "warn"}
+		if !reflect.DeepEqual(hints.Values(), expected) {
+			t.Errorf("expected hints %v, got %v", expected, hints.Values())
+		}
+	})
+}
+
+func TestAddHookHints(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+	var hints HookHints
+	client.AddHooks(hintsRecordingHook{hints: &hints})
+	client.AddHookHints(NewHookHints(map[string]interface{}{"team": "checkout", "level": "info"}))
+	client.AddFlagHints("flaky", NewHookHints(map[string]interface{}{"level": "debug"}))
+
+	tests := map[string]struct {
+		flag     string
+		options  []Option
+		expected map[string]interface{}
+	}{
+		"client hints apply without call hints": {
+			flag:     "foo",
+			expected: map[string]interface{}{"team": "checkout", "level": "info"},
+		},
+		"call hints take precedence over client hints": {
+			flag:     "foo",
+			options:  []Option{WithHookHints(NewHookHints(map[string]interface{}{"level": "trace", "debug": true}))},
+			expected: map[string]interface{}{"team": "checkout", "level": "trace", "debug": true},
+		},
+		"flag hints take precedence over client hints": {
+			flag:     "flaky",
+			expected: map[string]interface{}{"team": "checkout", "level": "debug"},
+		},
+		"call hints take precedence over flag hints": {
+			flag:     "flaky",
+			options:  []Option{WithHookHints(NewHookHints(map[string]interface{}{"level": "trace"}))},
+			expected: map[string]interface{}{"team": "checkout", "level": "trace"},
+		},
+		"call hints are merged in order": {
+			flag: "foo",
+			options: []Option{
+				WithHookHints(NewHookHints(map[string]interface{}{"debug": true, "level": "trace"})),
+				WithHookHints(NewHookHints(map[string]interface{}{"level": "warn"})),
+			},
+			expected: map[string]interface{}{"team": "checkout", "level": "warn", "debug": true},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := client.BooleanValue(context.Background(), test.flag, false, EvaluationContext{}, test.options...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(hints.Values(), test.expected) {
+				t.Errorf("expected hints %v, got %v", test.expected, hints.Values())
+			}
+		})
+	}
+}
+
+func TestEvaluateWithTrace(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 2)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	hook := contextHook{evalCtx: NewEvaluationContext("hooked", map[string]interface{}{"plan": "premium", "region": "eu"})}
+	client.AddHooks(hook)
+	mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, FlattenedContext{
+		TargetingKey: "hooked", "plan": "premium", "region": "eu",
+	}).Times(2).Return(BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{Reason: TargetingMatchReason}})
+	evalCtx := NewEvaluationContext("user", map[string]interface{}{"plan": "free", "region": "eu"})
+
+	details, trace, err := client.EvaluateWithTrace(context.Background(), FlagRequest{Key: "flag", Type: Boolean, DefaultValue: false}, evalCtx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stages := make([]HookStage, len(trace))
+	for i, entry := range trace {
+		stages[i] = entry.Stage
+		if !reflect.DeepEqual(entry.Hook, hook) || entry.Err != nil {
+			t.Errorf("expected the %s stage of the context hook to succeed, got %+v", entry.Stage, entry)
+		}
+	}
+	if expected := []HookStage{BeforeStage, AfterStage, FinallyStage}; !reflect.DeepEqual(stages, expected) {
+		t.Fatalf("expected the trace of the stages %v, got %v", expected, stages)
+	}
+	if expected := map[string]interface{}{TargetingKey: "hooked", "plan": "premium"}; !reflect.DeepEqual(trace[0].ContextChanges, expected) {
+		t.Errorf("expected the before stage to record the context changes %v, got %v", expected, trace[0].ContextChanges)
+	}
+
+	// tracing is purely observational
+	untraced, err := client.BooleanValueDetails(context.Background(), "flag", false, evalCtx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if details.Value != untraced.Value || details.ResolutionDetail.Reason != untraced.Reason {
+		t.Errorf("expected the traced evaluation to resolve like the untraced one, got %+v and %+v", details, untraced)
+	}
+}
+
+// fallbackHook recovers every failed evaluation with its fallback
+type fallbackHook struct {
+	UnimplementedHook
+	fallback interface{}
+}
+
+func (h fallbackHook) ErrorWithFallback(context.Context, HookContext, error, HookHints) (interface{}, bool) {
+	return h.fallback, true
+}
+
+func TestErrorRecoveryHook(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 1)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	mocks.providerAPI.EXPECT().StringEvaluation(gomock.Any(), "flag", "default", gomock.Any()).
+		Return(StringResolutionDetail{Value: "default", ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewGeneralResolutionError("unavailable"),
+			Reason:          ErrorReason,
+		}})
+
+	var hookErr error
+	var finallyDetails InterfaceEvaluationDetails
+	details, err := client.StringValueDetails(context.Background(), "flag", "default", EvaluationContext{}, WithHooks(
+		fallbackHook{fallback: 42}, fallbackHook{fallback: "fallback"}, fallbackHook{fallback: "ignored"},
+		errorRecordingHook{err: &hookErr}, finallyDetailsHook{details: &finallyDetails}))
+	if err != nil {
+		t.Fatalf("expected the evaluation to recover, got %v", err)
+	}
+	if details.Value != "fallback" || details.Reason != DefaultReason || details.ErrorCode != "" {
+		t.Errorf("expected the first fallback of the flag type with the DEFAULT reason, got %+v", details)
+	}
+	if finallyDetails.Value != "fallback" {
+		t.Errorf("expected the finally hooks to receive the recovered evaluation, got %+v", finallyDetails)
+	}
+	if !errors.Is(hookErr, GeneralError) {
+		t.Errorf("expected the other error hooks to receive the original error, got %v", hookErr)
+	}
+}
+
+// flattenedContextHook adds an attribute to the evaluation context in its before stage, and records the flattened
+// context of the hook context in all of its stages
+type flattenedContextHook struct {
+	UnimplementedHook
+	seen map[HookStage]FlattenedContext
+}
+
+func (h flattenedContextHook) Before(_ context.Context, hookContext HookContext, _ HookHints) (*EvaluationContext, error) {
+	h.seen[BeforeStage] = hookContext.FlattenedContext()
+	evalCtx := NewEvaluationContext(hookContext.EvaluationContext().TargetingKey(), map[string]interface{}{"bucket": "beta"})
+	return &evalCtx, nil
+}
+
+func (h flattenedContextHook) After(_ context.Context, hookContext HookContext, _ InterfaceEvaluationDetails, _ HookHints) error {
+	h.seen[AfterStage] = hookContext.FlattenedContext()
+	return nil
+}
+
+func TestHookContextFlattenedContext(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 1)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	client.SetEvaluationContext(NewEvaluationContext("user-1", map[string]interface{}{"plan": "pro"}))
+
+	var resolvedWith FlattenedContext
+	mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ bool, flatCtx FlattenedContext) BoolResolutionDetail {
+			resolvedWith = flatCtx
+			return BoolResolutionDetail{Value: true}
+		})
+
+	hook := flattenedContextHook{seen: map[HookStage]FlattenedContext{}}
+	_, err := client.BooleanValue(context.Background(), "flag", false, NewEvaluationContext("", map[string]interface{}{"region": "eu"}), WithHooks(hook))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if hook.seen[BeforeStage] != nil {
+		t.Errorf("expected no flattened context in the before stage, got %v", hook.seen[BeforeStage])
+	}
+	expected := FlattenedContext{TargetingKey: "user-1", "plan": "pro", "region": "eu", "bucket": "beta"}
+	if !reflect.DeepEqual(hook.seen[AfterStage], expected) {
+		t.Errorf("expected the after stage to see the flattened context %v, got %v", expected, hook.seen[AfterStage])
+	}
+	if !reflect.DeepEqual(resolvedWith, hook.seen[AfterStage]) {
+		t.Errorf("expected the flattened context given to the provider %v, got %v", resolvedWith, hook.seen[AfterStage])
+	}
+}
+
+func TestHookErrorEvents(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	if err := SetNamedProviderAndWait(t.Name(), NoopProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewClient(t.Name())
+	events := make(chan EventDetails, 1)
+	onHookError := func(details EventDetails) {
+		events <- details
+	}
+	client.AddHandler(HookError, &onHookError)
+
+	t.Run("a failing before hook emits a HOOK_ERROR event", func(t *testing.T) {
+		_, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{},
+			WithHooks(failingHook{stage: BeforeStage}), WithHookErrorEvents(true))
+		if err == nil {
+			t.Fatal("expected the evaluation to fail")
+		}
+
+		select {
+		case details := <-events:
+			if details.ProviderName != "NoopProvider" || details.Message != "before stage failed" {
+				t.Errorf("expected the event to report the provider and error, got %+v", details)
+			}
+			if details.EventMetadata[HookErrorFlagKey] != "flag" || details.EventMetadata[HookErrorStageKey] != "before" {
+				t.Errorf("expected the event to carry the flag key and stage, got %v", details.EventMetadata)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a HOOK_ERROR event")
+		}
+		if state := client.State(); state != ReadyState {
+			t.Errorf("expected the event not to change the provider state, got %s", state)
+		}
+	})
+
+	t.Run("a failing after hook emits a HOOK_ERROR event", func(t *testing.T) {
+		_, _ = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{},
+			WithHooks(failingHook{stage: AfterStage}), WithHookErrorEvents(true))
+
+		select {
+		case details := <-events:
+			if details.EventMetadata[HookErrorStageKey] != "after" {
+				t.Errorf("expected the event to report the after stage, got %v", details.EventMetadata)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a HOOK_ERROR event")
+		}
+	})
+
+	t.Run("events are not emitted by default", func(t *testing.T) {
+		_, _ = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{},
+			WithHooks(failingHook{stage: BeforeStage}))
+
+		select {
+		case details := <-events:
+			t.Errorf("expected no event, got %+v", details)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+func TestMaxHooks(t *testing.T) {
+	t.Run("evaluations exceeding the limit run no hooks", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		var calls int
+		client.AddHooks(countingHook{calls: &calls}, countingHook{calls: &calls})
+		details, err := client.BooleanValueDetails(context.Background(), "flag", true, EvaluationContext{},
+			WithHooks(countingHook{calls: &calls}), WithMaxHooks(2))
+		if !errors.Is(err, HookLimitExceededError) || !errors.Is(err, GeneralError) {
+			t.Errorf("expected a general error matching HookLimitExceededError, got %v", err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "3 hooks, exceeding the limit of 2") {
+			t.Errorf("expected the error to report the hook count & limit, got %v", err)
+		}
+		if !details.Value || details.Reason != ErrorReason || details.ErrorCode != GeneralCode {
+			t.Errorf("expected the default value with the error reason & code, got %v, %s, %s", details.Value, details.Reason, details.ErrorCode)
+		}
+		if calls != 0 {
+			t.Errorf("expected no hook to run, got %d calls", calls)
+		}
+	})
+
+	t.Run("evaluations within the limit run their hooks", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", true, gomock.Any()).
+			Return(BoolResolutionDetail{Value: false})
+
+		var calls int
+		if _, err := client.BooleanValue(context.Background(), "flag", true, EvaluationContext{},
+			WithHooks(countingHook{calls: &calls}, countingHook{calls: &calls}), WithMaxHooks(2)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 6 {
+			t.Errorf("expected the before, after & finally stages of both hooks to run, got %d calls", calls)
+		}
+	})
+}
+
+func TestAfterHookErrorReturnsTheDefaultValue(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 1)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+	mocks.providerAPI.EXPECT().ObjectEvaluation(gomock.Any(), "flag", "default", gomock.Any()).
+		Return(InterfaceResolutionDetail{Value: "resolved", ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}})
+
+	details, err := client.ObjectValueDetails(context.Background(), "flag", "default", EvaluationContext{},
+		WithHooks(failingHook{stage: AfterStage}))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if details.Value != "default" || details.Reason != ErrorReason || details.ErrorCode != GeneralCode {
+		t.Errorf("expected the default value with the error reason & code, got %v, %s, %s", details.Value, details.Reason, details.ErrorCode)
+	}
+}
+
+func TestBeforeHookErrorDetails(t *testing.T) {
+	mocks := hydratedMocksForClientTests(t, 1)
+	client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+	details, err := client.ObjectValueDetails(context.Background(), "flag", "default", EvaluationContext{},
+		WithHooks(failingHook{stage: BeforeStage}))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if details.Value != "default" || details.Reason != ErrorReason || details.ErrorCode != GeneralCode {
+		t.Errorf("expected the default value with the error reason & code, got %v, %s, %s", details.Value, details.Reason, details.ErrorCode)
+	}
+	if details.ErrorMessage != err.Error() {
+		t.Errorf("expected the error message %q, got %q", err.Error(), details.ErrorMessage)
+	}
+}
+
+// stageRecordingHook records the stages it is invoked in, in order, failing the given stage
+type stageRecordingHook struct {
+	stages *[]HookStage
+	fail   HookStage
+}
+
+func (h stageRecordingHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	*h.stages = append(*h.stages, BeforeStage)
+	if h.fail == BeforeStage {
+		return nil, errors.New("before failed")
+	}
+	return nil, nil
+}
+
+func (h stageRecordingHook) After(context.Context, HookContext, InterfaceEvaluationDetails, HookHints) error {
+	*h.stages = append(*h.stages, AfterStage)
+	if h.fail == AfterStage {
+		return errors.New("after failed")
+	}
+	return nil
+}
+
+func (h stageRecordingHook) Error(context.Context, HookContext, error, HookHints) {
+	*h.stages = append(*h.stages, ErrorStage)
+}
+
+func (h stageRecordingHook) Finally(context.Context, HookContext, HookHints) {
+	*h.stages = append(*h.stages, FinallyStage)
+}
+
+// After hooks run on success only, Error hooks on failure only, and Finally hooks always run.
+func TestHookStageInvocationMatrix(t *testing.T) {
+	const none = HookStage(-1)
+	resolved := BoolResolutionDetail{Value: true, ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}}
+	failed := BoolResolutionDetail{ProviderResolutionDetail: ProviderResolutionDetail{
+		ResolutionError: NewGeneralResolutionError("provider failed"),
+		Reason:          ErrorReason,
+	}}
+
+	tests := map[string]struct {
+		state     State
+		fail      HookStage
+		resolves  bool
+		resolved  BoolResolutionDetail
+		expected  []HookStage
+		expectErr bool
+	}{
+		"success": {
+			state: ReadyState, fail: none, resolves: true, resolved: resolved,
+			expected: []HookStage{BeforeStage, AfterStage, FinallyStage},
+		},
+		"before error": {
+			state: ReadyState, fail: BeforeStage,
+			expected: []HookStage{BeforeStage, ErrorStage, FinallyStage}, expectErr: true,
+		},
+		"provider error": {
+			state: ReadyState, fail: none, resolves: true, resolved: failed,
+			expected: []HookStage{BeforeStage, ErrorStage, FinallyStage}, expectErr: true,
+		},
+		"after error": {
+			state: ReadyState, fail: AfterStage, resolves: true, resolved: resolved,
+			expected: []HookStage{BeforeStage, AfterStage, ErrorStage, FinallyStage}, expectErr: true,
+		},
+		"provider not ready": {
+			state: NotReadyState, fail: none,
+			expected: []HookStage{ErrorStage, FinallyStage}, expectErr: true,
+		},
+		"provider fatal": {
+			state: FatalState, fail: none,
+			expected: []HookStage{ErrorStage, FinallyStage}, expectErr: true,
+		},
+	}
+
+	evaluations := map[string]func(client *Client, hook Hook) (interface{}, error){
+		"single evaluation": func(client *Client, hook Hook) (interface{}, error) {
+			details, err := client.BooleanValueDetails(context.Background(), "foo", false, EvaluationContext{}, WithHooks(hook))
+			return details.Value, err
+		},
+		"batch evaluation": func(client *Client, hook Hook) (interface{}, error) {
+			details, err := client.EvaluateBatch(context.Background(), []FlagRequest{{Key: "foo", Type: Boolean, DefaultValue: false}},
+				EvaluationContext{}, WithHooks(hook))
+			if err == nil && len(details) == 1 && details[0].ErrorCode != "" {
+				err = errors.New(details[0].ErrorMessage)
+			}
+			if len(details) != 1 {
+				return nil, err
+			}
+			return details[0].Value, err
+		},
+	}
+
+	for evaluationName, evaluate := range evaluations {
+		for name, test := range tests {
+			t.Run(evaluationName+"/"+name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				mockClientApi := NewMockclientEvent(ctrl)
+				mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(test.state)
+				mockProvider := NewMockFeatureProvider(ctrl)
+				mockProvider.EXPECT().Metadata().AnyTimes()
+				mockProvider.EXPECT().Hooks().AnyTimes()
+				if test.resolves {
+					mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "foo", false, gomock.Any()).Return(test.resolved)
+				}
+				mockEvaluationApi := NewMockevaluationImpl(ctrl)
+				mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Return(mockProvider, nil, EvaluationContext{}, nil)
+				client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+				var stages []HookStage
+				value, err := evaluate(client, stageRecordingHook{stages: &stages, fail: test.fail})
+				if !reflect.DeepEqual(stages, test.expected) {
+					t.Errorf("expected the stages %v, got %v", test.expected, stages)
+				}
+				if test.expectErr != (err != nil) {
+					t.Errorf("expected an error: %v, got %v", test.expectErr, err)
+				}
+				if test.expectErr && value != false {
+					t.Errorf("expected the default value on failure, got %v", value)
+				}
+			})
+		}
+	}
+}
@@ -2,6 +2,8 @@ package openfeature
 
 import (
 	"context"
+	"time"
+
 	"github.com/go-logr/logr"
 )
 
@@ -9,14 +11,32 @@ import (
 type IEvaluation interface {
 	SetProvider(provider FeatureProvider) error
 	SetProviderAndWait(provider FeatureProvider) error
+	SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error
 	GetProviderMetadata() Metadata
 	SetNamedProvider(clientName string, provider FeatureProvider, async bool) error
+	SwapProvider(provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error)
+	SwapNamedProvider(clientName string, provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error)
 	GetNamedProviderMetadata(name string) Metadata
+	ProviderMetadata(domain string) Metadata
 	GetClient() IClient
 	GetNamedClient(clientName string) IClient
 	SetEvaluationContext(apiCtx EvaluationContext)
 	AddHooks(hooks ...Hook)
+	Hooks() []Hook
+	ClearHooks()
+	RemoveHook(hook Hook) bool
+	AddHookSet(set HookSet)
+	RemoveHookSet(name string) bool
+	AddContextEnricher(enricher ContextEnricher)
+	SetHealthCheckConfig(config HealthCheckConfig)
+	SetTrackingQueueConfig(config TrackingQueueConfig)
+	ProviderStatus(domain string) State
+	WaitForReady(ctx context.Context, domain string) error
+	RefreshProvider(ctx context.Context, domain string) error
+	AddNamedHandler(domain string, eventType EventType, callback EventCallback)
+	RemoveNamedHandler(domain string, eventType EventType, callback EventCallback)
 	Shutdown()
+	ShutdownWithContext(ctx context.Context) error
 	IEventing
 }
 
@@ -24,8 +44,17 @@ type IEvaluation interface {
 type IClient interface {
 	Metadata() ClientMetadata
 	AddHooks(hooks ...Hook)
+	AddHookHints(hints HookHints)
+	AddFlagHints(flagKey string, hints HookHints)
+	Hooks() []Hook
+	ClearHooks()
+	RemoveHook(hook Hook) bool
 	SetEvaluationContext(evalCtx EvaluationContext)
 	EvaluationContext() EvaluationContext
+	Close()
+	FlagExists(ctx context.Context, flag string) (bool, error)
+	ListFlags(ctx context.Context) ([]string, error)
+	EvaluationMetadata(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (FlagMetadata, error)
 	BooleanValue(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, options ...Option) (bool, error)
 	StringValue(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, options ...Option) (string, error)
 	FloatValue(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (float64, error)
@@ -36,6 +65,40 @@ type IClient interface {
 	FloatValueDetails(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (FloatEvaluationDetails, error)
 	IntValueDetails(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) (IntEvaluationDetails, error)
 	ObjectValueDetails(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error)
+	ObjectValueInto(ctx context.Context, flag string, target interface{}, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error)
+	EvaluateBatch(ctx context.Context, requests []FlagRequest, evalCtx EvaluationContext, options ...Option) ([]InterfaceEvaluationDetails, error)
+	EvaluateWithTrace(ctx context.Context, request FlagRequest, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, []HookTraceEntry, error)
+	BooleanValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) bool, evalCtx EvaluationContext, options ...Option) (bool, error)
+	StringValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) string, evalCtx EvaluationContext, options ...Option) (string, error)
+	FloatValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) float64, evalCtx EvaluationContext, options ...Option) (float64, error)
+	IntValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) int64, evalCtx EvaluationContext, options ...Option) (int64, error)
+	ObjectValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) interface{}, evalCtx EvaluationContext, options ...Option) (interface{}, error)
+	ObjectValueWeightedDefault(ctx context.Context, flag string, fallbacks []WeightedValue, evalCtx EvaluationContext, options ...Option) (interface{}, error)
+	BooleanValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) bool, evalCtx EvaluationContext, options ...Option) (BooleanEvaluationDetails, error)
+	StringValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) string, evalCtx EvaluationContext, options ...Option) (StringEvaluationDetails, error)
+	FloatValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) float64, evalCtx EvaluationContext, options ...Option) (FloatEvaluationDetails, error)
+	IntValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) int64, evalCtx EvaluationContext, options ...Option) (IntEvaluationDetails, error)
+	ObjectValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) interface{}, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error)
+	BooleanValueWithProvider(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (bool, error)
+	StringValueWithProvider(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (string, error)
+	FloatValueWithProvider(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (float64, error)
+	IntValueWithProvider(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (int64, error)
+	ObjectValueWithProvider(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (interface{}, error)
+	BooleanValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (bool, error)
+	StringValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (string, error)
+	FloatValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (float64, error)
+	IntValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (int64, error)
+	ObjectValueForceVariant(ctx context.Context, flag string, variant string, evalCtx EvaluationContext, options ...Option) (interface{}, error)
+	BooleanValuePreflattened(ctx context.Context, flag string, defaultValue bool, flatCtx FlattenedContext, options ...Option) (bool, error)
+	StringValuePreflattened(ctx context.Context, flag string, defaultValue string, flatCtx FlattenedContext, options ...Option) (string, error)
+	FloatValuePreflattened(ctx context.Context, flag string, defaultValue float64, flatCtx FlattenedContext, options ...Option) (float64, error)
+	IntValuePreflattened(ctx context.Context, flag string, defaultValue int64, flatCtx FlattenedContext, options ...Option) (int64, error)
+	ObjectValuePreflattened(ctx context.Context, flag string, defaultValue interface{}, flatCtx FlattenedContext, options ...Option) (interface{}, error)
+	BooleanValueWithTimeout(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (bool, error)
+	StringValueWithTimeout(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (string, error)
+	FloatValueWithTimeout(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (float64, error)
+	IntValueWithTimeout(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (int64, error)
+	ObjectValueWithTimeout(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (interface{}, error)
 
 	Boolean(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, options ...Option) bool
 	String(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, options ...Option) string
@@ -44,6 +107,7 @@ type IClient interface {
 	Object(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) interface{}
 
 	State() State
+	ProviderStatus() State
 
 	IEventing
 	ITracking
@@ -51,13 +115,14 @@ type IClient interface {
 
 // IEventing defines the OpenFeature eventing contract
 type IEventing interface {
-	AddHandler(eventType EventType, callback EventCallback)
+	AddHandler(eventType EventType, callback EventCallback) (unsubscribe func())
 	RemoveHandler(eventType EventType, callback EventCallback)
 }
 
 // ITracking defines the Tracking contract
 type ITracking interface {
 	Track(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails)
+	TrackAsync(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails) bool
 }
 
 // evaluationImpl is an internal reference interface extending IEvaluation
@@ -67,10 +132,16 @@ type evaluationImpl interface {
 	GetNamedProviders() map[string]FeatureProvider
 	GetHooks() []Hook
 
+	SetProviderWithOptions(provider FeatureProvider, options ...ProviderOption) error
+	SetProviderAndWaitWithOptions(provider FeatureProvider, options ...ProviderOption) error
+	SetProviderAndWaitContextWithOptions(ctx context.Context, provider FeatureProvider, options ...ProviderOption) error
+	SetNamedProviderWithOptions(clientName string, provider FeatureProvider, async bool, options ...ProviderOption) error
+
 	// Deprecated
 	SetLogger(l logr.Logger)
 
-	ForEvaluation(clientName string) (FeatureProvider, []Hook, EvaluationContext)
+	ForEvaluation(clientName string) (FeatureProvider, []Hook, EvaluationContext, []ContextEnricher)
+	enqueueTracking(ctx context.Context, track func()) bool
 }
 
 // eventingImpl is an internal reference interface extending IEventing
@@ -88,4 +159,6 @@ type clientEvent interface {
 	RemoveClientHandler(name string, t EventType, c EventCallback)
 
 	State(domain string) State
+	// emitProviderEvent dispatches the event on behalf of the provider, e.g. for failures detected by the SDK
+	emitProviderEvent(provider FeatureProvider, event Event)
 }
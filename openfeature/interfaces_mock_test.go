@@ -7,6 +7,7 @@ package openfeature
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	logr "github.com/go-logr/logr"
 	gomock "github.com/golang/mock/gomock"
@@ -35,10 +36,24 @@ func (m *MockIEvaluation) EXPECT() *MockIEvaluationMockRecorder {
 	return m.recorder
 }
 
+// AddContextEnricher mocks base method.
+func (m *MockIEvaluation) AddContextEnricher(enricher ContextEnricher) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddContextEnricher", enricher)
+}
+
+// AddContextEnricher indicates an expected call of AddContextEnricher.
+func (mr *MockIEvaluationMockRecorder) AddContextEnricher(enricher interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContextEnricher", reflect.TypeOf((*MockIEvaluation)(nil).AddContextEnricher), enricher)
+}
+
 // AddHandler mocks base method.
-func (m *MockIEvaluation) AddHandler(eventType EventType, callback EventCallback) {
+func (m *MockIEvaluation) AddHandler(eventType EventType, callback EventCallback) func() {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret := m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret0, _ := ret[0].(func())
+	return ret0
 }
 
 // AddHandler indicates an expected call of AddHandler.
@@ -47,6 +62,18 @@ func (mr *MockIEvaluationMockRecorder) AddHandler(eventType, callback interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHandler", reflect.TypeOf((*MockIEvaluation)(nil).AddHandler), eventType, callback)
 }
 
+// AddHookSet mocks base method.
+func (m *MockIEvaluation) AddHookSet(set HookSet) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddHookSet", set)
+}
+
+// AddHookSet indicates an expected call of AddHookSet.
+func (mr *MockIEvaluationMockRecorder) AddHookSet(set interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHookSet", reflect.TypeOf((*MockIEvaluation)(nil).AddHookSet), set)
+}
+
 // AddHooks mocks base method.
 func (m *MockIEvaluation) AddHooks(hooks ...Hook) {
 	m.ctrl.T.Helper()
@@ -63,6 +90,30 @@ func (mr *MockIEvaluationMockRecorder) AddHooks(hooks ...interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHooks", reflect.TypeOf((*MockIEvaluation)(nil).AddHooks), hooks...)
 }
 
+// AddNamedHandler mocks base method.
+func (m *MockIEvaluation) AddNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddNamedHandler", domain, eventType, callback)
+}
+
+// AddNamedHandler indicates an expected call of AddNamedHandler.
+func (mr *MockIEvaluationMockRecorder) AddNamedHandler(domain, eventType, callback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNamedHandler", reflect.TypeOf((*MockIEvaluation)(nil).AddNamedHandler), domain, eventType, callback)
+}
+
+// ClearHooks mocks base method.
+func (m *MockIEvaluation) ClearHooks() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearHooks")
+}
+
+// ClearHooks indicates an expected call of ClearHooks.
+func (mr *MockIEvaluationMockRecorder) ClearHooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearHooks", reflect.TypeOf((*MockIEvaluation)(nil).ClearHooks))
+}
+
 // GetClient mocks base method.
 func (m *MockIEvaluation) GetClient() IClient {
 	m.ctrl.T.Helper()
@@ -119,6 +170,62 @@ func (mr *MockIEvaluationMockRecorder) GetProviderMetadata() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderMetadata", reflect.TypeOf((*MockIEvaluation)(nil).GetProviderMetadata))
 }
 
+// Hooks mocks base method.
+func (m *MockIEvaluation) Hooks() []Hook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hooks")
+	ret0, _ := ret[0].([]Hook)
+	return ret0
+}
+
+// Hooks indicates an expected call of Hooks.
+func (mr *MockIEvaluationMockRecorder) Hooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hooks", reflect.TypeOf((*MockIEvaluation)(nil).Hooks))
+}
+
+// ProviderMetadata mocks base method.
+func (m *MockIEvaluation) ProviderMetadata(domain string) Metadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderMetadata", domain)
+	ret0, _ := ret[0].(Metadata)
+	return ret0
+}
+
+// ProviderMetadata indicates an expected call of ProviderMetadata.
+func (mr *MockIEvaluationMockRecorder) ProviderMetadata(domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderMetadata", reflect.TypeOf((*MockIEvaluation)(nil).ProviderMetadata), domain)
+}
+
+// ProviderStatus mocks base method.
+func (m *MockIEvaluation) ProviderStatus(domain string) State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderStatus", domain)
+	ret0, _ := ret[0].(State)
+	return ret0
+}
+
+// ProviderStatus indicates an expected call of ProviderStatus.
+func (mr *MockIEvaluationMockRecorder) ProviderStatus(domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderStatus", reflect.TypeOf((*MockIEvaluation)(nil).ProviderStatus), domain)
+}
+
+// RefreshProvider mocks base method.
+func (m *MockIEvaluation) RefreshProvider(ctx context.Context, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshProvider", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshProvider indicates an expected call of RefreshProvider.
+func (mr *MockIEvaluationMockRecorder) RefreshProvider(ctx, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshProvider", reflect.TypeOf((*MockIEvaluation)(nil).RefreshProvider), ctx, domain)
+}
+
 // RemoveHandler mocks base method.
 func (m *MockIEvaluation) RemoveHandler(eventType EventType, callback EventCallback) {
 	m.ctrl.T.Helper()
@@ -131,6 +238,46 @@ func (mr *MockIEvaluationMockRecorder) RemoveHandler(eventType, callback interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHandler", reflect.TypeOf((*MockIEvaluation)(nil).RemoveHandler), eventType, callback)
 }
 
+// RemoveHook mocks base method.
+func (m *MockIEvaluation) RemoveHook(hook Hook) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHook", hook)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RemoveHook indicates an expected call of RemoveHook.
+func (mr *MockIEvaluationMockRecorder) RemoveHook(hook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHook", reflect.TypeOf((*MockIEvaluation)(nil).RemoveHook), hook)
+}
+
+// RemoveHookSet mocks base method.
+func (m *MockIEvaluation) RemoveHookSet(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHookSet", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RemoveHookSet indicates an expected call of RemoveHookSet.
+func (mr *MockIEvaluationMockRecorder) RemoveHookSet(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHookSet", reflect.TypeOf((*MockIEvaluation)(nil).RemoveHookSet), name)
+}
+
+// RemoveNamedHandler mocks base method.
+func (m *MockIEvaluation) RemoveNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveNamedHandler", domain, eventType, callback)
+}
+
+// RemoveNamedHandler indicates an expected call of RemoveNamedHandler.
+func (mr *MockIEvaluationMockRecorder) RemoveNamedHandler(domain, eventType, callback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNamedHandler", reflect.TypeOf((*MockIEvaluation)(nil).RemoveNamedHandler), domain, eventType, callback)
+}
+
 // SetEvaluationContext mocks base method.
 func (m *MockIEvaluation) SetEvaluationContext(apiCtx EvaluationContext) {
 	m.ctrl.T.Helper()
@@ -143,6 +290,18 @@ func (mr *MockIEvaluationMockRecorder) SetEvaluationContext(apiCtx interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEvaluationContext", reflect.TypeOf((*MockIEvaluation)(nil).SetEvaluationContext), apiCtx)
 }
 
+// SetHealthCheckConfig mocks base method.
+func (m *MockIEvaluation) SetHealthCheckConfig(config HealthCheckConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHealthCheckConfig", config)
+}
+
+// SetHealthCheckConfig indicates an expected call of SetHealthCheckConfig.
+func (mr *MockIEvaluationMockRecorder) SetHealthCheckConfig(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthCheckConfig", reflect.TypeOf((*MockIEvaluation)(nil).SetHealthCheckConfig), config)
+}
+
 // SetNamedProvider mocks base method.
 func (m *MockIEvaluation) SetNamedProvider(clientName string, provider FeatureProvider, async bool) error {
 	m.ctrl.T.Helper()
@@ -185,6 +344,32 @@ func (mr *MockIEvaluationMockRecorder) SetProviderAndWait(provider interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderAndWait", reflect.TypeOf((*MockIEvaluation)(nil).SetProviderAndWait), provider)
 }
 
+// SetProviderAndWaitContext mocks base method.
+func (m *MockIEvaluation) SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProviderAndWaitContext", ctx, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProviderAndWaitContext indicates an expected call of SetProviderAndWaitContext.
+func (mr *MockIEvaluationMockRecorder) SetProviderAndWaitContext(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderAndWaitContext", reflect.TypeOf((*MockIEvaluation)(nil).SetProviderAndWaitContext), ctx, provider)
+}
+
+// SetTrackingQueueConfig mocks base method.
+func (m *MockIEvaluation) SetTrackingQueueConfig(config TrackingQueueConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrackingQueueConfig", config)
+}
+
+// SetTrackingQueueConfig indicates an expected call of SetTrackingQueueConfig.
+func (mr *MockIEvaluationMockRecorder) SetTrackingQueueConfig(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrackingQueueConfig", reflect.TypeOf((*MockIEvaluation)(nil).SetTrackingQueueConfig), config)
+}
+
 // Shutdown mocks base method.
 func (m *MockIEvaluation) Shutdown() {
 	m.ctrl.T.Helper()
@@ -197,6 +382,74 @@ func (mr *MockIEvaluationMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockIEvaluation)(nil).Shutdown))
 }
 
+// ShutdownWithContext mocks base method.
+func (m *MockIEvaluation) ShutdownWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutdownWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShutdownWithContext indicates an expected call of ShutdownWithContext.
+func (mr *MockIEvaluationMockRecorder) ShutdownWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutdownWithContext", reflect.TypeOf((*MockIEvaluation)(nil).ShutdownWithContext), ctx)
+}
+
+// SwapNamedProvider mocks base method.
+func (m *MockIEvaluation) SwapNamedProvider(clientName string, provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{clientName, provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SwapNamedProvider", varargs...)
+	ret0, _ := ret[0].(FeatureProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapNamedProvider indicates an expected call of SwapNamedProvider.
+func (mr *MockIEvaluationMockRecorder) SwapNamedProvider(clientName, provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{clientName, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapNamedProvider", reflect.TypeOf((*MockIEvaluation)(nil).SwapNamedProvider), varargs...)
+}
+
+// SwapProvider mocks base method.
+func (m *MockIEvaluation) SwapProvider(provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SwapProvider", varargs...)
+	ret0, _ := ret[0].(FeatureProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapProvider indicates an expected call of SwapProvider.
+func (mr *MockIEvaluationMockRecorder) SwapProvider(provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapProvider", reflect.TypeOf((*MockIEvaluation)(nil).SwapProvider), varargs...)
+}
+
+// WaitForReady mocks base method.
+func (m *MockIEvaluation) WaitForReady(ctx context.Context, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForReady", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForReady indicates an expected call of WaitForReady.
+func (mr *MockIEvaluationMockRecorder) WaitForReady(ctx, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForReady", reflect.TypeOf((*MockIEvaluation)(nil).WaitForReady), ctx, domain)
+}
+
 // MockIClient is a mock of IClient interface.
 type MockIClient struct {
 	ctrl     *gomock.Controller
@@ -220,10 +473,24 @@ func (m *MockIClient) EXPECT() *MockIClientMockRecorder {
 	return m.recorder
 }
 
+// AddFlagHints mocks base method.
+func (m *MockIClient) AddFlagHints(flagKey string, hints HookHints) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddFlagHints", flagKey, hints)
+}
+
+// AddFlagHints indicates an expected call of AddFlagHints.
+func (mr *MockIClientMockRecorder) AddFlagHints(flagKey, hints interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFlagHints", reflect.TypeOf((*MockIClient)(nil).AddFlagHints), flagKey, hints)
+}
+
 // AddHandler mocks base method.
-func (m *MockIClient) AddHandler(eventType EventType, callback EventCallback) {
+func (m *MockIClient) AddHandler(eventType EventType, callback EventCallback) func() {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret := m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret0, _ := ret[0].(func())
+	return ret0
 }
 
 // AddHandler indicates an expected call of AddHandler.
@@ -232,6 +499,18 @@ func (mr *MockIClientMockRecorder) AddHandler(eventType, callback interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHandler", reflect.TypeOf((*MockIClient)(nil).AddHandler), eventType, callback)
 }
 
+// AddHookHints mocks base method.
+func (m *MockIClient) AddHookHints(hints HookHints) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddHookHints", hints)
+}
+
+// AddHookHints indicates an expected call of AddHookHints.
+func (mr *MockIClientMockRecorder) AddHookHints(hints interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHookHints", reflect.TypeOf((*MockIClient)(nil).AddHookHints), hints)
+}
+
 // AddHooks mocks base method.
 func (m *MockIClient) AddHooks(hooks ...Hook) {
 	m.ctrl.T.Helper()
@@ -307,175 +586,664 @@ func (mr *MockIClientMockRecorder) BooleanValueDetails(ctx, flag, defaultValue,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooleanValueDetails", reflect.TypeOf((*MockIClient)(nil).BooleanValueDetails), varargs...)
 }
 
-// EvaluationContext mocks base method.
-func (m *MockIClient) EvaluationContext() EvaluationContext {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EvaluationContext")
-	ret0, _ := ret[0].(EvaluationContext)
-	return ret0
-}
-
-// EvaluationContext indicates an expected call of EvaluationContext.
-func (mr *MockIClientMockRecorder) EvaluationContext() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluationContext", reflect.TypeOf((*MockIClient)(nil).EvaluationContext))
-}
-
-// Float mocks base method.
-func (m *MockIClient) Float(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) float64 {
+// BooleanValueDetailsFunc mocks base method.
+func (m *MockIClient) BooleanValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) bool, evalCtx EvaluationContext, options ...Option) (BooleanEvaluationDetails, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Float", varargs...)
-	ret0, _ := ret[0].(float64)
-	return ret0
+	ret := m.ctrl.Call(m, "BooleanValueDetailsFunc", varargs...)
+	ret0, _ := ret[0].(BooleanEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Float indicates an expected call of Float.
-func (mr *MockIClientMockRecorder) Float(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// BooleanValueDetailsFunc indicates an expected call of BooleanValueDetailsFunc.
+func (mr *MockIClientMockRecorder) BooleanValueDetailsFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Float", reflect.TypeOf((*MockIClient)(nil).Float), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooleanValueDetailsFunc", reflect.TypeOf((*MockIClient)(nil).BooleanValueDetailsFunc), varargs...)
 }
 
-// FloatValue mocks base method.
-func (m *MockIClient) FloatValue(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (float64, error) {
+// BooleanValueForceVariant mocks base method.
+func (m *MockIClient) BooleanValueForceVariant(ctx context.Context, flag, variant string, evalCtx EvaluationContext, options ...Option) (bool, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, variant, evalCtx}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "FloatValue", varargs...)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "BooleanValueForceVariant", varargs...)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FloatValue indicates an expected call of FloatValue.
-func (mr *MockIClientMockRecorder) FloatValue(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// BooleanValueForceVariant indicates an expected call of BooleanValueForceVariant.
+func (mr *MockIClientMockRecorder) BooleanValueForceVariant(ctx, flag, variant, evalCtx interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValue", reflect.TypeOf((*MockIClient)(nil).FloatValue), varargs...)
+	varargs := append([]interface{}{ctx, flag, variant, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooleanValueForceVariant", reflect.TypeOf((*MockIClient)(nil).BooleanValueForceVariant), varargs...)
 }
 
-// FloatValueDetails mocks base method.
-func (m *MockIClient) FloatValueDetails(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (FloatEvaluationDetails, error) {
+// BooleanValueFunc mocks base method.
+func (m *MockIClient) BooleanValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) bool, evalCtx EvaluationContext, options ...Option) (bool, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "FloatValueDetails", varargs...)
-	ret0, _ := ret[0].(FloatEvaluationDetails)
+	ret := m.ctrl.Call(m, "BooleanValueFunc", varargs...)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FloatValueDetails indicates an expected call of FloatValueDetails.
-func (mr *MockIClientMockRecorder) FloatValueDetails(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// BooleanValueFunc indicates an expected call of BooleanValueFunc.
+func (mr *MockIClientMockRecorder) BooleanValueFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValueDetails", reflect.TypeOf((*MockIClient)(nil).FloatValueDetails), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooleanValueFunc", reflect.TypeOf((*MockIClient)(nil).BooleanValueFunc), varargs...)
 }
 
-// Int mocks base method.
-func (m *MockIClient) Int(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) int64 {
+// BooleanValuePreflattened mocks base method.
+func (m *MockIClient) BooleanValuePreflattened(ctx context.Context, flag string, defaultValue bool, flatCtx FlattenedContext, options ...Option) (bool, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultValue, flatCtx}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Int", varargs...)
-	ret0, _ := ret[0].(int64)
-	return ret0
+	ret := m.ctrl.Call(m, "BooleanValuePreflattened", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Int indicates an expected call of Int.
-func (mr *MockIClientMockRecorder) Int(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// BooleanValuePreflattened indicates an expected call of BooleanValuePreflattened.
+func (mr *MockIClientMockRecorder) BooleanValuePreflattened(ctx, flag, defaultValue, flatCtx interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Int", reflect.TypeOf((*MockIClient)(nil).Int), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultValue, flatCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooleanValuePreflattened", reflect.TypeOf((*MockIClient)(nil).BooleanValuePreflattened), varargs...)
 }
 
-// IntValue mocks base method.
-func (m *MockIClient) IntValue(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) (int64, error) {
+// BooleanValueWithProvider mocks base method.
+func (m *MockIClient) BooleanValueWithProvider(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (bool, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, provider}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "IntValue", varargs...)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "BooleanValueWithProvider", varargs...)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IntValue indicates an expected call of IntValue.
-func (mr *MockIClientMockRecorder) IntValue(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// BooleanValueWithProvider indicates an expected call of BooleanValueWithProvider.
+func (mr *MockIClientMockRecorder) BooleanValueWithProvider(ctx, flag, defaultValue, evalCtx, provider interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValue", reflect.TypeOf((*MockIClient)(nil).IntValue), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooleanValueWithProvider", reflect.TypeOf((*MockIClient)(nil).BooleanValueWithProvider), varargs...)
 }
 
-// IntValueDetails mocks base method.
-func (m *MockIClient) IntValueDetails(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) (IntEvaluationDetails, error) {
+// BooleanValueWithTimeout mocks base method.
+func (m *MockIClient) BooleanValueWithTimeout(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (bool, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, timeout}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "IntValueDetails", varargs...)
-	ret0, _ := ret[0].(IntEvaluationDetails)
+	ret := m.ctrl.Call(m, "BooleanValueWithTimeout", varargs...)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IntValueDetails indicates an expected call of IntValueDetails.
-func (mr *MockIClientMockRecorder) IntValueDetails(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// BooleanValueWithTimeout indicates an expected call of BooleanValueWithTimeout.
+func (mr *MockIClientMockRecorder) BooleanValueWithTimeout(ctx, flag, defaultValue, evalCtx, timeout interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValueDetails", reflect.TypeOf((*MockIClient)(nil).IntValueDetails), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, timeout}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BooleanValueWithTimeout", reflect.TypeOf((*MockIClient)(nil).BooleanValueWithTimeout), varargs...)
 }
 
-// Metadata mocks base method.
-func (m *MockIClient) Metadata() ClientMetadata {
+// ClearHooks mocks base method.
+func (m *MockIClient) ClearHooks() {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Metadata")
-	ret0, _ := ret[0].(ClientMetadata)
-	return ret0
+	m.ctrl.Call(m, "ClearHooks")
 }
 
-// Metadata indicates an expected call of Metadata.
-func (mr *MockIClientMockRecorder) Metadata() *gomock.Call {
+// ClearHooks indicates an expected call of ClearHooks.
+func (mr *MockIClientMockRecorder) ClearHooks() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockIClient)(nil).Metadata))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearHooks", reflect.TypeOf((*MockIClient)(nil).ClearHooks))
 }
 
-// Object mocks base method.
-func (m *MockIClient) Object(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) interface{} {
+// Close mocks base method.
+func (m *MockIClient) Close() {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
-	for _, a := range options {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "Object", varargs...)
-	ret0, _ := ret[0].(interface{})
-	return ret0
+	m.ctrl.Call(m, "Close")
 }
 
-// Object indicates an expected call of Object.
-func (mr *MockIClientMockRecorder) Object(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// Close indicates an expected call of Close.
+func (mr *MockIClientMockRecorder) Close() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Object", reflect.TypeOf((*MockIClient)(nil).Object), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIClient)(nil).Close))
 }
 
-// ObjectValue mocks base method.
-func (m *MockIClient) ObjectValue(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+// EvaluateBatch mocks base method.
+func (m *MockIClient) EvaluateBatch(ctx context.Context, requests []FlagRequest, evalCtx EvaluationContext, options ...Option) ([]InterfaceEvaluationDetails, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, requests, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EvaluateBatch", varargs...)
+	ret0, _ := ret[0].([]InterfaceEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluateBatch indicates an expected call of EvaluateBatch.
+func (mr *MockIClientMockRecorder) EvaluateBatch(ctx, requests, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, requests, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateBatch", reflect.TypeOf((*MockIClient)(nil).EvaluateBatch), varargs...)
+}
+
+// EvaluateWithTrace mocks base method.
+func (m *MockIClient) EvaluateWithTrace(ctx context.Context, request FlagRequest, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, []HookTraceEntry, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, request, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EvaluateWithTrace", varargs...)
+	ret0, _ := ret[0].(InterfaceEvaluationDetails)
+	ret1, _ := ret[1].([]HookTraceEntry)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EvaluateWithTrace indicates an expected call of EvaluateWithTrace.
+func (mr *MockIClientMockRecorder) EvaluateWithTrace(ctx, request, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, request, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateWithTrace", reflect.TypeOf((*MockIClient)(nil).EvaluateWithTrace), varargs...)
+}
+
+// EvaluationContext mocks base method.
+func (m *MockIClient) EvaluationContext() EvaluationContext {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluationContext")
+	ret0, _ := ret[0].(EvaluationContext)
+	return ret0
+}
+
+// EvaluationContext indicates an expected call of EvaluationContext.
+func (mr *MockIClientMockRecorder) EvaluationContext() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluationContext", reflect.TypeOf((*MockIClient)(nil).EvaluationContext))
+}
+
+// EvaluationMetadata mocks base method.
+func (m *MockIClient) EvaluationMetadata(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (FlagMetadata, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EvaluationMetadata", varargs...)
+	ret0, _ := ret[0].(FlagMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluationMetadata indicates an expected call of EvaluationMetadata.
+func (mr *MockIClientMockRecorder) EvaluationMetadata(ctx, flag, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluationMetadata", reflect.TypeOf((*MockIClient)(nil).EvaluationMetadata), varargs...)
+}
+
+// FlagExists mocks base method.
+func (m *MockIClient) FlagExists(ctx context.Context, flag string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagExists", ctx, flag)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlagExists indicates an expected call of FlagExists.
+func (mr *MockIClientMockRecorder) FlagExists(ctx, flag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagExists", reflect.TypeOf((*MockIClient)(nil).FlagExists), ctx, flag)
+}
+
+// Float mocks base method.
+func (m *MockIClient) Float(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) float64 {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Float", varargs...)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// Float indicates an expected call of Float.
+func (mr *MockIClientMockRecorder) Float(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Float", reflect.TypeOf((*MockIClient)(nil).Float), varargs...)
+}
+
+// FloatValue mocks base method.
+func (m *MockIClient) FloatValue(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (float64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValue", varargs...)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValue indicates an expected call of FloatValue.
+func (mr *MockIClientMockRecorder) FloatValue(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValue", reflect.TypeOf((*MockIClient)(nil).FloatValue), varargs...)
+}
+
+// FloatValueDetails mocks base method.
+func (m *MockIClient) FloatValueDetails(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (FloatEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValueDetails", varargs...)
+	ret0, _ := ret[0].(FloatEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValueDetails indicates an expected call of FloatValueDetails.
+func (mr *MockIClientMockRecorder) FloatValueDetails(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValueDetails", reflect.TypeOf((*MockIClient)(nil).FloatValueDetails), varargs...)
+}
+
+// FloatValueDetailsFunc mocks base method.
+func (m *MockIClient) FloatValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) float64, evalCtx EvaluationContext, options ...Option) (FloatEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValueDetailsFunc", varargs...)
+	ret0, _ := ret[0].(FloatEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValueDetailsFunc indicates an expected call of FloatValueDetailsFunc.
+func (mr *MockIClientMockRecorder) FloatValueDetailsFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValueDetailsFunc", reflect.TypeOf((*MockIClient)(nil).FloatValueDetailsFunc), varargs...)
+}
+
+// FloatValueForceVariant mocks base method.
+func (m *MockIClient) FloatValueForceVariant(ctx context.Context, flag, variant string, evalCtx EvaluationContext, options ...Option) (float64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, variant, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValueForceVariant", varargs...)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValueForceVariant indicates an expected call of FloatValueForceVariant.
+func (mr *MockIClientMockRecorder) FloatValueForceVariant(ctx, flag, variant, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, variant, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValueForceVariant", reflect.TypeOf((*MockIClient)(nil).FloatValueForceVariant), varargs...)
+}
+
+// FloatValueFunc mocks base method.
+func (m *MockIClient) FloatValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) float64, evalCtx EvaluationContext, options ...Option) (float64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValueFunc", varargs...)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValueFunc indicates an expected call of FloatValueFunc.
+func (mr *MockIClientMockRecorder) FloatValueFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValueFunc", reflect.TypeOf((*MockIClient)(nil).FloatValueFunc), varargs...)
+}
+
+// FloatValuePreflattened mocks base method.
+func (m *MockIClient) FloatValuePreflattened(ctx context.Context, flag string, defaultValue float64, flatCtx FlattenedContext, options ...Option) (float64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, flatCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValuePreflattened", varargs...)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValuePreflattened indicates an expected call of FloatValuePreflattened.
+func (mr *MockIClientMockRecorder) FloatValuePreflattened(ctx, flag, defaultValue, flatCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, flatCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValuePreflattened", reflect.TypeOf((*MockIClient)(nil).FloatValuePreflattened), varargs...)
+}
+
+// FloatValueWithProvider mocks base method.
+func (m *MockIClient) FloatValueWithProvider(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (float64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValueWithProvider", varargs...)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValueWithProvider indicates an expected call of FloatValueWithProvider.
+func (mr *MockIClientMockRecorder) FloatValueWithProvider(ctx, flag, defaultValue, evalCtx, provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValueWithProvider", reflect.TypeOf((*MockIClient)(nil).FloatValueWithProvider), varargs...)
+}
+
+// FloatValueWithTimeout mocks base method.
+func (m *MockIClient) FloatValueWithTimeout(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (float64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, timeout}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FloatValueWithTimeout", varargs...)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FloatValueWithTimeout indicates an expected call of FloatValueWithTimeout.
+func (mr *MockIClientMockRecorder) FloatValueWithTimeout(ctx, flag, defaultValue, evalCtx, timeout interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, timeout}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatValueWithTimeout", reflect.TypeOf((*MockIClient)(nil).FloatValueWithTimeout), varargs...)
+}
+
+// Hooks mocks base method.
+func (m *MockIClient) Hooks() []Hook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hooks")
+	ret0, _ := ret[0].([]Hook)
+	return ret0
+}
+
+// Hooks indicates an expected call of Hooks.
+func (mr *MockIClientMockRecorder) Hooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hooks", reflect.TypeOf((*MockIClient)(nil).Hooks))
+}
+
+// Int mocks base method.
+func (m *MockIClient) Int(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) int64 {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Int", varargs...)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// Int indicates an expected call of Int.
+func (mr *MockIClientMockRecorder) Int(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Int", reflect.TypeOf((*MockIClient)(nil).Int), varargs...)
+}
+
+// IntValue mocks base method.
+func (m *MockIClient) IntValue(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValue", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValue indicates an expected call of IntValue.
+func (mr *MockIClientMockRecorder) IntValue(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValue", reflect.TypeOf((*MockIClient)(nil).IntValue), varargs...)
+}
+
+// IntValueDetails mocks base method.
+func (m *MockIClient) IntValueDetails(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) (IntEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValueDetails", varargs...)
+	ret0, _ := ret[0].(IntEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValueDetails indicates an expected call of IntValueDetails.
+func (mr *MockIClientMockRecorder) IntValueDetails(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValueDetails", reflect.TypeOf((*MockIClient)(nil).IntValueDetails), varargs...)
+}
+
+// IntValueDetailsFunc mocks base method.
+func (m *MockIClient) IntValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) int64, evalCtx EvaluationContext, options ...Option) (IntEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValueDetailsFunc", varargs...)
+	ret0, _ := ret[0].(IntEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValueDetailsFunc indicates an expected call of IntValueDetailsFunc.
+func (mr *MockIClientMockRecorder) IntValueDetailsFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValueDetailsFunc", reflect.TypeOf((*MockIClient)(nil).IntValueDetailsFunc), varargs...)
+}
+
+// IntValueForceVariant mocks base method.
+func (m *MockIClient) IntValueForceVariant(ctx context.Context, flag, variant string, evalCtx EvaluationContext, options ...Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, variant, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValueForceVariant", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValueForceVariant indicates an expected call of IntValueForceVariant.
+func (mr *MockIClientMockRecorder) IntValueForceVariant(ctx, flag, variant, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, variant, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValueForceVariant", reflect.TypeOf((*MockIClient)(nil).IntValueForceVariant), varargs...)
+}
+
+// IntValueFunc mocks base method.
+func (m *MockIClient) IntValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) int64, evalCtx EvaluationContext, options ...Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValueFunc", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValueFunc indicates an expected call of IntValueFunc.
+func (mr *MockIClientMockRecorder) IntValueFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValueFunc", reflect.TypeOf((*MockIClient)(nil).IntValueFunc), varargs...)
+}
+
+// IntValuePreflattened mocks base method.
+func (m *MockIClient) IntValuePreflattened(ctx context.Context, flag string, defaultValue int64, flatCtx FlattenedContext, options ...Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, flatCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValuePreflattened", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValuePreflattened indicates an expected call of IntValuePreflattened.
+func (mr *MockIClientMockRecorder) IntValuePreflattened(ctx, flag, defaultValue, flatCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, flatCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValuePreflattened", reflect.TypeOf((*MockIClient)(nil).IntValuePreflattened), varargs...)
+}
+
+// IntValueWithProvider mocks base method.
+func (m *MockIClient) IntValueWithProvider(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValueWithProvider", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValueWithProvider indicates an expected call of IntValueWithProvider.
+func (mr *MockIClientMockRecorder) IntValueWithProvider(ctx, flag, defaultValue, evalCtx, provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValueWithProvider", reflect.TypeOf((*MockIClient)(nil).IntValueWithProvider), varargs...)
+}
+
+// IntValueWithTimeout mocks base method.
+func (m *MockIClient) IntValueWithTimeout(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, timeout}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IntValueWithTimeout", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntValueWithTimeout indicates an expected call of IntValueWithTimeout.
+func (mr *MockIClientMockRecorder) IntValueWithTimeout(ctx, flag, defaultValue, evalCtx, timeout interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, timeout}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntValueWithTimeout", reflect.TypeOf((*MockIClient)(nil).IntValueWithTimeout), varargs...)
+}
+
+// ListFlags mocks base method.
+func (m *MockIClient) ListFlags(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlags", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlags indicates an expected call of ListFlags.
+func (mr *MockIClientMockRecorder) ListFlags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlags", reflect.TypeOf((*MockIClient)(nil).ListFlags), ctx)
+}
+
+// Metadata mocks base method.
+func (m *MockIClient) Metadata() ClientMetadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metadata")
+	ret0, _ := ret[0].(ClientMetadata)
+	return ret0
+}
+
+// Metadata indicates an expected call of Metadata.
+func (mr *MockIClientMockRecorder) Metadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockIClient)(nil).Metadata))
+}
+
+// Object mocks base method.
+func (m *MockIClient) Object(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) interface{} {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Object", varargs...)
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// Object indicates an expected call of Object.
+func (mr *MockIClientMockRecorder) Object(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Object", reflect.TypeOf((*MockIClient)(nil).Object), varargs...)
+}
+
+// ObjectValue mocks base method.
+func (m *MockIClient) ObjectValue(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
@@ -512,6 +1280,180 @@ func (mr *MockIClientMockRecorder) ObjectValueDetails(ctx, flag, defaultValue, e
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueDetails", reflect.TypeOf((*MockIClient)(nil).ObjectValueDetails), varargs...)
 }
 
+// ObjectValueDetailsFunc mocks base method.
+func (m *MockIClient) ObjectValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) interface{}, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValueDetailsFunc", varargs...)
+	ret0, _ := ret[0].(InterfaceEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValueDetailsFunc indicates an expected call of ObjectValueDetailsFunc.
+func (mr *MockIClientMockRecorder) ObjectValueDetailsFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueDetailsFunc", reflect.TypeOf((*MockIClient)(nil).ObjectValueDetailsFunc), varargs...)
+}
+
+// ObjectValueForceVariant mocks base method.
+func (m *MockIClient) ObjectValueForceVariant(ctx context.Context, flag, variant string, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, variant, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValueForceVariant", varargs...)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValueForceVariant indicates an expected call of ObjectValueForceVariant.
+func (mr *MockIClientMockRecorder) ObjectValueForceVariant(ctx, flag, variant, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, variant, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueForceVariant", reflect.TypeOf((*MockIClient)(nil).ObjectValueForceVariant), varargs...)
+}
+
+// ObjectValueFunc mocks base method.
+func (m *MockIClient) ObjectValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) interface{}, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValueFunc", varargs...)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValueFunc indicates an expected call of ObjectValueFunc.
+func (mr *MockIClientMockRecorder) ObjectValueFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueFunc", reflect.TypeOf((*MockIClient)(nil).ObjectValueFunc), varargs...)
+}
+
+// ObjectValueInto mocks base method.
+func (m *MockIClient) ObjectValueInto(ctx context.Context, flag string, target interface{}, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, target, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValueInto", varargs...)
+	ret0, _ := ret[0].(InterfaceEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValueInto indicates an expected call of ObjectValueInto.
+func (mr *MockIClientMockRecorder) ObjectValueInto(ctx, flag, target, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, target, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueInto", reflect.TypeOf((*MockIClient)(nil).ObjectValueInto), varargs...)
+}
+
+// ObjectValuePreflattened mocks base method.
+func (m *MockIClient) ObjectValuePreflattened(ctx context.Context, flag string, defaultValue interface{}, flatCtx FlattenedContext, options ...Option) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, flatCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValuePreflattened", varargs...)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValuePreflattened indicates an expected call of ObjectValuePreflattened.
+func (mr *MockIClientMockRecorder) ObjectValuePreflattened(ctx, flag, defaultValue, flatCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, flatCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValuePreflattened", reflect.TypeOf((*MockIClient)(nil).ObjectValuePreflattened), varargs...)
+}
+
+// ObjectValueWeightedDefault mocks base method.
+func (m *MockIClient) ObjectValueWeightedDefault(ctx context.Context, flag string, fallbacks []WeightedValue, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, fallbacks, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValueWeightedDefault", varargs...)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValueWeightedDefault indicates an expected call of ObjectValueWeightedDefault.
+func (mr *MockIClientMockRecorder) ObjectValueWeightedDefault(ctx, flag, fallbacks, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, fallbacks, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueWeightedDefault", reflect.TypeOf((*MockIClient)(nil).ObjectValueWeightedDefault), varargs...)
+}
+
+// ObjectValueWithProvider mocks base method.
+func (m *MockIClient) ObjectValueWithProvider(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValueWithProvider", varargs...)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValueWithProvider indicates an expected call of ObjectValueWithProvider.
+func (mr *MockIClientMockRecorder) ObjectValueWithProvider(ctx, flag, defaultValue, evalCtx, provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueWithProvider", reflect.TypeOf((*MockIClient)(nil).ObjectValueWithProvider), varargs...)
+}
+
+// ObjectValueWithTimeout mocks base method.
+func (m *MockIClient) ObjectValueWithTimeout(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, timeout}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectValueWithTimeout", varargs...)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectValueWithTimeout indicates an expected call of ObjectValueWithTimeout.
+func (mr *MockIClientMockRecorder) ObjectValueWithTimeout(ctx, flag, defaultValue, evalCtx, timeout interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, timeout}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectValueWithTimeout", reflect.TypeOf((*MockIClient)(nil).ObjectValueWithTimeout), varargs...)
+}
+
+// ProviderStatus mocks base method.
+func (m *MockIClient) ProviderStatus() State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderStatus")
+	ret0, _ := ret[0].(State)
+	return ret0
+}
+
+// ProviderStatus indicates an expected call of ProviderStatus.
+func (mr *MockIClientMockRecorder) ProviderStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderStatus", reflect.TypeOf((*MockIClient)(nil).ProviderStatus))
+}
+
 // RemoveHandler mocks base method.
 func (m *MockIClient) RemoveHandler(eventType EventType, callback EventCallback) {
 	m.ctrl.T.Helper()
@@ -524,6 +1466,20 @@ func (mr *MockIClientMockRecorder) RemoveHandler(eventType, callback interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHandler", reflect.TypeOf((*MockIClient)(nil).RemoveHandler), eventType, callback)
 }
 
+// RemoveHook mocks base method.
+func (m *MockIClient) RemoveHook(hook Hook) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHook", hook)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RemoveHook indicates an expected call of RemoveHook.
+func (mr *MockIClientMockRecorder) RemoveHook(hook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHook", reflect.TypeOf((*MockIClient)(nil).RemoveHook), hook)
+}
+
 // SetEvaluationContext mocks base method.
 func (m *MockIClient) SetEvaluationContext(evalCtx EvaluationContext) {
 	m.ctrl.T.Helper()
@@ -544,69 +1500,189 @@ func (m *MockIClient) State() State {
 	return ret0
 }
 
-// State indicates an expected call of State.
-func (mr *MockIClientMockRecorder) State() *gomock.Call {
+// State indicates an expected call of State.
+func (mr *MockIClientMockRecorder) State() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockIClient)(nil).State))
+}
+
+// String mocks base method.
+func (m *MockIClient) String(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, options ...Option) string {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "String", varargs...)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// String indicates an expected call of String.
+func (mr *MockIClientMockRecorder) String(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockIClient)(nil).String), varargs...)
+}
+
+// StringValue mocks base method.
+func (m *MockIClient) StringValue(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, options ...Option) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StringValue", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StringValue indicates an expected call of StringValue.
+func (mr *MockIClientMockRecorder) StringValue(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValue", reflect.TypeOf((*MockIClient)(nil).StringValue), varargs...)
+}
+
+// StringValueDetails mocks base method.
+func (m *MockIClient) StringValueDetails(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, options ...Option) (StringEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StringValueDetails", varargs...)
+	ret0, _ := ret[0].(StringEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StringValueDetails indicates an expected call of StringValueDetails.
+func (mr *MockIClientMockRecorder) StringValueDetails(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValueDetails", reflect.TypeOf((*MockIClient)(nil).StringValueDetails), varargs...)
+}
+
+// StringValueDetailsFunc mocks base method.
+func (m *MockIClient) StringValueDetailsFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) string, evalCtx EvaluationContext, options ...Option) (StringEvaluationDetails, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StringValueDetailsFunc", varargs...)
+	ret0, _ := ret[0].(StringEvaluationDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StringValueDetailsFunc indicates an expected call of StringValueDetailsFunc.
+func (mr *MockIClientMockRecorder) StringValueDetailsFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValueDetailsFunc", reflect.TypeOf((*MockIClient)(nil).StringValueDetailsFunc), varargs...)
+}
+
+// StringValueForceVariant mocks base method.
+func (m *MockIClient) StringValueForceVariant(ctx context.Context, flag, variant string, evalCtx EvaluationContext, options ...Option) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, variant, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StringValueForceVariant", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StringValueForceVariant indicates an expected call of StringValueForceVariant.
+func (mr *MockIClientMockRecorder) StringValueForceVariant(ctx, flag, variant, evalCtx interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, flag, variant, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValueForceVariant", reflect.TypeOf((*MockIClient)(nil).StringValueForceVariant), varargs...)
+}
+
+// StringValueFunc mocks base method.
+func (m *MockIClient) StringValueFunc(ctx context.Context, flag string, defaultFn func(EvaluationContext) string, evalCtx EvaluationContext, options ...Option) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, flag, defaultFn, evalCtx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StringValueFunc", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StringValueFunc indicates an expected call of StringValueFunc.
+func (mr *MockIClientMockRecorder) StringValueFunc(ctx, flag, defaultFn, evalCtx interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockIClient)(nil).State))
+	varargs := append([]interface{}{ctx, flag, defaultFn, evalCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValueFunc", reflect.TypeOf((*MockIClient)(nil).StringValueFunc), varargs...)
 }
 
-// String mocks base method.
-func (m *MockIClient) String(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, options ...Option) string {
+// StringValuePreflattened mocks base method.
+func (m *MockIClient) StringValuePreflattened(ctx context.Context, flag, defaultValue string, flatCtx FlattenedContext, options ...Option) (string, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultValue, flatCtx}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "String", varargs...)
+	ret := m.ctrl.Call(m, "StringValuePreflattened", varargs...)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// String indicates an expected call of String.
-func (mr *MockIClientMockRecorder) String(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// StringValuePreflattened indicates an expected call of StringValuePreflattened.
+func (mr *MockIClientMockRecorder) StringValuePreflattened(ctx, flag, defaultValue, flatCtx interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockIClient)(nil).String), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultValue, flatCtx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValuePreflattened", reflect.TypeOf((*MockIClient)(nil).StringValuePreflattened), varargs...)
 }
 
-// StringValue mocks base method.
-func (m *MockIClient) StringValue(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, options ...Option) (string, error) {
+// StringValueWithProvider mocks base method.
+func (m *MockIClient) StringValueWithProvider(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, provider FeatureProvider, options ...Option) (string, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, provider}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "StringValue", varargs...)
+	ret := m.ctrl.Call(m, "StringValueWithProvider", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// StringValue indicates an expected call of StringValue.
-func (mr *MockIClientMockRecorder) StringValue(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// StringValueWithProvider indicates an expected call of StringValueWithProvider.
+func (mr *MockIClientMockRecorder) StringValueWithProvider(ctx, flag, defaultValue, evalCtx, provider interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValue", reflect.TypeOf((*MockIClient)(nil).StringValue), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValueWithProvider", reflect.TypeOf((*MockIClient)(nil).StringValueWithProvider), varargs...)
 }
 
-// StringValueDetails mocks base method.
-func (m *MockIClient) StringValueDetails(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, options ...Option) (StringEvaluationDetails, error) {
+// StringValueWithTimeout mocks base method.
+func (m *MockIClient) StringValueWithTimeout(ctx context.Context, flag, defaultValue string, evalCtx EvaluationContext, timeout time.Duration, options ...Option) (string, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, flag, defaultValue, evalCtx}
+	varargs := []interface{}{ctx, flag, defaultValue, evalCtx, timeout}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "StringValueDetails", varargs...)
-	ret0, _ := ret[0].(StringEvaluationDetails)
+	ret := m.ctrl.Call(m, "StringValueWithTimeout", varargs...)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// StringValueDetails indicates an expected call of StringValueDetails.
-func (mr *MockIClientMockRecorder) StringValueDetails(ctx, flag, defaultValue, evalCtx interface{}, options ...interface{}) *gomock.Call {
+// StringValueWithTimeout indicates an expected call of StringValueWithTimeout.
+func (mr *MockIClientMockRecorder) StringValueWithTimeout(ctx, flag, defaultValue, evalCtx, timeout interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx}, options...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValueDetails", reflect.TypeOf((*MockIClient)(nil).StringValueDetails), varargs...)
+	varargs := append([]interface{}{ctx, flag, defaultValue, evalCtx, timeout}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StringValueWithTimeout", reflect.TypeOf((*MockIClient)(nil).StringValueWithTimeout), varargs...)
 }
 
 // Track mocks base method.
@@ -621,6 +1697,20 @@ func (mr *MockIClientMockRecorder) Track(ctx, trackingEventName, evalCtx, detail
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockIClient)(nil).Track), ctx, trackingEventName, evalCtx, details)
 }
 
+// TrackAsync mocks base method.
+func (m *MockIClient) TrackAsync(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackAsync", ctx, trackingEventName, evalCtx, details)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TrackAsync indicates an expected call of TrackAsync.
+func (mr *MockIClientMockRecorder) TrackAsync(ctx, trackingEventName, evalCtx, details interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackAsync", reflect.TypeOf((*MockIClient)(nil).TrackAsync), ctx, trackingEventName, evalCtx, details)
+}
+
 // MockIEventing is a mock of IEventing interface.
 type MockIEventing struct {
 	ctrl     *gomock.Controller
@@ -645,9 +1735,11 @@ func (m *MockIEventing) EXPECT() *MockIEventingMockRecorder {
 }
 
 // AddHandler mocks base method.
-func (m *MockIEventing) AddHandler(eventType EventType, callback EventCallback) {
+func (m *MockIEventing) AddHandler(eventType EventType, callback EventCallback) func() {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret := m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret0, _ := ret[0].(func())
+	return ret0
 }
 
 // AddHandler indicates an expected call of AddHandler.
@@ -703,6 +1795,20 @@ func (mr *MockITrackingMockRecorder) Track(ctx, trackingEventName, evalCtx, deta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockITracking)(nil).Track), ctx, trackingEventName, evalCtx, details)
 }
 
+// TrackAsync mocks base method.
+func (m *MockITracking) TrackAsync(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackAsync", ctx, trackingEventName, evalCtx, details)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TrackAsync indicates an expected call of TrackAsync.
+func (mr *MockITrackingMockRecorder) TrackAsync(ctx, trackingEventName, evalCtx, details interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackAsync", reflect.TypeOf((*MockITracking)(nil).TrackAsync), ctx, trackingEventName, evalCtx, details)
+}
+
 // MockevaluationImpl is a mock of evaluationImpl interface.
 type MockevaluationImpl struct {
 	ctrl     *gomock.Controller
@@ -726,10 +1832,24 @@ func (m *MockevaluationImpl) EXPECT() *MockevaluationImplMockRecorder {
 	return m.recorder
 }
 
+// AddContextEnricher mocks base method.
+func (m *MockevaluationImpl) AddContextEnricher(enricher ContextEnricher) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddContextEnricher", enricher)
+}
+
+// AddContextEnricher indicates an expected call of AddContextEnricher.
+func (mr *MockevaluationImplMockRecorder) AddContextEnricher(enricher interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContextEnricher", reflect.TypeOf((*MockevaluationImpl)(nil).AddContextEnricher), enricher)
+}
+
 // AddHandler mocks base method.
-func (m *MockevaluationImpl) AddHandler(eventType EventType, callback EventCallback) {
+func (m *MockevaluationImpl) AddHandler(eventType EventType, callback EventCallback) func() {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret := m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret0, _ := ret[0].(func())
+	return ret0
 }
 
 // AddHandler indicates an expected call of AddHandler.
@@ -738,6 +1858,18 @@ func (mr *MockevaluationImplMockRecorder) AddHandler(eventType, callback interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHandler", reflect.TypeOf((*MockevaluationImpl)(nil).AddHandler), eventType, callback)
 }
 
+// AddHookSet mocks base method.
+func (m *MockevaluationImpl) AddHookSet(set HookSet) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddHookSet", set)
+}
+
+// AddHookSet indicates an expected call of AddHookSet.
+func (mr *MockevaluationImplMockRecorder) AddHookSet(set interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHookSet", reflect.TypeOf((*MockevaluationImpl)(nil).AddHookSet), set)
+}
+
 // AddHooks mocks base method.
 func (m *MockevaluationImpl) AddHooks(hooks ...Hook) {
 	m.ctrl.T.Helper()
@@ -754,14 +1886,39 @@ func (mr *MockevaluationImplMockRecorder) AddHooks(hooks ...interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHooks", reflect.TypeOf((*MockevaluationImpl)(nil).AddHooks), hooks...)
 }
 
+// AddNamedHandler mocks base method.
+func (m *MockevaluationImpl) AddNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddNamedHandler", domain, eventType, callback)
+}
+
+// AddNamedHandler indicates an expected call of AddNamedHandler.
+func (mr *MockevaluationImplMockRecorder) AddNamedHandler(domain, eventType, callback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNamedHandler", reflect.TypeOf((*MockevaluationImpl)(nil).AddNamedHandler), domain, eventType, callback)
+}
+
+// ClearHooks mocks base method.
+func (m *MockevaluationImpl) ClearHooks() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearHooks")
+}
+
+// ClearHooks indicates an expected call of ClearHooks.
+func (mr *MockevaluationImplMockRecorder) ClearHooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearHooks", reflect.TypeOf((*MockevaluationImpl)(nil).ClearHooks))
+}
+
 // ForEvaluation mocks base method.
-func (m *MockevaluationImpl) ForEvaluation(clientName string) (FeatureProvider, []Hook, EvaluationContext) {
+func (m *MockevaluationImpl) ForEvaluation(clientName string) (FeatureProvider, []Hook, EvaluationContext, []ContextEnricher) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ForEvaluation", clientName)
 	ret0, _ := ret[0].(FeatureProvider)
 	ret1, _ := ret[1].([]Hook)
 	ret2, _ := ret[2].(EvaluationContext)
-	return ret0, ret1, ret2
+	ret3, _ := ret[3].([]ContextEnricher)
+	return ret0, ret1, ret2, ret3
 }
 
 // ForEvaluation indicates an expected call of ForEvaluation.
@@ -868,6 +2025,62 @@ func (mr *MockevaluationImplMockRecorder) GetProviderMetadata() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderMetadata", reflect.TypeOf((*MockevaluationImpl)(nil).GetProviderMetadata))
 }
 
+// Hooks mocks base method.
+func (m *MockevaluationImpl) Hooks() []Hook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hooks")
+	ret0, _ := ret[0].([]Hook)
+	return ret0
+}
+
+// Hooks indicates an expected call of Hooks.
+func (mr *MockevaluationImplMockRecorder) Hooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hooks", reflect.TypeOf((*MockevaluationImpl)(nil).Hooks))
+}
+
+// ProviderMetadata mocks base method.
+func (m *MockevaluationImpl) ProviderMetadata(domain string) Metadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderMetadata", domain)
+	ret0, _ := ret[0].(Metadata)
+	return ret0
+}
+
+// ProviderMetadata indicates an expected call of ProviderMetadata.
+func (mr *MockevaluationImplMockRecorder) ProviderMetadata(domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderMetadata", reflect.TypeOf((*MockevaluationImpl)(nil).ProviderMetadata), domain)
+}
+
+// ProviderStatus mocks base method.
+func (m *MockevaluationImpl) ProviderStatus(domain string) State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderStatus", domain)
+	ret0, _ := ret[0].(State)
+	return ret0
+}
+
+// ProviderStatus indicates an expected call of ProviderStatus.
+func (mr *MockevaluationImplMockRecorder) ProviderStatus(domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderStatus", reflect.TypeOf((*MockevaluationImpl)(nil).ProviderStatus), domain)
+}
+
+// RefreshProvider mocks base method.
+func (m *MockevaluationImpl) RefreshProvider(ctx context.Context, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshProvider", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshProvider indicates an expected call of RefreshProvider.
+func (mr *MockevaluationImplMockRecorder) RefreshProvider(ctx, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshProvider", reflect.TypeOf((*MockevaluationImpl)(nil).RefreshProvider), ctx, domain)
+}
+
 // RemoveHandler mocks base method.
 func (m *MockevaluationImpl) RemoveHandler(eventType EventType, callback EventCallback) {
 	m.ctrl.T.Helper()
@@ -880,6 +2093,46 @@ func (mr *MockevaluationImplMockRecorder) RemoveHandler(eventType, callback inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHandler", reflect.TypeOf((*MockevaluationImpl)(nil).RemoveHandler), eventType, callback)
 }
 
+// RemoveHook mocks base method.
+func (m *MockevaluationImpl) RemoveHook(hook Hook) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHook", hook)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RemoveHook indicates an expected call of RemoveHook.
+func (mr *MockevaluationImplMockRecorder) RemoveHook(hook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHook", reflect.TypeOf((*MockevaluationImpl)(nil).RemoveHook), hook)
+}
+
+// RemoveHookSet mocks base method.
+func (m *MockevaluationImpl) RemoveHookSet(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHookSet", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RemoveHookSet indicates an expected call of RemoveHookSet.
+func (mr *MockevaluationImplMockRecorder) RemoveHookSet(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHookSet", reflect.TypeOf((*MockevaluationImpl)(nil).RemoveHookSet), name)
+}
+
+// RemoveNamedHandler mocks base method.
+func (m *MockevaluationImpl) RemoveNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveNamedHandler", domain, eventType, callback)
+}
+
+// RemoveNamedHandler indicates an expected call of RemoveNamedHandler.
+func (mr *MockevaluationImplMockRecorder) RemoveNamedHandler(domain, eventType, callback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNamedHandler", reflect.TypeOf((*MockevaluationImpl)(nil).RemoveNamedHandler), domain, eventType, callback)
+}
+
 // SetEvaluationContext mocks base method.
 func (m *MockevaluationImpl) SetEvaluationContext(apiCtx EvaluationContext) {
 	m.ctrl.T.Helper()
@@ -892,6 +2145,18 @@ func (mr *MockevaluationImplMockRecorder) SetEvaluationContext(apiCtx interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEvaluationContext", reflect.TypeOf((*MockevaluationImpl)(nil).SetEvaluationContext), apiCtx)
 }
 
+// SetHealthCheckConfig mocks base method.
+func (m *MockevaluationImpl) SetHealthCheckConfig(config HealthCheckConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHealthCheckConfig", config)
+}
+
+// SetHealthCheckConfig indicates an expected call of SetHealthCheckConfig.
+func (mr *MockevaluationImplMockRecorder) SetHealthCheckConfig(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthCheckConfig", reflect.TypeOf((*MockevaluationImpl)(nil).SetHealthCheckConfig), config)
+}
+
 // SetLogger mocks base method.
 func (m *MockevaluationImpl) SetLogger(l logr.Logger) {
 	m.ctrl.T.Helper()
@@ -918,6 +2183,25 @@ func (mr *MockevaluationImplMockRecorder) SetNamedProvider(clientName, provider,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNamedProvider", reflect.TypeOf((*MockevaluationImpl)(nil).SetNamedProvider), clientName, provider, async)
 }
 
+// SetNamedProviderWithOptions mocks base method.
+func (m *MockevaluationImpl) SetNamedProviderWithOptions(clientName string, provider FeatureProvider, async bool, options ...ProviderOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{clientName, provider, async}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetNamedProviderWithOptions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNamedProviderWithOptions indicates an expected call of SetNamedProviderWithOptions.
+func (mr *MockevaluationImplMockRecorder) SetNamedProviderWithOptions(clientName, provider, async interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{clientName, provider, async}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNamedProviderWithOptions", reflect.TypeOf((*MockevaluationImpl)(nil).SetNamedProviderWithOptions), varargs...)
+}
+
 // SetProvider mocks base method.
 func (m *MockevaluationImpl) SetProvider(provider FeatureProvider) error {
 	m.ctrl.T.Helper()
@@ -946,6 +2230,89 @@ func (mr *MockevaluationImplMockRecorder) SetProviderAndWait(provider interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderAndWait", reflect.TypeOf((*MockevaluationImpl)(nil).SetProviderAndWait), provider)
 }
 
+// SetProviderAndWaitContext mocks base method.
+func (m *MockevaluationImpl) SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProviderAndWaitContext", ctx, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProviderAndWaitContext indicates an expected call of SetProviderAndWaitContext.
+func (mr *MockevaluationImplMockRecorder) SetProviderAndWaitContext(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderAndWaitContext", reflect.TypeOf((*MockevaluationImpl)(nil).SetProviderAndWaitContext), ctx, provider)
+}
+
+// SetProviderAndWaitContextWithOptions mocks base method.
+func (m *MockevaluationImpl) SetProviderAndWaitContextWithOptions(ctx context.Context, provider FeatureProvider, options ...ProviderOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetProviderAndWaitContextWithOptions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProviderAndWaitContextWithOptions indicates an expected call of SetProviderAndWaitContextWithOptions.
+func (mr *MockevaluationImplMockRecorder) SetProviderAndWaitContextWithOptions(ctx, provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderAndWaitContextWithOptions", reflect.TypeOf((*MockevaluationImpl)(nil).SetProviderAndWaitContextWithOptions), varargs...)
+}
+
+// SetProviderAndWaitWithOptions mocks base method.
+func (m *MockevaluationImpl) SetProviderAndWaitWithOptions(provider FeatureProvider, options ...ProviderOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetProviderAndWaitWithOptions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProviderAndWaitWithOptions indicates an expected call of SetProviderAndWaitWithOptions.
+func (mr *MockevaluationImplMockRecorder) SetProviderAndWaitWithOptions(provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderAndWaitWithOptions", reflect.TypeOf((*MockevaluationImpl)(nil).SetProviderAndWaitWithOptions), varargs...)
+}
+
+// SetProviderWithOptions mocks base method.
+func (m *MockevaluationImpl) SetProviderWithOptions(provider FeatureProvider, options ...ProviderOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetProviderWithOptions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProviderWithOptions indicates an expected call of SetProviderWithOptions.
+func (mr *MockevaluationImplMockRecorder) SetProviderWithOptions(provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderWithOptions", reflect.TypeOf((*MockevaluationImpl)(nil).SetProviderWithOptions), varargs...)
+}
+
+// SetTrackingQueueConfig mocks base method.
+func (m *MockevaluationImpl) SetTrackingQueueConfig(config TrackingQueueConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrackingQueueConfig", config)
+}
+
+// SetTrackingQueueConfig indicates an expected call of SetTrackingQueueConfig.
+func (mr *MockevaluationImplMockRecorder) SetTrackingQueueConfig(config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrackingQueueConfig", reflect.TypeOf((*MockevaluationImpl)(nil).SetTrackingQueueConfig), config)
+}
+
 // Shutdown mocks base method.
 func (m *MockevaluationImpl) Shutdown() {
 	m.ctrl.T.Helper()
@@ -958,6 +2325,88 @@ func (mr *MockevaluationImplMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockevaluationImpl)(nil).Shutdown))
 }
 
+// ShutdownWithContext mocks base method.
+func (m *MockevaluationImpl) ShutdownWithContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutdownWithContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShutdownWithContext indicates an expected call of ShutdownWithContext.
+func (mr *MockevaluationImplMockRecorder) ShutdownWithContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutdownWithContext", reflect.TypeOf((*MockevaluationImpl)(nil).ShutdownWithContext), ctx)
+}
+
+// SwapNamedProvider mocks base method.
+func (m *MockevaluationImpl) SwapNamedProvider(clientName string, provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{clientName, provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SwapNamedProvider", varargs...)
+	ret0, _ := ret[0].(FeatureProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapNamedProvider indicates an expected call of SwapNamedProvider.
+func (mr *MockevaluationImplMockRecorder) SwapNamedProvider(clientName, provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{clientName, provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapNamedProvider", reflect.TypeOf((*MockevaluationImpl)(nil).SwapNamedProvider), varargs...)
+}
+
+// SwapProvider mocks base method.
+func (m *MockevaluationImpl) SwapProvider(provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{provider}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SwapProvider", varargs...)
+	ret0, _ := ret[0].(FeatureProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapProvider indicates an expected call of SwapProvider.
+func (mr *MockevaluationImplMockRecorder) SwapProvider(provider interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{provider}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapProvider", reflect.TypeOf((*MockevaluationImpl)(nil).SwapProvider), varargs...)
+}
+
+// WaitForReady mocks base method.
+func (m *MockevaluationImpl) WaitForReady(ctx context.Context, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForReady", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForReady indicates an expected call of WaitForReady.
+func (mr *MockevaluationImplMockRecorder) WaitForReady(ctx, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForReady", reflect.TypeOf((*MockevaluationImpl)(nil).WaitForReady), ctx, domain)
+}
+
+// enqueueTracking mocks base method.
+func (m *MockevaluationImpl) enqueueTracking(ctx context.Context, track func()) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "enqueueTracking", ctx, track)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// enqueueTracking indicates an expected call of enqueueTracking.
+func (mr *MockevaluationImplMockRecorder) enqueueTracking(ctx, track interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "enqueueTracking", reflect.TypeOf((*MockevaluationImpl)(nil).enqueueTracking), ctx, track)
+}
+
 // MockeventingImpl is a mock of eventingImpl interface.
 type MockeventingImpl struct {
 	ctrl     *gomock.Controller
@@ -994,9 +2443,11 @@ func (mr *MockeventingImplMockRecorder) AddClientHandler(clientName, t, c interf
 }
 
 // AddHandler mocks base method.
-func (m *MockeventingImpl) AddHandler(eventType EventType, callback EventCallback) {
+func (m *MockeventingImpl) AddHandler(eventType EventType, callback EventCallback) func() {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret := m.ctrl.Call(m, "AddHandler", eventType, callback)
+	ret0, _ := ret[0].(func())
+	return ret0
 }
 
 // AddHandler indicates an expected call of AddHandler.
@@ -1071,6 +2522,18 @@ func (mr *MockeventingImplMockRecorder) State(domain interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockeventingImpl)(nil).State), domain)
 }
 
+// emitProviderEvent mocks base method.
+func (m *MockeventingImpl) emitProviderEvent(provider FeatureProvider, event Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "emitProviderEvent", provider, event)
+}
+
+// emitProviderEvent indicates an expected call of emitProviderEvent.
+func (mr *MockeventingImplMockRecorder) emitProviderEvent(provider, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "emitProviderEvent", reflect.TypeOf((*MockeventingImpl)(nil).emitProviderEvent), provider, event)
+}
+
 // MockclientEvent is a mock of clientEvent interface.
 type MockclientEvent struct {
 	ctrl     *gomock.Controller
@@ -1131,3 +2594,15 @@ func (mr *MockclientEventMockRecorder) State(domain interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockclientEvent)(nil).State), domain)
 }
+
+// emitProviderEvent mocks base method.
+func (m *MockclientEvent) emitProviderEvent(provider FeatureProvider, event Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "emitProviderEvent", provider, event)
+}
+
+// emitProviderEvent indicates an expected call of emitProviderEvent.
+func (mr *MockclientEventMockRecorder) emitProviderEvent(provider, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "emitProviderEvent", reflect.TypeOf((*MockclientEvent)(nil).emitProviderEvent), provider, event)
+}
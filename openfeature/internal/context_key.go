@@ -8,3 +8,14 @@ type ContextKey struct{}
 // TransactionContext is the context key to use with golang.org/x/net/context's
 // WithValue function to associate an EvaluationContext value with a context.
 var TransactionContext ContextKey
+
+// CorrelationID is the context key to use with context's WithValue function to associate a correlation ID with a
+// context.
+var CorrelationID correlationIDKey
+
+type correlationIDKey struct{}
+
+// HookHints is the context key to use with context's WithValue function to associate hook hints with a context.
+var HookHints hookHintsKey
+
+type hookHintsKey struct{}
@@ -3,6 +3,9 @@ package memprovider
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"sync"
 
 	"github.com/open-feature/go-sdk/openfeature"
 )
@@ -12,15 +15,32 @@ const (
 	Disabled State = "DISABLED"
 )
 
+// interface guards to ensure that InMemoryProvider emits configuration changes, supports forcing variants, checking
+// the existence of flags and listing them
+var (
+	_ openfeature.EventHandler  = InMemoryProvider{}
+	_ openfeature.VariantForcer = InMemoryProvider{}
+	_ openfeature.FlagChecker   = InMemoryProvider{}
+	_ openfeature.FlagLister    = InMemoryProvider{}
+)
+
 type InMemoryProvider struct {
-	flags          map[string]InMemoryFlag
+	flags          *flagStore
 	trackingEvents map[string][]InMemoryEvent
+	events         chan openfeature.Event
+}
+
+// flagStore holds the flags of an InMemoryProvider, shared by all copies of the provider
+type flagStore struct {
+	mu    sync.RWMutex
+	flags map[string]InMemoryFlag
 }
 
 func NewInMemoryProvider(from map[string]InMemoryFlag) InMemoryProvider {
 	return InMemoryProvider{
-		flags:          from,
+		flags:          &flagStore{flags: from},
 		trackingEvents: map[string][]InMemoryEvent{},
+		events:         make(chan openfeature.Event, 5),
 	}
 }
 
@@ -128,6 +148,46 @@ func (i InMemoryProvider) ObjectEvaluation(ctx context.Context, flag string, def
 	}
 }
 
+// ForceVariant resolves the flag to the value of the given variant, skipping the flag's ContextEvaluator. Disabled
+// flags resolve to the default value as usual.
+func (i InMemoryProvider) ForceVariant(ctx context.Context, flag string, flagType openfeature.Type, variant string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	memoryFlag, details, ok := i.find(flag)
+	if !ok {
+		return openfeature.InterfaceResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: *details,
+		}
+	}
+
+	if memoryFlag.State == Disabled {
+		value, detail := memoryFlag.Resolve(defaultValue, evalCtx)
+		return openfeature.InterfaceResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+	}
+
+	value, ok := memoryFlag.Variants[variant]
+	if !ok {
+		return openfeature.InterfaceResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewGeneralResolutionError(fmt.Sprintf("variant %s of flag %s not found", variant, flag)),
+				Reason:          openfeature.ErrorReason,
+			},
+		}
+	}
+	// integer variants are declared as int, like for IntEvaluation
+	if v, ok := value.(int); ok && flagType == openfeature.Int {
+		value = int64(v)
+	}
+
+	return openfeature.InterfaceResolutionDetail{
+		Value: value,
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+			Reason:  openfeature.StaticReason,
+			Variant: variant,
+		},
+	}
+}
+
 func (i InMemoryProvider) Hooks() []openfeature.Hook {
 	return []openfeature.Hook{}
 }
@@ -140,8 +200,68 @@ func (i InMemoryProvider) Track(ctx context.Context, trackingEventName string, e
 	})
 }
 
+// FlagExists reports whether the provider holds the flag, disabled flags included
+func (i InMemoryProvider) FlagExists(ctx context.Context, flag string) (bool, error) {
+	_, _, ok := i.find(flag)
+	return ok, nil
+}
+
+// ListFlags returns the sorted keys of the provider's flags, disabled flags included
+func (i InMemoryProvider) ListFlags(ctx context.Context) ([]string, error) {
+	i.flags.mu.RLock()
+	keys := make([]string, 0, len(i.flags.flags))
+	for key := range i.flags.flags {
+		keys = append(keys, key)
+	}
+	i.flags.mu.RUnlock()
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// UpdateFlags replaces the flags of the provider, emitting a PROVIDER_CONFIGURATION_CHANGED event listing the added,
+// removed and changed flags. The event is dropped if the event channel is full, e.g. while the provider is not
+// registered.
+func (i InMemoryProvider) UpdateFlags(flags map[string]InMemoryFlag) {
+	i.flags.mu.Lock()
+	old := i.flags.flags
+	i.flags.flags = flags
+	i.flags.mu.Unlock()
+
+	var changes []string
+	for key, flag := range flags {
+		if oldFlag, ok := old[key]; !ok || !reflect.DeepEqual(oldFlag, flag) {
+			changes = append(changes, key)
+		}
+	}
+	for key := range old {
+		if _, ok := flags[key]; !ok {
+			changes = append(changes, key)
+		}
+	}
+	sort.Strings(changes)
+
+	select {
+	case i.events <- openfeature.Event{
+		ProviderName: i.Metadata().Name,
+		EventType:    openfeature.ProviderConfigChange,
+		ProviderEventDetails: openfeature.ProviderEventDetails{
+			Message:     "flags updated",
+			FlagChanges: changes,
+		},
+	}:
+	default:
+	}
+}
+
+// EventChannel returns the channel of the configuration changes resulting from UpdateFlags
+func (i InMemoryProvider) EventChannel() <-chan openfeature.Event {
+	return i.events
+}
+
 func (i InMemoryProvider) find(flag string) (*InMemoryFlag, *openfeature.ProviderResolutionDetail, bool) {
-	memoryFlag, ok := i.flags[flag]
+	i.flags.mu.RLock()
+	memoryFlag, ok := i.flags.flags[flag]
+	i.flags.mu.RUnlock()
 	if !ok {
 		return nil,
 			&openfeature.ProviderResolutionDetail{
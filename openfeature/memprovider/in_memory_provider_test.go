@@ -2,7 +2,9 @@ package memprovider
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/open-feature/go-sdk/openfeature"
 )
@@ -265,3 +267,205 @@ func TestInMemoryProvider_Track(t *testing.T) {
 	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{})
 	memoryProvider.Track(context.Background(), "example-event-name", openfeature.EvaluationContext{}, openfeature.TrackingEventDetails{})
 }
+
+func TestInMemoryProvider_VariantSelection(t *testing.T) {
+	// targeting rule selecting the variant from the targeting key, falling back to the default variant
+	var evaluator = func(callerFlag InMemoryFlag, evalCtx openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		if evalCtx[openfeature.TargetingKey] == "beta-user" {
+			return callerFlag.Variants["beta"], openfeature.ProviderResolutionDetail{
+				Reason:  openfeature.TargetingMatchReason,
+				Variant: "beta",
+			}
+		}
+		return callerFlag.Variants[callerFlag.DefaultVariant], openfeature.ProviderResolutionDetail{
+			Reason:  openfeature.DefaultReason,
+			Variant: callerFlag.DefaultVariant,
+		}
+	}
+
+	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{
+		"banner": {
+			Key:            "banner",
+			State:          Enabled,
+			DefaultVariant: "stable",
+			Variants: map[string]interface{}{
+				"stable": "welcome",
+				"beta":   "welcome, beta tester",
+			},
+			ContextEvaluator: &evaluator,
+		},
+	})
+
+	ctx := context.Background()
+
+	t.Run("targeting match", func(t *testing.T) {
+		evaluation := memoryProvider.StringEvaluation(ctx, "banner", "none", openfeature.FlattenedContext{
+			openfeature.TargetingKey: "beta-user",
+		})
+
+		if evaluation.Value != "welcome, beta tester" || evaluation.Variant != "beta" {
+			t.Errorf("incorect evaluation, expected beta variant, got %s (%s)", evaluation.Value, evaluation.Variant)
+		}
+		if evaluation.Reason != openfeature.TargetingMatchReason {
+			t.Errorf("expected reason %s, got %s", openfeature.TargetingMatchReason, evaluation.Reason)
+		}
+	})
+
+	t.Run("default variant", func(t *testing.T) {
+		evaluation := memoryProvider.StringEvaluation(ctx, "banner", "none", openfeature.FlattenedContext{
+			openfeature.TargetingKey: "someone-else",
+		})
+
+		if evaluation.Value != "welcome" || evaluation.Variant != "stable" {
+			t.Errorf("incorect evaluation, expected stable variant, got %s (%s)", evaluation.Value, evaluation.Variant)
+		}
+	})
+}
+
+func TestInMemoryProvider_UpdateFlags(t *testing.T) {
+	flag := func(variant string) InMemoryFlag {
+		return InMemoryFlag{
+			State:          Enabled,
+			DefaultVariant: variant,
+			Variants: map[string]interface{}{
+				"on":  true,
+				"off": false,
+			},
+		}
+	}
+
+	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{
+		"unchanged": flag("on"),
+		"changed":   flag("on"),
+		"removed":   flag("on"),
+	})
+
+	// copies of the provider, as registered with the API, share the flags
+	registered := memoryProvider
+
+	memoryProvider.UpdateFlags(map[string]InMemoryFlag{
+		"unchanged": flag("on"),
+		"changed":   flag("off"),
+		"added":     flag("on"),
+	})
+
+	t.Run("serves updated flags", func(t *testing.T) {
+		ctx := context.Background()
+
+		if evaluation := registered.BooleanEvaluation(ctx, "changed", true, nil); evaluation.Value != false {
+			t.Errorf("incorect evaluation, expected %t, got %t", false, evaluation.Value)
+		}
+		if evaluation := registered.BooleanEvaluation(ctx, "added", false, nil); evaluation.Value != true {
+			t.Errorf("incorect evaluation, expected %t, got %t", true, evaluation.Value)
+		}
+		if evaluation := registered.BooleanEvaluation(ctx, "removed", false, nil); evaluation.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode {
+			t.Errorf("expected flag not found, got %v", evaluation.ResolutionDetail().ErrorCode)
+		}
+	})
+
+	t.Run("emits configuration change", func(t *testing.T) {
+		select {
+		case event := <-registered.EventChannel():
+			if event.EventType != openfeature.ProviderConfigChange {
+				t.Errorf("expected event type %s, got %s", openfeature.ProviderConfigChange, event.EventType)
+			}
+			expected := []string{"added", "changed", "removed"}
+			if !reflect.DeepEqual(event.FlagChanges, expected) {
+				t.Errorf("expected flag changes %v, got %v", expected, event.FlagChanges)
+			}
+		case <-time.After(200 * time.Millisecond):
+			t.Fatal("expected a configuration change event")
+		}
+	})
+}
+
+func TestInMemoryProvider_ForceVariant(t *testing.T) {
+	// targeting rule always selecting the default variant
+	var evaluator = func(callerFlag InMemoryFlag, evalCtx openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		return callerFlag.Variants[callerFlag.DefaultVariant], openfeature.ProviderResolutionDetail{
+			Reason:  openfeature.DefaultReason,
+			Variant: callerFlag.DefaultVariant,
+		}
+	}
+
+	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{
+		"limit": {
+			Key:              "limit",
+			State:            Enabled,
+			DefaultVariant:   "low",
+			Variants:         map[string]interface{}{"low": 10, "high": 100},
+			ContextEvaluator: &evaluator,
+		},
+		"disabled": {
+			Key:            "disabled",
+			State:          Disabled,
+			DefaultVariant: "low",
+			Variants:       map[string]interface{}{"low": 10, "high": 100},
+		},
+	})
+
+	ctx := context.Background()
+
+	t.Run("forced variant", func(t *testing.T) {
+		resolution := memoryProvider.ForceVariant(ctx, "limit", openfeature.Int, "high", int64(0), openfeature.FlattenedContext{})
+
+		if resolution.Value != int64(100) || resolution.Variant != "high" {
+			t.Errorf("incorect evaluation, expected high variant, got %v (%s)", resolution.Value, resolution.Variant)
+		}
+		if resolution.Reason != openfeature.StaticReason {
+			t.Errorf("expected reason %s, got %s", openfeature.StaticReason, resolution.Reason)
+		}
+	})
+
+	t.Run("unknown variant", func(t *testing.T) {
+		resolution := memoryProvider.ForceVariant(ctx, "limit", openfeature.Int, "medium", int64(0), openfeature.FlattenedContext{})
+
+		if resolution.Error() == nil || resolution.Value != int64(0) {
+			t.Errorf("expected the default value with an error, got %+v", resolution)
+		}
+	})
+
+	t.Run("disabled flag", func(t *testing.T) {
+		resolution := memoryProvider.ForceVariant(ctx, "disabled", openfeature.Int, "high", int64(0), openfeature.FlattenedContext{})
+
+		if resolution.Reason != openfeature.DisabledReason || resolution.Value != int64(0) {
+			t.Errorf("expected the default value of the disabled flag, got %+v", resolution)
+		}
+	})
+}
+
+func TestInMemoryProvider_FlagExists(t *testing.T) {
+	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{
+		"enabled":  {Key: "enabled", State: Enabled, DefaultVariant: "on", Variants: map[string]interface{}{"on": true}},
+		"disabled": {Key: "disabled", State: Disabled, DefaultVariant: "on", Variants: map[string]interface{}{"on": true}},
+	})
+
+	for flag, expected := range map[string]bool{"enabled": true, "disabled": true, "missing": false} {
+		exists, err := memoryProvider.FlagExists(context.Background(), flag)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists != expected {
+			t.Errorf("expected flag %s to exist: %v, got %v", flag, expected, exists)
+		}
+	}
+}
+
+func TestInMemoryProvider_ListFlags(t *testing.T) {
+	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{
+		"web.checkout":    {Key: "web.checkout", State: Enabled, DefaultVariant: "on", Variants: map[string]interface{}{"on": true}},
+		"mobile.checkout": {Key: "mobile.checkout", State: Disabled, DefaultVariant: "on", Variants: map[string]interface{}{"on": true}},
+	})
+	err := openfeature.SetNamedProviderAndWait(t.Name(), memoryProvider)
+	if err != nil {
+		t.Fatalf("error setting provider: %v", err)
+	}
+
+	flags, err := openfeature.NewClient(t.Name()).ListFlags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"mobile.checkout", "web.checkout"}; !reflect.DeepEqual(flags, expected) {
+		t.Errorf("expected flags %v, got %v", expected, flags)
+	}
+}
@@ -0,0 +1,191 @@
+package multiprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// MultiProvider is a FeatureProvider resolving flags across multiple providers, combining their resolutions with a
+// pluggable ResolutionStrategy, e.g. to shadow compare the providers of different vendors during a migration.
+//
+// The MultiProvider initializes and shuts down all of its providers, and multiplexes their events. Hooks of the
+// member providers are not run.
+type MultiProvider struct {
+	providers []openfeature.FeatureProvider
+	strategy  ResolutionStrategy
+	events    chan openfeature.Event
+
+	mu       sync.Mutex
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// interface guards to ensure that MultiProvider initializes its providers and multiplexes their events
+var (
+	_ openfeature.StateHandler = (*MultiProvider)(nil)
+	_ openfeature.EventHandler = (*MultiProvider)(nil)
+)
+
+// NewMultiProvider creates a MultiProvider resolving flags with the given providers, in order, combined by the
+// strategy. A nil strategy defaults to UserPrecedenceStrategy.
+func NewMultiProvider(strategy ResolutionStrategy, providers ...openfeature.FeatureProvider) *MultiProvider {
+	if strategy == nil {
+		strategy = UserPrecedenceStrategy{}
+	}
+	return &MultiProvider{
+		providers: providers,
+		strategy:  strategy,
+		events:    make(chan openfeature.Event, 5),
+	}
+}
+
+// Metadata returns the MultiProvider's metadata, naming all of the member providers
+func (m *MultiProvider) Metadata() openfeature.Metadata {
+	names := make([]string, len(m.providers))
+	for i, provider := range m.providers {
+		names[i] = provider.Metadata().Name
+	}
+	return openfeature.Metadata{
+		Name: fmt.Sprintf("MultiProvider(%s)", strings.Join(names, ", ")),
+	}
+}
+
+func (m *MultiProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	value, detail := typed(m.resolve(ctx, flag, openfeature.Boolean, defaultValue, evalCtx), defaultValue)
+	return openfeature.BoolResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (m *MultiProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	value, detail := typed(m.resolve(ctx, flag, openfeature.String, defaultValue, evalCtx), defaultValue)
+	return openfeature.StringResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (m *MultiProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	value, detail := typed(m.resolve(ctx, flag, openfeature.Float, defaultValue, evalCtx), defaultValue)
+	return openfeature.FloatResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (m *MultiProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	value, detail := typed(m.resolve(ctx, flag, openfeature.Int, defaultValue, evalCtx), defaultValue)
+	return openfeature.IntResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+func (m *MultiProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return m.resolve(ctx, flag, openfeature.Object, defaultValue, evalCtx)
+}
+
+// Hooks returns no hooks, the hooks of the member providers are not run
+func (m *MultiProvider) Hooks() []openfeature.Hook {
+	return []openfeature.Hook{}
+}
+
+// Init initializes all member providers implementing openfeature.StateHandler, returning their joined errors, and
+// starts multiplexing the events of the member providers implementing openfeature.EventHandler
+func (m *MultiProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.shutdown == nil {
+		m.shutdown = make(chan struct{})
+		for _, provider := range m.providers {
+			if handler, ok := provider.(openfeature.EventHandler); ok {
+				m.wg.Add(1)
+				go m.forward(handler.EventChannel(), m.shutdown)
+			}
+		}
+	}
+
+	var errs []error
+	for _, provider := range m.providers {
+		if handler, ok := provider.(openfeature.StateHandler); ok {
+			if err := handler.Init(evaluationContext); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", provider.Metadata().Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down all member providers implementing openfeature.StateHandler and stops multiplexing their events
+func (m *MultiProvider) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.shutdown != nil {
+		close(m.shutdown)
+		m.wg.Wait()
+		m.shutdown = nil
+	}
+
+	for _, provider := range m.providers {
+		if handler, ok := provider.(openfeature.StateHandler); ok {
+			handler.Shutdown()
+		}
+	}
+}
+
+// EventChannel returns the channel the events of all member providers are multiplexed to
+func (m *MultiProvider) EventChannel() <-chan openfeature.Event {
+	return m.events
+}
+
+func (m *MultiProvider) forward(events <-chan openfeature.Event, shutdown <-chan struct{}) {
+	defer m.wg.Done()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			select {
+			case m.events <- event:
+			case <-shutdown:
+				return
+			}
+		case <-shutdown:
+			return
+		}
+	}
+}
+
+func (m *MultiProvider) resolve(
+	ctx context.Context, flag string, flagType openfeature.Type, defaultValue interface{}, evalCtx openfeature.FlattenedContext,
+) openfeature.InterfaceResolutionDetail {
+	if len(m.providers) == 0 {
+		return errorResolution(defaultValue, openfeature.NewGeneralResolutionError("multi provider has no providers"))
+	}
+	return m.strategy.Resolve(ctx, m.providers, Request{
+		Flag:             flag,
+		Type:             flagType,
+		DefaultValue:     defaultValue,
+		FlattenedContext: evalCtx,
+	})
+}
+
+// typed converts the resolution of a strategy to the flag's Go type, failing with a TYPE_MISMATCH error if the
+// resolved value is not of that type
+func typed[T any](resolution openfeature.InterfaceResolutionDetail, defaultValue T) (T, openfeature.ProviderResolutionDetail) {
+	if value, ok := resolution.Value.(T); ok {
+		return value, resolution.ProviderResolutionDetail
+	}
+	if resolution.Error() != nil {
+		return defaultValue, resolution.ProviderResolutionDetail
+	}
+	return defaultValue, errorResolution(defaultValue, openfeature.NewTypeMismatchResolutionError(
+		fmt.Sprintf("resolved value %v is not of type %T", resolution.Value, defaultValue))).ProviderResolutionDetail
+}
+
+func errorResolution(defaultValue interface{}, err openfeature.ResolutionError) openfeature.InterfaceResolutionDetail {
+	return openfeature.InterfaceResolutionDetail{
+		Value: defaultValue,
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+			ResolutionError: err,
+			Reason:          openfeature.ErrorReason,
+		},
+	}
+}
@@ -0,0 +1,222 @@
+package multiprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+// vendorProvider is an InMemoryProvider with its own name, resolving "boolFlag" to the given value and reason
+type vendorProvider struct {
+	memprovider.InMemoryProvider
+	name string
+}
+
+func newVendorProvider(name string, value bool, reason openfeature.Reason) vendorProvider {
+	evaluator := func(flag memprovider.InMemoryFlag, _ openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		return value, openfeature.ProviderResolutionDetail{Reason: reason}
+	}
+	return vendorProvider{
+		InMemoryProvider: memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+			"boolFlag": {
+				Key:              "boolFlag",
+				State:            memprovider.Enabled,
+				DefaultVariant:   "on",
+				Variants:         map[string]interface{}{"on": true, "off": false},
+				ContextEvaluator: &evaluator,
+			},
+		}),
+		name: name,
+	}
+}
+
+func (p vendorProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: p.name}
+}
+
+// statefulProvider is an InMemoryProvider recording its initialization & shutdown, and emitting events
+type statefulProvider struct {
+	memprovider.InMemoryProvider
+	initErr  error
+	shutdown *bool
+	events   chan openfeature.Event
+}
+
+func newStatefulProvider(initErr error) statefulProvider {
+	return statefulProvider{
+		InMemoryProvider: memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{}),
+		initErr:          initErr,
+		shutdown:         new(bool),
+		events:           make(chan openfeature.Event, 1),
+	}
+}
+
+func (p statefulProvider) Init(openfeature.EvaluationContext) error {
+	return p.initErr
+}
+
+func (p statefulProvider) Shutdown() {
+	*p.shutdown = true
+}
+
+func (p statefulProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+var missing = memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{})
+
+func TestFirstMatchStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the first targeting match", func(t *testing.T) {
+		multi := NewMultiProvider(FirstMatchStrategy{}, missing,
+			newVendorProvider("static", false, openfeature.StaticReason),
+			newVendorProvider("targeting", true, openfeature.TargetingMatchReason))
+
+		resolution := multi.BooleanEvaluation(ctx, "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.Value != true || resolution.Reason != openfeature.TargetingMatchReason {
+			t.Errorf("expected the targeting match, got %+v", resolution)
+		}
+	})
+
+	t.Run("falls back to the first successful resolution", func(t *testing.T) {
+		multi := NewMultiProvider(FirstMatchStrategy{}, missing,
+			newVendorProvider("static", true, openfeature.StaticReason),
+			newVendorProvider("default", false, openfeature.DefaultReason))
+
+		resolution := multi.BooleanEvaluation(ctx, "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.Value != true || resolution.Reason != openfeature.StaticReason {
+			t.Errorf("expected the first successful resolution, got %+v", resolution)
+		}
+	})
+
+	t.Run("returns the last error if all providers fail", func(t *testing.T) {
+		multi := NewMultiProvider(FirstMatchStrategy{}, missing, missing)
+
+		resolution := multi.BooleanEvaluation(ctx, "boolFlag", true, openfeature.FlattenedContext{})
+		if resolution.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode || resolution.Value != true {
+			t.Errorf("expected the default value with a FLAG_NOT_FOUND error, got %+v", resolution)
+		}
+	})
+}
+
+func TestUserPrecedenceStrategy(t *testing.T) {
+	multi := NewMultiProvider(UserPrecedenceStrategy{}, missing,
+		newVendorProvider("first", false, openfeature.StaticReason),
+		newVendorProvider("second", true, openfeature.TargetingMatchReason))
+
+	resolution := multi.BooleanEvaluation(context.Background(), "boolFlag", true, openfeature.FlattenedContext{})
+	if resolution.Error() != nil {
+		t.Fatalf("unexpected error: %v", resolution.Error())
+	}
+	if resolution.Value != false || resolution.Reason != openfeature.StaticReason {
+		t.Errorf("expected the resolution of the first successful provider, got %+v", resolution)
+	}
+}
+
+func TestComparisonStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the agreed resolution", func(t *testing.T) {
+		var mismatches int
+		multi := NewMultiProvider(ComparisonStrategy{OnMismatch: func(string, []ProviderResolution) { mismatches++ }},
+			newVendorProvider("old", true, openfeature.StaticReason),
+			newVendorProvider("new", true, openfeature.TargetingMatchReason))
+
+		resolution := multi.BooleanEvaluation(ctx, "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.Error() != nil {
+			t.Fatalf("unexpected error: %v", resolution.Error())
+		}
+		if resolution.Value != true || resolution.Reason != openfeature.StaticReason {
+			t.Errorf("expected the resolution of the first provider, got %+v", resolution)
+		}
+		if mismatches != 0 {
+			t.Errorf("expected no mismatch, got %d", mismatches)
+		}
+	})
+
+	t.Run("fails when providers disagree", func(t *testing.T) {
+		var reported []ProviderResolution
+		multi := NewMultiProvider(ComparisonStrategy{OnMismatch: func(flag string, resolutions []ProviderResolution) {
+			reported = resolutions
+		}},
+			newVendorProvider("old", true, openfeature.StaticReason),
+			newVendorProvider("new", false, openfeature.StaticReason))
+
+		resolution := multi.BooleanEvaluation(ctx, "boolFlag", true, openfeature.FlattenedContext{})
+		if resolution.ResolutionDetail().ErrorCode != openfeature.GeneralCode || resolution.Value != true {
+			t.Errorf("expected the default value with a GENERAL error, got %+v", resolution)
+		}
+		if len(reported) != 2 || reported[0].ProviderName != "old" || reported[1].Value != false {
+			t.Errorf("expected the mismatch to report both resolutions, got %+v", reported)
+		}
+	})
+
+	t.Run("returns the failure of a provider", func(t *testing.T) {
+		var mismatches int
+		multi := NewMultiProvider(ComparisonStrategy{OnMismatch: func(string, []ProviderResolution) { mismatches++ }},
+			newVendorProvider("old", true, openfeature.StaticReason), missing)
+
+		resolution := multi.BooleanEvaluation(ctx, "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode {
+			t.Errorf("expected a FLAG_NOT_FOUND error, got %+v", resolution)
+		}
+		if mismatches != 1 {
+			t.Errorf("expected the failure to be reported as a mismatch, got %d", mismatches)
+		}
+	})
+}
+
+func TestMultiProvider_TypeMismatch(t *testing.T) {
+	multi := NewMultiProvider(nil, newVendorProvider("bool", true, openfeature.StaticReason))
+
+	resolution := multi.StringEvaluation(context.Background(), "boolFlag", "default", openfeature.FlattenedContext{})
+	if resolution.ResolutionDetail().ErrorCode != openfeature.TypeMismatchCode || resolution.Value != "default" {
+		t.Errorf("expected the default value with a TYPE_MISMATCH error, got %+v", resolution)
+	}
+}
+
+func TestMultiProvider_Empty(t *testing.T) {
+	multi := NewMultiProvider(ComparisonStrategy{})
+
+	resolution := multi.IntEvaluation(context.Background(), "intFlag", 3, openfeature.FlattenedContext{})
+	if resolution.Error() == nil || resolution.Value != 3 {
+		t.Errorf("expected the default value with an error, got %+v", resolution)
+	}
+}
+
+func TestMultiProvider_Metadata(t *testing.T) {
+	multi := NewMultiProvider(nil, newVendorProvider("old", true, ""), newVendorProvider("new", true, ""))
+
+	if name := multi.Metadata().Name; name != "MultiProvider(old, new)" {
+		t.Errorf("expected the metadata to name the member providers, got %s", name)
+	}
+}
+
+func TestMultiProvider_Lifecycle(t *testing.T) {
+	healthy, failing := newStatefulProvider(nil), newStatefulProvider(errors.New("unreachable"))
+	multi := NewMultiProvider(nil, healthy, failing)
+
+	if err := multi.Init(openfeature.EvaluationContext{}); err == nil {
+		t.Error("expected the initialization error of the failing provider")
+	}
+
+	healthy.events <- openfeature.Event{EventType: openfeature.ProviderConfigChange}
+	select {
+	case event := <-multi.EventChannel():
+		if event.EventType != openfeature.ProviderConfigChange {
+			t.Errorf("expected the forwarded event, got %s", event.EventType)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the member provider's event to be forwarded")
+	}
+
+	multi.Shutdown()
+	if !*healthy.shutdown || !*failing.shutdown {
+		t.Error("expected all member providers to be shut down")
+	}
+}
@@ -0,0 +1,130 @@
+package multiprovider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// QuorumProvider is a MultiProvider resolving critical flags by quorum: all member providers resolve the flag
+// concurrently, and the value agreed on by the quorum is returned, see QuorumStrategy.
+type QuorumProvider struct {
+	*MultiProvider
+	quorum int
+}
+
+// interface guards to ensure that QuorumProvider initializes its providers and multiplexes their events
+var (
+	_ openfeature.StateHandler = (*QuorumProvider)(nil)
+	_ openfeature.EventHandler = (*QuorumProvider)(nil)
+)
+
+// NewQuorumProvider creates a QuorumProvider requiring the given number of providers to agree on the value of a flag.
+// A quorum of zero or less requires a majority of the providers. A positive timeout bounds the resolution of a flag,
+// providers which did not resolve the flag in time do not vote.
+func NewQuorumProvider(quorum int, timeout time.Duration, providers ...openfeature.FeatureProvider) *QuorumProvider {
+	if quorum <= 0 {
+		quorum = len(providers)/2 + 1
+	}
+	return &QuorumProvider{
+		MultiProvider: NewMultiProvider(QuorumStrategy{Quorum: quorum, Timeout: timeout}, providers...),
+		quorum:        quorum,
+	}
+}
+
+// Metadata returns the QuorumProvider's metadata, naming the quorum and all of the member providers
+func (q *QuorumProvider) Metadata() openfeature.Metadata {
+	names := make([]string, len(q.providers))
+	for i, provider := range q.providers {
+		names[i] = provider.Metadata().Name
+	}
+	return openfeature.Metadata{
+		Name: fmt.Sprintf("QuorumProvider(%d of %s)", q.quorum, strings.Join(names, ", ")),
+	}
+}
+
+// QuorumStrategy resolves the flag with all providers concurrently, returning as soon as a Quorum of providers
+// successfully resolved the flag to the same value, compared by deep equality, the resolution of the first of them to
+// respond. A Quorum of zero or less requires a majority of the providers.
+//
+// A positive Timeout bounds the resolution, providers which did not resolve the flag in time do not vote. The context
+// of the providers is cancelled once the resolution returns, whether or not a Timeout is set. If no value reaches the
+// quorum, a resolution with the default value and a GENERAL error is returned.
+type QuorumStrategy struct {
+	Quorum  int
+	Timeout time.Duration
+}
+
+func (s QuorumStrategy) Resolve(ctx context.Context, providers []openfeature.FeatureProvider, request Request) openfeature.InterfaceResolutionDetail {
+	quorum := s.Quorum
+	if quorum <= 0 {
+		quorum = len(providers)/2 + 1
+	}
+	// cancelled once the resolution returns, so that providers still resolving the flag after the quorum is reached do
+	// not keep running
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if s.Timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, s.Timeout)
+		defer cancelTimeout()
+	}
+
+	// buffered for all providers, so that providers resolving the flag after the quorum is reached do not block
+	results := make(chan ProviderResolution, len(providers))
+	for _, provider := range providers {
+		go func(provider openfeature.FeatureProvider) {
+			results <- ProviderResolution{
+				ProviderName:              provider.Metadata().Name,
+				InterfaceResolutionDetail: Evaluate(ctx, provider, request),
+			}
+		}(provider)
+	}
+
+	type vote struct {
+		resolution openfeature.InterfaceResolutionDetail
+		count      int
+	}
+	var votes []*vote
+	var outcomes []string
+	for pending := len(providers); pending > 0; pending-- {
+		var result ProviderResolution
+		select {
+		case result = <-results:
+		case <-ctx.Done():
+			outcomes = append(outcomes, fmt.Sprintf("%d providers did not respond: %v", pending, context.Cause(ctx)))
+			return noQuorum(request, quorum, outcomes)
+		}
+
+		if !succeeded(result.InterfaceResolutionDetail) {
+			outcomes = append(outcomes, fmt.Sprintf("%s: %v", result.ProviderName, result.Error()))
+			continue
+		}
+		var agreed *vote
+		for _, v := range votes {
+			if reflect.DeepEqual(v.resolution.Value, result.Value) {
+				agreed = v
+				break
+			}
+		}
+		if agreed == nil {
+			agreed = &vote{resolution: result.InterfaceResolutionDetail}
+			votes = append(votes, agreed)
+		}
+		agreed.count++
+		if agreed.count >= quorum {
+			return agreed.resolution
+		}
+		outcomes = append(outcomes, fmt.Sprintf("%s: %v", result.ProviderName, result.Value))
+	}
+	return noQuorum(request, quorum, outcomes)
+}
+
+func noQuorum(request Request, quorum int, outcomes []string) openfeature.InterfaceResolutionDetail {
+	return errorResolution(request.DefaultValue, openfeature.NewGeneralResolutionError(
+		fmt.Sprintf("no quorum of %d providers on flag %s (%s)", quorum, request.Flag, strings.Join(outcomes, ", "))))
+}
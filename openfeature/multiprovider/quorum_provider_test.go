@@ -0,0 +1,126 @@
+package multiprovider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+// hangingProvider is an InMemoryProvider resolving flags only once the context is done
+type hangingProvider struct {
+	memprovider.InMemoryProvider
+}
+
+func (p hangingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	<-ctx.Done()
+	return openfeature.BoolResolutionDetail{Value: true, ProviderResolutionDetail: openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason}}
+}
+
+// cancelRecordingProvider is a hangingProvider closing cancelled once the context is done
+type cancelRecordingProvider struct {
+	hangingProvider
+	cancelled chan struct{}
+}
+
+func (p cancelRecordingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	defer close(p.cancelled)
+	return p.hangingProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func TestQuorumProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the value agreed on by 2 of 3 providers", func(t *testing.T) {
+		quorum := NewQuorumProvider(0, time.Second,
+			newVendorProvider("a", true, openfeature.StaticReason),
+			newVendorProvider("b", false, openfeature.StaticReason),
+			newVendorProvider("c", true, openfeature.StaticReason))
+
+		resolution := quorum.BooleanEvaluation(ctx, "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.Error() != nil {
+			t.Fatalf("unexpected error: %v", resolution.Error())
+		}
+		if resolution.Value != true {
+			t.Errorf("expected the majority value, got %+v", resolution)
+		}
+	})
+
+	t.Run("fails without a majority", func(t *testing.T) {
+		quorum := NewQuorumProvider(0, time.Second,
+			newVendorProvider("a", true, openfeature.StaticReason),
+			newVendorProvider("b", false, openfeature.StaticReason),
+			missing)
+
+		resolution := quorum.BooleanEvaluation(ctx, "boolFlag", true, openfeature.FlattenedContext{})
+		if resolution.ResolutionDetail().ErrorCode != openfeature.GeneralCode || resolution.Value != true {
+			t.Errorf("expected the default value with a GENERAL error, got %+v", resolution)
+		}
+	})
+
+	t.Run("providers not responding in time do not vote", func(t *testing.T) {
+		quorum := NewQuorumProvider(2, 10*time.Millisecond,
+			newVendorProvider("a", true, openfeature.StaticReason),
+			hangingProvider{missing},
+			hangingProvider{missing})
+
+		resolution := quorum.BooleanEvaluation(ctx, "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.ResolutionDetail().ErrorCode != openfeature.GeneralCode {
+			t.Errorf("expected a GENERAL error, got %+v", resolution)
+		}
+		if !strings.Contains(resolution.ResolutionDetail().ErrorMessage, "did not respond") {
+			t.Errorf("expected the error to report the providers which did not respond, got %s", resolution.ResolutionDetail().ErrorMessage)
+		}
+	})
+
+	t.Run("providers still resolving once the quorum is reached are cancelled", func(t *testing.T) {
+		hanging := cancelRecordingProvider{hangingProvider{missing}, make(chan struct{})}
+		quorum := NewQuorumProvider(2, 0,
+			newVendorProvider("a", true, openfeature.StaticReason),
+			newVendorProvider("b", true, openfeature.StaticReason),
+			hanging)
+
+		resolution := quorum.BooleanEvaluation(ctx, "boolFlag", false, openfeature.FlattenedContext{})
+		if resolution.Error() != nil || resolution.Value != true {
+			t.Fatalf("expected the value agreed on, got %+v", resolution)
+		}
+		select {
+		case <-hanging.cancelled:
+		case <-time.After(time.Second):
+			t.Error("expected the context of the provider still resolving the flag to be cancelled")
+		}
+	})
+
+	t.Run("object values are compared deeply", func(t *testing.T) {
+		object := func(name string, value map[string]interface{}) openfeature.FeatureProvider {
+			evaluator := func(memprovider.InMemoryFlag, openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+				return value, openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason}
+			}
+			provider := newVendorProvider(name, true, openfeature.StaticReason)
+			provider.InMemoryProvider = memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+				"objectFlag": {Key: "objectFlag", State: memprovider.Enabled, ContextEvaluator: &evaluator},
+			})
+			return provider
+		}
+		quorum := NewQuorumProvider(2, 0,
+			object("a", map[string]interface{}{"color": "blue"}),
+			object("b", map[string]interface{}{"color": "blue"}))
+
+		resolution := quorum.ObjectEvaluation(ctx, "objectFlag", nil, openfeature.FlattenedContext{})
+		if resolution.Error() != nil {
+			t.Errorf("expected equal objects to agree, got %v", resolution.Error())
+		}
+	})
+}
+
+func TestQuorumProvider_Metadata(t *testing.T) {
+	quorum := NewQuorumProvider(0, 0, newVendorProvider("a", true, ""), newVendorProvider("b", true, ""),
+		newVendorProvider("c", true, ""))
+
+	if name := quorum.Metadata().Name; name != "QuorumProvider(2 of a, b, c)" {
+		t.Errorf("expected the metadata to name the quorum and member providers, got %s", name)
+	}
+}
@@ -0,0 +1,149 @@
+package multiprovider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// Request is a flag to resolve with the providers of a MultiProvider
+type Request struct {
+	Flag             string
+	Type             openfeature.Type
+	DefaultValue     interface{}
+	FlattenedContext openfeature.FlattenedContext
+}
+
+// ResolutionStrategy combines the resolutions of the providers of a MultiProvider into the resolution of a flag.
+// Strategies resolve the flag with the providers they need, in the order they need, using Evaluate. The providers
+// are never empty.
+type ResolutionStrategy interface {
+	Resolve(ctx context.Context, providers []openfeature.FeatureProvider, request Request) openfeature.InterfaceResolutionDetail
+}
+
+// ProviderResolution is the resolution of a flag by one of the providers of a MultiProvider
+type ProviderResolution struct {
+	ProviderName string
+	openfeature.InterfaceResolutionDetail
+}
+
+// Evaluate resolves the requested flag with the provider method matching the flag type
+func Evaluate(ctx context.Context, provider openfeature.FeatureProvider, request Request) openfeature.InterfaceResolutionDetail {
+	flag, evalCtx := request.Flag, request.FlattenedContext
+	var resolution openfeature.InterfaceResolutionDetail
+	switch request.Type {
+	case openfeature.Boolean:
+		res := provider.BooleanEvaluation(ctx, flag, request.DefaultValue.(bool), evalCtx)
+		resolution.Value, resolution.ProviderResolutionDetail = res.Value, res.ProviderResolutionDetail
+	case openfeature.String:
+		res := provider.StringEvaluation(ctx, flag, request.DefaultValue.(string), evalCtx)
+		resolution.Value, resolution.ProviderResolutionDetail = res.Value, res.ProviderResolutionDetail
+	case openfeature.Float:
+		res := provider.FloatEvaluation(ctx, flag, request.DefaultValue.(float64), evalCtx)
+		resolution.Value, resolution.ProviderResolutionDetail = res.Value, res.ProviderResolutionDetail
+	case openfeature.Int:
+		res := provider.IntEvaluation(ctx, flag, request.DefaultValue.(int64), evalCtx)
+		resolution.Value, resolution.ProviderResolutionDetail = res.Value, res.ProviderResolutionDetail
+	default:
+		resolution = provider.ObjectEvaluation(ctx, flag, request.DefaultValue, evalCtx)
+	}
+	return resolution
+}
+
+// FirstMatchStrategy resolves the flag with the providers in order, returning the first resolution with the
+// TARGETING_MATCH reason. If no provider matches, the first successful resolution is returned, or the last failed
+// resolution if all providers fail.
+type FirstMatchStrategy struct{}
+
+func (FirstMatchStrategy) Resolve(ctx context.Context, providers []openfeature.FeatureProvider, request Request) openfeature.InterfaceResolutionDetail {
+	var fallback *openfeature.InterfaceResolutionDetail
+	var resolution openfeature.InterfaceResolutionDetail
+	for _, provider := range providers {
+		resolution = Evaluate(ctx, provider, request)
+		if !succeeded(resolution) {
+			continue
+		}
+		if resolution.Reason == openfeature.TargetingMatchReason {
+			return resolution
+		}
+		if fallback == nil {
+			successful := resolution
+			fallback = &successful
+		}
+	}
+	if fallback != nil {
+		return *fallback
+	}
+	return resolution
+}
+
+// UserPrecedenceStrategy resolves the flag with the providers in the order given by the user, returning the first
+// successful resolution, or the last failed resolution if all providers fail.
+type UserPrecedenceStrategy struct{}
+
+func (UserPrecedenceStrategy) Resolve(ctx context.Context, providers []openfeature.FeatureProvider, request Request) openfeature.InterfaceResolutionDetail {
+	var resolution openfeature.InterfaceResolutionDetail
+	for _, provider := range providers {
+		resolution = Evaluate(ctx, provider, request)
+		if succeeded(resolution) {
+			return resolution
+		}
+	}
+	return resolution
+}
+
+// ComparisonStrategy resolves the flag with all providers, returning the resolution of the first provider if all of
+// them resolve the flag successfully to the same value.
+//
+// Otherwise OnMismatch, if set, is called with the resolutions of all providers, e.g. to report the disagreement, and
+// the first failed resolution is returned, or a resolution with the default value and a GENERAL error if all
+// providers succeeded but disagree on the value.
+type ComparisonStrategy struct {
+	OnMismatch func(flag string, resolutions []ProviderResolution)
+}
+
+func (s ComparisonStrategy) Resolve(ctx context.Context, providers []openfeature.FeatureProvider, request Request) openfeature.InterfaceResolutionDetail {
+	resolutions := make([]ProviderResolution, len(providers))
+	var failed *openfeature.InterfaceResolutionDetail
+	agree := true
+	for i, provider := range providers {
+		resolutions[i] = ProviderResolution{
+			ProviderName:              provider.Metadata().Name,
+			InterfaceResolutionDetail: Evaluate(ctx, provider, request),
+		}
+		if !succeeded(resolutions[i].InterfaceResolutionDetail) {
+			if failed == nil {
+				failed = &resolutions[i].InterfaceResolutionDetail
+			}
+			continue
+		}
+		if !reflect.DeepEqual(resolutions[i].Value, resolutions[0].Value) {
+			agree = false
+		}
+	}
+
+	if failed == nil && agree {
+		return resolutions[0].InterfaceResolutionDetail
+	}
+	if s.OnMismatch != nil {
+		s.OnMismatch(request.Flag, resolutions)
+	}
+	if failed != nil {
+		return *failed
+	}
+
+	values := make([]string, len(resolutions))
+	for i, resolution := range resolutions {
+		values[i] = fmt.Sprintf("%s: %v", resolution.ProviderName, resolution.Value)
+	}
+	return errorResolution(request.DefaultValue, openfeature.NewGeneralResolutionError(
+		fmt.Sprintf("providers disagree on flag %s (%s)", request.Flag, strings.Join(values, ", "))))
+}
+
+// succeeded reports whether the provider resolved the flag without error
+func succeeded(resolution openfeature.InterfaceResolutionDetail) bool {
+	return resolution.Error() == nil && resolution.Reason != openfeature.ErrorReason
+}
@@ -3,6 +3,9 @@ package openfeature
 import "context"
 
 // NoopProvider implements the FeatureProvider interface and provides functions for evaluating flags
+//
+// It is the default provider of the API until another one is set: evaluations of domains without a provider, while
+// no default provider is set, resolve to the caller's default value with the DEFAULT reason and no error.
 type NoopProvider struct {
 }
 
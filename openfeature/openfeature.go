@@ -1,6 +1,10 @@
 package openfeature
 
-import "github.com/go-logr/logr"
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
 
 // api is the global evaluationImpl implementation. This is a singleton and there can only be one instance.
 var api evaluationImpl
@@ -30,30 +34,86 @@ func SetProvider(provider FeatureProvider) error {
 	return api.SetProvider(provider)
 }
 
+// SetProviderWithOptions sets the default provider as SetProvider does, configuring the registration with the given
+// options, e.g. WithInitTimeout
+func SetProviderWithOptions(provider FeatureProvider, options ...ProviderOption) error {
+	return api.SetProviderWithOptions(provider, options...)
+}
+
 // SetProviderAndWait sets the default provider and waits for its initialization.
 // Returns an error if initialization cause error
 func SetProviderAndWait(provider FeatureProvider) error {
 	return api.SetProviderAndWait(provider)
 }
 
+// SetProviderAndWaitWithOptions sets the default provider and waits for its initialization as SetProviderAndWait
+// does, configuring the registration with the given options, e.g. WithInitTimeout
+func SetProviderAndWaitWithOptions(provider FeatureProvider, options ...ProviderOption) error {
+	return api.SetProviderAndWaitWithOptions(provider, options...)
+}
+
+// SetProviderAndWaitContext sets the default provider and waits for its initialization until the context is done.
+// Returns the context's error if initialization has not completed by then. The provider stays registered and its
+// initialization carries on in the background, the previous default provider not being restored.
+func SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error {
+	return api.SetProviderAndWaitContext(ctx, provider)
+}
+
+// SetProviderAndWaitContextWithOptions sets the default provider and waits for its initialization until the context
+// is done as SetProviderAndWaitContext does, configuring the registration with the given options
+func SetProviderAndWaitContextWithOptions(ctx context.Context, provider FeatureProvider, options ...ProviderOption) error {
+	return api.SetProviderAndWaitContextWithOptions(ctx, provider, options...)
+}
+
 // ProviderMetadata returns the default provider's metadata
 func ProviderMetadata() Metadata {
 	return api.GetProviderMetadata()
 }
 
 // SetNamedProvider sets a provider mapped to the given Client domain. Provider initialization is asynchronous and
-// status can be checked from provider status
+// status can be checked from provider status. The previous provider of the domain keeps serving evaluations until the
+// initialization completes, and stays in place if the initialization fails. A domain without a provider of its own is
+// in the NOT_READY state until the initialization completes.
 func SetNamedProvider(domain string, provider FeatureProvider) error {
 	return api.SetNamedProvider(domain, provider, true)
 }
 
+// SetNamedProviderWithOptions sets a provider mapped to the given Client domain as SetNamedProvider does, configuring
+// the registration with the given options, e.g. WithInitTimeout
+func SetNamedProviderWithOptions(domain string, provider FeatureProvider, options ...ProviderOption) error {
+	return api.SetNamedProviderWithOptions(domain, provider, true, options...)
+}
+
 // SetNamedProviderAndWait sets a provider mapped to the given Client domain and waits for its initialization.
-// Returns an error if initialization cause error
+// Returns an error if initialization cause error, in which case the previous provider of the domain stays in place
 func SetNamedProviderAndWait(domain string, provider FeatureProvider) error {
 	return api.SetNamedProvider(domain, provider, false)
 }
 
-// NamedProviderMetadata returns the named provider's Metadata
+// SetNamedProviderAndWaitWithOptions sets a provider mapped to the given Client domain and waits for its
+// initialization as SetNamedProviderAndWait does, configuring the registration with the given options
+func SetNamedProviderAndWaitWithOptions(domain string, provider FeatureProvider, options ...ProviderOption) error {
+	return api.SetNamedProviderWithOptions(domain, provider, false, options...)
+}
+
+// SwapProvider sets the default provider and waits for its initialization, returning the replaced default provider,
+// or nil if none was set. The replaced provider is not shut down: the caller is responsible for shutting it down, or
+// restoring it to roll back the swap. If the initialization fails, the previous default provider stays in place and
+// nil is returned along with the error.
+func SwapProvider(provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	return api.SwapProvider(provider, options...)
+}
+
+// SwapNamedProvider sets a provider mapped to the given Client domain and waits for its initialization, returning the
+// replaced provider of the domain, or nil if there was none. The replaced provider is not shut down: the caller is
+// responsible for shutting it down, or restoring it to roll back the swap. If the initialization fails, the previous
+// provider of the domain stays in place and nil is returned along with the error.
+func SwapNamedProvider(domain string, provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	return api.SwapNamedProvider(domain, provider, options...)
+}
+
+// NamedProviderMetadata returns the Metadata of the provider bound to the domain, or of the default provider for
+// domains without a bound provider, safe to call concurrently with provider registrations
 func NamedProviderMetadata(name string) Metadata {
 	return api.GetNamedProviderMetadata(name)
 }
@@ -73,9 +133,57 @@ func AddHooks(hooks ...Hook) {
 	api.AddHooks(hooks...)
 }
 
-// AddHandler allows to add API level event handler
-func AddHandler(eventType EventType, callback EventCallback) {
-	api.AddHandler(eventType, callback)
+// ClearHooks removes all API level hooks, e.g. to isolate tests. Evaluations in flight keep running the hooks they
+// started with.
+func ClearHooks() {
+	api.ClearHooks()
+}
+
+// RemoveHook removes all occurrences of the API level hook, reporting whether the hook was registered. Hooks are
+// compared by identity, hooks of non-comparable types cannot be removed. Evaluations in flight keep running the hooks
+// they started with.
+func RemoveHook(hook Hook) bool {
+	return api.RemoveHook(hook)
+}
+
+// AddHookSet appends the hooks of the set to the API level hooks, preserving their order, e.g. to register an
+// observability bundle of logging, metrics and tracing hooks as one unit. See HookSet for the hints of the set.
+func AddHookSet(set HookSet) {
+	api.AddHookSet(set)
+}
+
+// RemoveHookSet removes exactly the API level hooks registered with the named set, reporting whether the set was
+// registered. The same hooks registered individually or with another set are kept. Evaluations in flight keep running
+// the hooks they started with.
+func RemoveHookSet(name string) bool {
+	return api.RemoveHookSet(name)
+}
+
+// Hooks returns a copy of the API level hooks, e.g. to report the hooks in use
+func Hooks() []Hook {
+	return api.Hooks()
+}
+
+// AddContextEnricher appends to the context enrichers, which run in registration order before the hooks of every
+// evaluation. Evaluation contexts are thus merged in the order: API (global) < transaction < client < invocation <
+// enrichers < before hooks, later contexts overriding duplicate attributes.
+func AddContextEnricher(enricher ContextEnricher) {
+	api.AddContextEnricher(enricher)
+}
+
+// SetHealthCheckConfig configures the interval and jitter of the health polling of providers implementing
+// HealthChecker, applying to providers registered afterwards. Polling stops on Shutdown.
+func SetHealthCheckConfig(config HealthCheckConfig) {
+	api.SetHealthCheckConfig(config)
+}
+
+// AddHandler allows to add API level event handler. The handler is immediately invoked if the default provider is
+// already in a state matching the event type.
+//
+// The returned unsubscribe function removes the handler like RemoveHandler, e.g. deferred in setup code, and is a
+// no-op when called again.
+func AddHandler(eventType EventType, callback EventCallback) (unsubscribe func()) {
+	return api.AddHandler(eventType, callback)
 }
 
 // RemoveHandler allows to remove API level event handler
@@ -83,7 +191,58 @@ func RemoveHandler(eventType EventType, callback EventCallback) {
 	api.RemoveHandler(eventType, callback)
 }
 
-// Shutdown active providers
+// AddNamedHandler allows to add an event handler for the provider associated with the domain, falling back to the
+// default provider if the domain has no associated provider. Handlers receive EventDetails carrying the domain, and
+// are immediately invoked if the provider is already in a state matching the event type.
+// Handlers run concurrently, so API level and named handlers of an event run in no particular order.
+func AddNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	api.AddNamedHandler(domain, eventType, callback)
+}
+
+// RemoveNamedHandler allows to remove an event handler added with AddNamedHandler
+func RemoveNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	api.RemoveNamedHandler(domain, eventType, callback)
+}
+
+// ProviderStatus returns the state of the provider bound to the domain, reflecting the provider's latest lifecycle
+// event. The state of the default provider is returned for domains without a bound provider.
+func ProviderStatus(domain string) State {
+	return api.ProviderStatus(domain)
+}
+
+// WaitForReady blocks until the default provider is ready or the context is done, returning at once if the provider
+// already is ready. It returns an error if the provider reaches the ERROR or FATAL state first, wrapping
+// ProviderFatalError for the latter, or the context's error if the context is done first.
+func WaitForReady(ctx context.Context) error {
+	return api.WaitForReady(ctx, defaultDomain)
+}
+
+// WaitForNamedReady blocks until the provider bound to the domain, or the default provider for domains without a
+// bound provider, is ready or the context is done. See WaitForReady for the returned errors.
+func WaitForNamedReady(ctx context.Context, domain string) error {
+	return api.WaitForReady(ctx, domain)
+}
+
+// RefreshProvider reloads the configuration of the provider bound to the domain, or of the default provider for the
+// empty domain and domains without a bound provider, e.g. on a SIGHUP. A PROVIDER_CONFIGURATION_CHANGED event is
+// emitted on success. Providers not implementing Refresher fail with an error matching RefreshUnsupportedError.
+func RefreshProvider(ctx context.Context, domain string) error {
+	return api.RefreshProvider(ctx, domain)
+}
+
+// SetTrackingQueueConfig configures the worker pool, size and overflow behavior of the queue of Client.TrackAsync,
+// applying to the queue started by the next asynchronous tracking event. The queue is drained on Shutdown.
+func SetTrackingQueueConfig(config TrackingQueueConfig) {
+	api.SetTrackingQueueConfig(config)
+}
+
+// Shutdown active providers. A provider instance registered for several domains is shut down once.
 func Shutdown() {
 	api.Shutdown()
 }
+
+// ShutdownWithContext shuts down the active providers concurrently, returning early with an error naming the
+// providers which did not shut down in time if the context is done first
+func ShutdownWithContext(ctx context.Context) error {
+	return api.ShutdownWithContext(ctx)
+}
@@ -1,10 +1,14 @@
 package openfeature
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 )
@@ -15,28 +19,232 @@ type evaluationAPI struct {
 	namedProviders  map[string]FeatureProvider
 	hks             []Hook
 	apiCtx          EvaluationContext
+	enrichers       []ContextEnricher
 	eventExecutor   *eventExecutor
 	mu              sync.RWMutex
+	// pending holds the latest registration of each domain with a provider still initializing, so that a registration
+	// superseded while its provider initializes does not replace the latest provider
+	pending        map[string]providerRegistration
+	registrationID uint64
+	// prebound holds the registration of each domain without a provider of its own bound while its provider
+	// initializes, so that the domain is not served by the default provider in the meantime
+	prebound map[string]uint64
+	// lazyInits are the deferred initializations of the providers registered with WithLazyInit, by domain
+	lazyInits map[string]*lazyInitialization
+	// implicitDefaults are the domains of the providers registered with WithImplicitDefaultProvider
+	implicitDefaults map[string]bool
+	// tracking is the queue of Client.TrackAsync, started on the first asynchronous tracking event and drained on
+	// shutdown
+	trackingMu     sync.Mutex
+	trackingConfig TrackingQueueConfig
+	tracking       *trackingQueue
+}
+
+// providerRegistration is a provider registration for a domain, identified by a sequence number
+type providerRegistration struct {
+	id       uint64
+	provider FeatureProvider
+}
+
+// ProviderOption configures the registration of a provider, e.g. WithInitTimeout
+type ProviderOption func(*providerOptions)
+
+type providerOptions struct {
+	initTimeout time.Duration
+	// lazyInit defers the initialization of the provider to its first use, see WithLazyInit
+	lazyInit bool
+	// keepReplaced leaves the shutdown of the replaced provider to the caller, see SwapProvider
+	keepReplaced bool
+	// initialized is the initialization event of a provider initialized before its registration, see SwapProvider
+	initialized *Event
+	// implicitDefaultProvider serves the evaluations with the NoopProvider until the provider is ready, see
+	// WithImplicitDefaultProvider
+	implicitDefaultProvider bool
+}
+
+func newProviderOptions(options []ProviderOption) providerOptions {
+	var opts providerOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// WithInitTimeout bounds the initialization of the provider by the timeout. Providers implementing
+// ContextAwareStateHandler are given a context cancelled on timeout, to abort their initialization cleanly. Other
+// providers are no longer waited for past the timeout, their initialization carrying on in the background.
+// Initializations exceeding the timeout fail with an error matching context.DeadlineExceeded, and the provider emits a
+// PROVIDER_ERROR event. A zero or negative timeout disables the bound, which is the default.
+func WithInitTimeout(timeout time.Duration) ProviderOption {
+	return func(options *providerOptions) {
+		options.initTimeout = timeout
+	}
+}
+
+// WithLazyInit defers the initialization of the provider to its first use, e.g. the first evaluation of one of its
+// flags, rather than its registration, so that short-lived processes never evaluating a flag do not initialize the
+// provider at all. The registration completes at once, the provider being in the NOT_READY state until initialized.
+// The first evaluation initializes the provider, concurrent evaluations waiting for the initialization to complete, and
+// the initialization emits the PROVIDER_READY or PROVIDER_ERROR event as usual. A provider never initialized is not
+// shut down either, when replaced or on Shutdown. Providers not implementing StateHandler are ready immediately either
+// way.
+func WithLazyInit(lazy bool) ProviderOption {
+	return func(options *providerOptions) {
+		options.lazyInit = lazy
+	}
+}
+
+// WithImplicitDefaultProvider configures whether the evaluations of the domain of the provider are served by the
+// NoopProvider while the provider is in the NOT_READY state, which is disabled by default. Such evaluations, as those
+// of a domain whose first provider is still initializing, or of the domains without a provider of their own while the
+// default provider initializes, then resolve to the caller's default value with the DEFAULT reason instead of failing
+// with PROVIDER_NOT_READY.
+func WithImplicitDefaultProvider(enabled bool) ProviderOption {
+	return func(options *providerOptions) {
+		options.implicitDefaultProvider = enabled
+	}
+}
+
+// lazyInitialization is the deferred initialization of a provider registered with WithLazyInit, run at most once
+type lazyInitialization struct {
+	once sync.Once
+	init func(apiCtx EvaluationContext)
+	// initialized reports whether the initialization ran, set once it completed
+	initialized bool
+}
+
+// newLazyInitialization defers the initialization of the provider of the domain
+func (api *evaluationAPI) newLazyInitialization(domain string, provider FeatureProvider, options providerOptions) *lazyInitialization {
+	return &lazyInitialization{init: func(apiCtx EvaluationContext) {
+		event, err := initializer(provider, apiCtx, options)
+		api.eventExecutor.triggerInitEvent(domain, stateFromEventOrError(event, err), event, provider)
+	}}
+}
+
+// run initializes the provider unless it was initialized or cancelled already, waiting for an initialization in
+// progress
+func (l *lazyInitialization) run(apiCtx EvaluationContext) {
+	l.once.Do(func() {
+		l.init(apiCtx)
+		l.initialized = true
+	})
+}
+
+// cancel prevents the initialization of a provider no longer bound, waiting for an initialization in progress. It
+// reports whether the provider was initialized, hence needs to be shut down.
+func (l *lazyInitialization) cancel() bool {
+	l.once.Do(func() {})
+	return l.initialized
+}
+
+// setLazyInitialization sets the deferred initialization of the provider of the domain, nil if its provider is
+// initialized eagerly, returning the replaced one, if any. The API lock must be held, the replaced initialization is
+// to be cancelled without holding it, as cancelling waits for an initialization in progress.
+func (api *evaluationAPI) setLazyInitialization(domain string, lazy *lazyInitialization) *lazyInitialization {
+	replaced := api.lazyInits[domain]
+	delete(api.lazyInits, domain)
+	if lazy != nil {
+		api.lazyInits[domain] = lazy
+	}
+	return replaced
 }
 
 // newEvaluationAPI is a helper to generate an API. Used internally
 func newEvaluationAPI(eventExecutor *eventExecutor) *evaluationAPI {
 	return &evaluationAPI{
-		defaultProvider: NoopProvider{},
-		namedProviders:  map[string]FeatureProvider{},
-		hks:             []Hook{},
-		apiCtx:          EvaluationContext{},
-		mu:              sync.RWMutex{},
-		eventExecutor:   eventExecutor,
+		defaultProvider:  NoopProvider{},
+		namedProviders:   map[string]FeatureProvider{},
+		hks:              []Hook{},
+		apiCtx:           EvaluationContext{},
+		mu:               sync.RWMutex{},
+		pending:          map[string]providerRegistration{},
+		prebound:         map[string]uint64{},
+		lazyInits:        map[string]*lazyInitialization{},
+		implicitDefaults: map[string]bool{},
+		eventExecutor:    eventExecutor,
 	}
 }
 
 func (api *evaluationAPI) SetProvider(provider FeatureProvider) error {
-	return api.setProvider(provider, true)
+	return api.SetProviderWithOptions(provider)
+}
+
+// SetProviderWithOptions sets the default FeatureProvider as SetProvider, configuring the registration with the given
+// options.
+func (api *evaluationAPI) SetProviderWithOptions(provider FeatureProvider, options ...ProviderOption) error {
+	_, err := api.setProvider(provider, true, nil, newProviderOptions(options))
+	return err
 }
 
 func (api *evaluationAPI) SetProviderAndWait(provider FeatureProvider) error {
-	return api.setProvider(provider, false)
+	return api.SetProviderAndWaitWithOptions(provider)
+}
+
+// SetProviderAndWaitWithOptions sets the default FeatureProvider and waits for its initialization as
+// SetProviderAndWait, configuring the registration with the given options.
+func (api *evaluationAPI) SetProviderAndWaitWithOptions(provider FeatureProvider, options ...ProviderOption) error {
+	_, err := api.setProvider(provider, false, nil, newProviderOptions(options))
+	return err
+}
+
+// SwapProvider sets the default FeatureProvider once its initialization completes, returning the replaced default
+// provider, or nil if none was set. The replaced provider is not shut down: the caller is responsible for shutting it
+// down, or restoring it, e.g. to roll back a hot swap. If the initialization fails, the current default provider stays
+// in place and nil is returned along with the error.
+func (api *evaluationAPI) SwapProvider(provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	if provider == nil {
+		return nil, errors.New("default provider cannot be set to nil")
+	}
+
+	opts := newProviderOptions(options)
+	opts.keepReplaced = true
+	if _, ok := provider.(StateHandler); ok && !opts.lazyInit {
+		api.mu.RLock()
+		apiCtx := api.apiCtx
+		api.mu.RUnlock()
+
+		event, err := initializer(provider, apiCtx, opts)
+		if err != nil {
+			// the provider never served the default domain, only API level handlers are notified of its initialization
+			api.eventExecutor.triggerEvent(event, provider)
+			return nil, err
+		}
+		// the provider is bound without being initialized again
+		opts.initialized = &event
+	}
+
+	replaced, err := api.setProvider(provider, false, nil, opts)
+	if _, ok := replaced.(NoopProvider); ok {
+		replaced = nil
+	}
+	return replaced, err
+}
+
+// SetProviderAndWaitContext sets the default FeatureProvider and waits for its initialization until the context is
+// done, returning the context's error if the initialization has not completed by then.
+//
+// The provider is set as the default provider immediately, as with SetProvider, and the previous default provider is
+// shut down. Cancelling the context does not roll back the registration: the initialization carries on in the
+// background, and its outcome is conveyed by provider events and status.
+func (api *evaluationAPI) SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error {
+	return api.SetProviderAndWaitContextWithOptions(ctx, provider)
+}
+
+// SetProviderAndWaitContextWithOptions sets the default FeatureProvider and waits for its initialization until the
+// context is done as SetProviderAndWaitContext, configuring the registration with the given options.
+func (api *evaluationAPI) SetProviderAndWaitContextWithOptions(ctx context.Context, provider FeatureProvider, options ...ProviderOption) error {
+	initDone := make(chan error, 1)
+	_, err := api.setProvider(provider, true, initDone, newProviderOptions(options))
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err := <-initDone:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // GetProviderMetadata returns the default FeatureProvider's metadata
@@ -44,47 +252,155 @@ func (api *evaluationAPI) GetProviderMetadata() Metadata {
 	api.mu.RLock()
 	defer api.mu.RUnlock()
 
-	return api.defaultProvider.Metadata()
+	return providerMetadata(api.defaultProvider)
 }
 
 // SetNamedProvider sets a provider with client name. Returns an error if FeatureProvider is nil
+//
+// The provider replaces the current provider of the domain once its initialization completes, the current provider
+// serving evaluations in the meantime. If the initialization fails, the current provider of the domain, if any, stays
+// in place. Registrations superseded by a later registration of the same domain are discarded.
 func (api *evaluationAPI) SetNamedProvider(clientName string, provider FeatureProvider, async bool) error {
-	api.mu.Lock()
-	defer api.mu.Unlock()
+	return api.SetNamedProviderWithOptions(clientName, provider, async)
+}
+
+// SetNamedProviderWithOptions sets a provider with client name as SetNamedProvider, configuring the registration with
+// the given options.
+func (api *evaluationAPI) SetNamedProviderWithOptions(clientName string, provider FeatureProvider, async bool, options ...ProviderOption) error {
+	_, err := api.setNamedProvider(clientName, provider, async, newProviderOptions(options))
+	return err
+}
+
+// SwapNamedProvider sets the provider of the domain and waits for its initialization, as SetNamedProvider does
+// synchronously, returning the replaced provider of the domain, or nil if there was none. The replaced provider is not
+// shut down: the caller is responsible for shutting it down, or restoring it, e.g. to roll back a hot swap. If the
+// initialization fails while another provider is bound to the domain, that provider stays in place and nil is
+// returned along with the error.
+func (api *evaluationAPI) SwapNamedProvider(clientName string, provider FeatureProvider, options ...ProviderOption) (FeatureProvider, error) {
+	opts := newProviderOptions(options)
+	opts.keepReplaced = true
+	return api.setNamedProvider(clientName, provider, false, opts)
+}
 
+// setNamedProvider registers the provider of the domain, returning the provider it replaced, if any, once bound
+// synchronously
+func (api *evaluationAPI) setNamedProvider(clientName string, provider FeatureProvider, async bool, opts providerOptions) (FeatureProvider, error) {
 	if provider == nil {
-		return errors.New("provider cannot be set to nil")
+		return nil, errors.New("provider cannot be set to nil")
 	}
 
-	// Initialize new named provider and Shutdown the old one
-	// Provider update must be non-blocking, hence initialization & Shutdown happens concurrently
-	oldProvider := api.namedProviders[clientName]
-	api.namedProviders[clientName] = provider
+	if _, ok := provider.(StateHandler); !ok {
+		opts.lazyInit = false
+	}
 
-	err := api.initNewAndShutdownOld(clientName, provider, oldProvider, async)
-	if err != nil {
-		return err
+	api.mu.Lock()
+	api.registrationID++
+	registration := providerRegistration{id: api.registrationID, provider: provider}
+	api.pending[clientName] = registration
+	api.implicitDefaults[clientName] = opts.implicitDefaultProvider
+	apiCtx := api.apiCtx
+	if _, bound := api.namedProviders[clientName]; !bound && !opts.lazyInit {
+		if _, ok := provider.(StateHandler); ok {
+			// a domain without a provider of its own is not served by the default provider while the provider
+			// initializes, its evaluations failing with PROVIDER_NOT_READY instead
+			api.namedProviders[clientName] = provider
+			api.prebound[clientName] = registration.id
+			api.eventExecutor.setState(clientName, NotReadyState)
+		}
 	}
+	api.mu.Unlock()
 
-	err = api.eventExecutor.registerNamedEventingProvider(clientName, provider)
-	if err != nil {
-		return err
+	if opts.lazyInit {
+		// bound at once, initialized on first use
+		return api.bindNamedProvider(clientName, registration, Event{}, nil, opts)
 	}
 
-	return nil
+	// a provider without state handling capability is ready immediately, hence bound without waiting
+	if _, ok := provider.(StateHandler); async && ok {
+		go func() {
+			// for async initialization, error is conveyed as an event
+			event, err := initializer(provider, apiCtx, opts)
+			_, _ = api.bindNamedProvider(clientName, registration, event, err, opts)
+		}()
+		return nil, nil
+	}
+
+	event, err := initializer(provider, apiCtx, opts)
+	replaced, bindErr := api.bindNamedProvider(clientName, registration, event, err, opts)
+	if bindErr != nil {
+		return replaced, bindErr
+	}
+	return replaced, err
 }
 
-// GetNamedProviderMetadata returns the default FeatureProvider's metadata
-func (api *evaluationAPI) GetNamedProviderMetadata(name string) Metadata {
-	api.mu.RLock()
-	defer api.mu.RUnlock()
+// bindNamedProvider binds an initialized provider to the domain, unless the registration was superseded or the
+// initialization failed while another provider is bound to the domain, and shuts down the replaced provider unless kept
+// by the options. The replaced provider is returned, nil if the provider was not bound or replaced none.
+func (api *evaluationAPI) bindNamedProvider(
+	clientName string, registration providerRegistration, event Event, initErr error, options providerOptions,
+) (FeatureProvider, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
 
-	provider, ok := api.namedProviders[name]
-	if !ok {
-		return ProviderMetadata()
+	provider := registration.provider
+	superseded := api.pending[clientName].id != registration.id
+	if !superseded {
+		delete(api.pending, clientName)
+	}
+
+	oldProvider, bound := api.namedProviders[clientName]
+	// a provider bound while it initializes, as the domain had no provider of its own, is not the current provider of
+	// the domain: it is replaced even by a provider failing to initialize, and shut down once its own initialization
+	// completes
+	if preboundID, ok := api.prebound[clientName]; ok && (preboundID == registration.id || !superseded) {
+		delete(api.prebound, clientName)
+		oldProvider, bound = nil, false
+	}
+	if superseded || (initErr != nil && bound) {
+		// the provider never served the domain, only API level handlers are notified of its initialization
+		api.eventExecutor.triggerEvent(event, provider)
+		if superseded {
+			api.shutdownUnbound(provider, nil)
+			return nil, fmt.Errorf("registration of provider %s for domain %q was superseded",
+				provider.Metadata().Name, clientName)
+		}
+		return nil, nil
+	}
+
+	api.namedProviders[clientName] = provider
+	err := api.eventExecutor.registerNamedEventingProvider(clientName, provider)
+	var replacedInit *lazyInitialization
+	if options.lazyInit {
+		replacedInit = api.setLazyInitialization(clientName, api.newLazyInitialization(clientName, provider, options))
+		api.eventExecutor.setState(clientName, NotReadyState)
+	} else {
+		replacedInit = api.setLazyInitialization(clientName, nil)
+		api.eventExecutor.triggerInitEvent(clientName, stateFromEventOrError(event, initErr), event, provider)
+	}
+	if !options.keepReplaced {
+		api.shutdownUnbound(oldProvider, replacedInit)
 	}
 
-	return provider.Metadata()
+	return oldProvider, err
+}
+
+// GetNamedProviderMetadata returns the metadata of the FeatureProvider bound to the name, or of the default
+// FeatureProvider if none is bound
+func (api *evaluationAPI) GetNamedProviderMetadata(name string) Metadata {
+	return api.ProviderMetadata(name)
+}
+
+// ProviderMetadata returns the metadata of the provider currently registered for the domain, or of the default
+// provider for the empty domain and domains without a bound provider, which is the NoopProvider's if no provider was
+// set. The provider is looked up atomically, so that reads racing a provider swap see either provider.
+func (api *evaluationAPI) ProviderMetadata(domain string) Metadata {
+	api.mu.RLock()
+	provider, _ := api.lookupProvider(domain)
+	api.mu.RUnlock()
+	if provider == nil {
+		return Metadata{}
+	}
+	return providerMetadata(provider)
 }
 
 // GetNamedProviders returns named providers map.
@@ -112,6 +428,14 @@ func (api *evaluationAPI) SetEvaluationContext(apiCtx EvaluationContext) {
 	api.apiCtx = apiCtx
 }
 
+// AddContextEnricher appends to the context enrichers run before the hooks of every evaluation
+func (api *evaluationAPI) AddContextEnricher(enricher ContextEnricher) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.enrichers = append(api.enrichers, enricher)
+}
+
 // Deprecated
 func (api *evaluationAPI) SetLogger(l logr.Logger) {
 
@@ -124,16 +448,97 @@ func (api *evaluationAPI) AddHooks(hooks ...Hook) {
 	api.hks = append(api.hks, hooks...)
 }
 
+// ClearHooks removes all API level hooks
+func (api *evaluationAPI) ClearHooks() {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.hks = nil
+}
+
+// RemoveHook removes all occurrences of the API level hook, reporting whether the hook was registered
+func (api *evaluationAPI) RemoveHook(hook Hook) bool {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	var removed bool
+	api.hks, removed = removeHook(api.hks, hook)
+	return removed
+}
+
+// SetTrackingQueueConfig configures the queue of Client.TrackAsync. The configuration applies to the queue started by
+// the next asynchronous tracking event after the API is initialized or shut down.
+func (api *evaluationAPI) SetTrackingQueueConfig(config TrackingQueueConfig) {
+	api.trackingMu.Lock()
+	defer api.trackingMu.Unlock()
+
+	api.trackingConfig = config
+}
+
+// enqueueTracking queues the tracking job, starting the tracking queue if needed, and reports whether it was queued
+func (api *evaluationAPI) enqueueTracking(ctx context.Context, track func()) bool {
+	api.trackingMu.Lock()
+	if api.tracking == nil {
+		api.tracking = newTrackingQueue(api.trackingConfig)
+	}
+	queue := api.tracking
+	api.trackingMu.Unlock()
+
+	return queue.enqueue(ctx, track)
+}
+
+// drainTracking drains the tracking queue, if started, until the context is done
+func (api *evaluationAPI) drainTracking(ctx context.Context) error {
+	api.trackingMu.Lock()
+	queue := api.tracking
+	api.tracking = nil
+	api.trackingMu.Unlock()
+
+	if queue == nil {
+		return nil
+	}
+	return queue.drain(ctx)
+}
+
+// AddHookSet appends the hooks of the set to the API level hooks, in order
+func (api *evaluationAPI) AddHookSet(set HookSet) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.hks = append(api.hks, set.members()...)
+}
+
+// RemoveHookSet removes the API level hooks registered with the named set, reporting whether the set was registered
+func (api *evaluationAPI) RemoveHookSet(name string) bool {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	var removed bool
+	api.hks, removed = removeHookSet(api.hks, name)
+	return removed
+}
+
+// Hooks returns a copy of the API level hooks, including the hooks of the registered sets
+func (api *evaluationAPI) Hooks() []Hook {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+
+	return unwrapHooks(api.hks)
+}
+
+// GetHooks returns a copy of the API level hooks
+//
+// Deprecated: use Hooks
 func (api *evaluationAPI) GetHooks() []Hook {
 	api.mu.RLock()
 	defer api.mu.RUnlock()
 
-	return api.hks
+	return unwrapHooks(api.hks)
 }
 
-// AddHandler allows to add API level event handler
-func (api *evaluationAPI) AddHandler(eventType EventType, callback EventCallback) {
-	api.eventExecutor.AddHandler(eventType, callback)
+// AddHandler allows to add API level event handler, returning a function removing it
+func (api *evaluationAPI) AddHandler(eventType EventType, callback EventCallback) func() {
+	return api.eventExecutor.AddHandler(eventType, callback)
 }
 
 // RemoveHandler allows to remove API level event handler
@@ -141,37 +546,251 @@ func (api *evaluationAPI) RemoveHandler(eventType EventType, callback EventCallb
 	api.eventExecutor.RemoveHandler(eventType, callback)
 }
 
+// SetHealthCheckConfig configures the health polling of providers implementing HealthChecker. The configuration
+// applies to providers registered afterwards.
+func (api *evaluationAPI) SetHealthCheckConfig(config HealthCheckConfig) {
+	api.eventExecutor.setHealthCheckConfig(config)
+}
+
+// AddNamedHandler allows to add a handler for the events of the provider associated with the domain
+func (api *evaluationAPI) AddNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	api.eventExecutor.AddClientHandler(domain, eventType, callback)
+}
+
+// RemoveNamedHandler allows to remove a handler added with AddNamedHandler
+func (api *evaluationAPI) RemoveNamedHandler(domain string, eventType EventType, callback EventCallback) {
+	api.eventExecutor.RemoveClientHandler(domain, eventType, callback)
+}
+
+// ProviderStatus returns the state of the provider bound to the domain, reflecting the provider's latest lifecycle
+// event. The state of the default provider is returned for domains without a bound provider.
+func (api *evaluationAPI) ProviderStatus(domain string) State {
+	return api.eventExecutor.State(domain)
+}
+
+// WaitForReady blocks until the provider bound to the domain, or the default provider for domains without a bound
+// provider, is ready, returning at once if it already is. It returns an error if the provider reaches the ERROR or
+// FATAL state first, wrapping ProviderFatalError for the latter, or the context's error if the context is done first.
+func (api *evaluationAPI) WaitForReady(ctx context.Context, domain string) error {
+	result := make(chan error, 1)
+	report := func(err error) {
+		select {
+		case result <- err:
+		default:
+		}
+	}
+
+	ready := func(EventDetails) {
+		report(nil)
+	}
+	failed := func(details EventDetails) {
+		if api.eventExecutor.State(domain) == FatalState {
+			report(fmt.Errorf("provider %s: %w: %s", details.ProviderName, ProviderFatalError, details.Message))
+			return
+		}
+		report(fmt.Errorf("provider %s is in error state: %s", details.ProviderName, details.Message))
+	}
+
+	// handlers are invoked on registration if the provider is already in the matching state
+	api.eventExecutor.AddClientHandler(domain, ProviderReady, &ready)
+	defer api.eventExecutor.RemoveClientHandler(domain, ProviderReady, &ready)
+	api.eventExecutor.AddClientHandler(domain, ProviderError, &failed)
+	defer api.eventExecutor.RemoveClientHandler(domain, ProviderError, &failed)
+	// waiting for a provider registered with WithLazyInit is a use of it, initializing it in the background
+	go api.ForEvaluation(domain)
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RefreshProvider reloads the configuration of the provider bound to the domain, or of the default provider for
+// domains without a bound provider, emitting a PROVIDER_CONFIGURATION_CHANGED event on success. It returns an error
+// matching RefreshUnsupportedError if the provider does not implement Refresher, or the error of the refresh.
+func (api *evaluationAPI) RefreshProvider(ctx context.Context, domain string) error {
+	provider, _, _, _ := api.ForEvaluation(domain)
+	refresher, ok := provider.(Refresher)
+	if !ok {
+		return fmt.Errorf("provider %s: %w", provider.Metadata().Name, RefreshUnsupportedError)
+	}
+	if err := refresher.Refresh(ctx); err != nil {
+		return fmt.Errorf("refresh provider %s: %w", provider.Metadata().Name, err)
+	}
+
+	api.eventExecutor.emitProviderEvent(provider, Event{
+		ProviderName:         provider.Metadata().Name,
+		EventType:            ProviderConfigChange,
+		ProviderEventDetails: ProviderEventDetails{Message: "provider configuration refreshed"},
+	})
+	return nil
+}
+
+// Shutdown shuts down the active providers, each distinct provider instance exactly once even if it is bound to
+// several domains. Providers not implementing StateHandler are skipped.
 func (api *evaluationAPI) Shutdown() {
 	api.mu.Lock()
 	defer api.mu.Unlock()
 
-	v, ok := api.defaultProvider.(StateHandler)
-	if ok {
-		v.Shutdown()
-	}
+	api.eventExecutor.stopHealthChecks()
+	// queued tracking events are handed to the providers before they shut down
+	_ = api.drainTracking(context.Background())
 
-	for _, provider := range api.namedProviders {
-		v, ok = provider.(StateHandler)
-		if ok {
+	bound := api.boundProviders()
+	api.lazyInits = map[string]*lazyInitialization{}
+	for _, b := range bound {
+		if v, ok := b.ref.featureProvider.(StateHandler); ok && b.initialized() {
 			v.Shutdown()
 		}
 	}
 }
 
+// ShutdownWithContext shuts down the active providers concurrently, returning early if the context is done before all
+// providers are shut down. Queued tracking events of Client.TrackAsync are handed to the providers first. The returned
+// error joins an error for every provider which did not shut down in time, and for the tracking queue if it did not
+// drain in time.
+// Each distinct provider instance is shut down exactly once, even if it is bound to several domains. Providers not
+// implementing StateHandler are skipped.
+func (api *evaluationAPI) ShutdownWithContext(ctx context.Context) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.eventExecutor.stopHealthChecks()
+	// queued tracking events are handed to the providers before they shut down
+	var errs []error
+	if err := api.drainTracking(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	bound := api.boundProviders()
+	api.lazyInits = map[string]*lazyInitialization{}
+	pending := make([]chan struct{}, len(bound))
+	for i, b := range bound {
+		handler, ok := b.ref.featureProvider.(StateHandler)
+		if !ok {
+			continue
+		}
+
+		done := make(chan struct{})
+		pending[i] = done
+		go func(b boundProvider) {
+			defer close(done)
+			if b.initialized() {
+				handler.Shutdown()
+			}
+		}(b)
+	}
+
+	for i, done := range pending {
+		if done == nil {
+			continue
+		}
+		select {
+		case <-done:
+			continue
+		case <-ctx.Done():
+		}
+
+		// once the context is done, only report the providers which have not shut down yet
+		select {
+		case <-done:
+		default:
+			errs = append(errs, fmt.Errorf("provider %s of domain %s did not shut down in time: %w",
+				bound[i].ref.featureProvider.Metadata().Name, strings.Join(bound[i].domains, ", "), ctx.Err()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// boundProvider is a distinct provider instance bound to the API, along with the quoted domains it is bound to
+type boundProvider struct {
+	ref     providerReference
+	domains []string
+	// lazy are the deferred initializations of the domains the provider is registered for with WithLazyInit, eager
+	// whether it is registered for any domain without
+	lazy  []*lazyInitialization
+	eager bool
+}
+
+// initialized cancels the deferred initializations of the provider, reporting whether it was initialized, hence needs
+// to be shut down
+func (b boundProvider) initialized() bool {
+	initialized := b.eager
+	for _, lazy := range b.lazy {
+		if lazy.cancel() {
+			initialized = true
+		}
+	}
+	return initialized
+}
+
+// boundProviders returns each distinct provider instance bound to the API once, so that a provider registered for
+// several domains is shut down exactly once. Provider instances are told apart as by the event executor, see
+// providerReference.equals.
+func (api *evaluationAPI) boundProviders() []boundProvider {
+	var bound []boundProvider
+	bind := func(domain string, provider FeatureProvider) {
+		if provider == nil {
+			return
+		}
+		ref := newProviderRef(provider)
+		i := slices.IndexFunc(bound, func(b boundProvider) bool { return b.ref.equals(ref) })
+		if i < 0 {
+			bound = append(bound, boundProvider{ref: ref})
+			i = len(bound) - 1
+		}
+		bound[i].domains = append(bound[i].domains, strconv.Quote(domain))
+		if lazy := api.lazyInits[domain]; lazy != nil {
+			bound[i].lazy = append(bound[i].lazy, lazy)
+		} else {
+			bound[i].eager = true
+		}
+	}
+
+	bind(defaultDomain, api.defaultProvider)
+	domains := make([]string, 0, len(api.namedProviders))
+	for domain := range api.namedProviders {
+		domains = append(domains, domain)
+	}
+	slices.Sort(domains)
+	for _, domain := range domains {
+		bind(domain, api.namedProviders[domain])
+	}
+	return bound
+}
+
 // ForEvaluation is a helper to retrieve transaction scoped operators.
 // Returns the default FeatureProvider if no provider mapping exist for the given client name.
-func (api *evaluationAPI) ForEvaluation(clientName string) (FeatureProvider, []Hook, EvaluationContext) {
+// A provider registered with WithLazyInit is initialized first, if not initialized yet. The NoopProvider is returned in
+// place of a provider registered with WithImplicitDefaultProvider which is not ready.
+func (api *evaluationAPI) ForEvaluation(clientName string) (FeatureProvider, []Hook, EvaluationContext, []ContextEnricher) {
 	api.mu.RLock()
-	defer api.mu.RUnlock()
+	provider, domain := api.lookupProvider(clientName)
+	lazy := api.lazyInits[domain]
+	implicitDefault := api.implicitDefaults[domain]
+	hooks, apiCtx, enrichers := api.hks, api.apiCtx, api.enrichers
+	api.mu.RUnlock()
+
+	// a provider registered with WithLazyInit is initialized on first use, outside the API lock
+	if lazy != nil {
+		lazy.run(apiCtx)
+	}
+	if implicitDefault && api.eventExecutor.State(domain) == NotReadyState {
+		provider = NoopProvider{}
+	}
 
-	var provider FeatureProvider
+	return provider, hooks, apiCtx, enrichers
+}
 
-	provider = api.namedProviders[clientName]
-	if provider == nil {
-		provider = api.defaultProvider
+// lookupProvider returns the provider bound to the domain, or the default provider for domains without a bound
+// provider, along with the domain it is registered for. The API lock must be held.
+func (api *evaluationAPI) lookupProvider(clientName string) (FeatureProvider, string) {
+	if provider := api.namedProviders[clientName]; provider != nil {
+		return provider, clientName
 	}
-
-	return provider, api.hks, api.apiCtx
+	return api.defaultProvider, defaultDomain
 }
 
 // GetProvider returns the default FeatureProvider
@@ -182,72 +801,110 @@ func (api *evaluationAPI) GetProvider() FeatureProvider {
 	return api.defaultProvider
 }
 
-// SetProvider sets the default FeatureProvider of the evaluationAPI.
-// Returns an error if provider registration cause an error
-func (api *evaluationAPI) setProvider(provider FeatureProvider, async bool) error {
+// setProvider sets the default provider, returning the provider it replaced. The outcome of an async initialization is
+// sent to initDone, if not nil.
+func (api *evaluationAPI) setProvider(provider FeatureProvider, async bool, initDone chan<- error, options providerOptions) (FeatureProvider, error) {
 	api.mu.Lock()
 	defer api.mu.Unlock()
 
 	if provider == nil {
-		return errors.New("default provider cannot be set to nil")
+		return nil, errors.New("default provider cannot be set to nil")
+	}
+	if _, ok := provider.(StateHandler); !ok {
+		options.lazyInit = false
 	}
+	api.implicitDefaults[defaultDomain] = options.implicitDefaultProvider
 
 	oldProvider := api.defaultProvider
 	api.defaultProvider = provider
 
-	err := api.initNewAndShutdownOld("", provider, oldProvider, async)
+	err := api.initNewAndShutdownOld("", provider, oldProvider, async, initDone, options)
 	if err != nil {
-		return err
+		return oldProvider, err
 	}
 
 	err = api.eventExecutor.registerDefaultProvider(provider)
 	if err != nil {
-		return err
+		return oldProvider, err
 	}
 
-	return nil
+	return oldProvider, nil
 }
 
 // initNewAndShutdownOld is a helper to initialise new FeatureProvider and Shutdown the old FeatureProvider.
-func (api *evaluationAPI) initNewAndShutdownOld(clientName string, newProvider FeatureProvider, oldProvider FeatureProvider, async bool) error {
-	if async {
+func (api *evaluationAPI) initNewAndShutdownOld(clientName string, newProvider FeatureProvider, oldProvider FeatureProvider, async bool, initDone chan<- error, options providerOptions) error {
+	var replacedInit *lazyInitialization
+	if options.lazyInit {
+		// initialized on first use
+		replacedInit = api.setLazyInitialization(clientName, api.newLazyInitialization(clientName, newProvider, options))
+		api.eventExecutor.setState(clientName, NotReadyState)
+		if initDone != nil {
+			initDone <- nil
+		}
+	} else if async {
+		replacedInit = api.setLazyInitialization(clientName, nil)
 		go func(executor *eventExecutor, ctx EvaluationContext) {
 			// for async initialization, error is conveyed as an event
-			event, _ := initializer(newProvider, ctx)
-			executor.states.Store(clientName, stateFromEventOrError(event, nil))
-			executor.triggerEvent(event, newProvider)
+			event, err := initializer(newProvider, ctx, options)
+			executor.triggerInitEvent(clientName, stateFromEventOrError(event, nil), event, newProvider)
+			if initDone != nil {
+				initDone <- err
+			}
 		}(api.eventExecutor, api.apiCtx)
 	} else {
-		event, err := initializer(newProvider, api.apiCtx)
-		api.eventExecutor.states.Store(clientName, stateFromEventOrError(event, err))
-		api.eventExecutor.triggerEvent(event, newProvider)
+		replacedInit = api.setLazyInitialization(clientName, nil)
+		var event Event
+		var err error
+		if options.initialized != nil {
+			event = *options.initialized
+		} else {
+			event, err = initializer(newProvider, api.apiCtx, options)
+		}
+		api.eventExecutor.triggerInitEvent(clientName, stateFromEventOrError(event, err), event, newProvider)
 		if err != nil {
 			return err
 		}
 	}
 
-	v, ok := oldProvider.(StateHandler)
+	if !options.keepReplaced {
+		api.shutdownUnbound(oldProvider, replacedInit)
+	}
 
-	// oldProvider can be nil or without state handling capability
-	if oldProvider == nil || !ok {
-		return nil
+	return nil
+}
+
+// shutdownUnbound shuts down the provider if it is not bound to the API anymore, nor pending a binding. A provider
+// registered with WithLazyInit is shut down once its deferred initialization, lazy, is cancelled, and only if the
+// initialization ran.
+func (api *evaluationAPI) shutdownUnbound(provider FeatureProvider, lazy *lazyInitialization) {
+	v, ok := provider.(StateHandler)
+
+	// provider can be nil or without state handling capability
+	if provider == nil || !ok {
+		return
 	}
 
-	// check for multiple bindings
-	if oldProvider == api.defaultProvider || slices.Contains(mapValues(api.namedProviders), oldProvider) {
-		return nil
+	// check for multiple bindings, including pending ones
+	if provider == api.defaultProvider || slices.Contains(mapValues(api.namedProviders), provider) {
+		return
+	}
+	for _, pending := range api.pending {
+		if pending.provider == provider {
+			return
+		}
 	}
 
 	go func(forShutdown StateHandler) {
+		if lazy != nil && !lazy.cancel() {
+			return
+		}
 		forShutdown.Shutdown()
 	}(v)
-
-	return nil
 }
 
 // initializer is a helper to execute provider initialization and generate appropriate event for the initialization
 // It also returns an error if the initialization resulted in an error
-func initializer(provider FeatureProvider, apiCtx EvaluationContext) (Event, error) {
+func initializer(provider FeatureProvider, apiCtx EvaluationContext, options providerOptions) (Event, error) {
 	var event = Event{
 		ProviderName: provider.Metadata().Name,
 		EventType:    ProviderReady,
@@ -262,7 +919,7 @@ func initializer(provider FeatureProvider, apiCtx EvaluationContext) (Event, err
 		return event, nil
 	}
 
-	err := handler.Init(apiCtx)
+	err := initialize(handler, apiCtx, options.initTimeout)
 	if err != nil {
 		event.EventType = ProviderError
 		event.Message = fmt.Sprintf("Provider initialization error, %v", err)
@@ -278,6 +935,39 @@ func initializer(provider FeatureProvider, apiCtx EvaluationContext) (Event, err
 	return event, err
 }
 
+// initialize initializes the provider, bounding the initialization by the timeout if positive. Providers implementing
+// ContextAwareStateHandler are given a context cancelled on timeout. The initialization of other providers is not
+// waited for past the timeout, and carries on in the background.
+func initialize(handler StateHandler, apiCtx EvaluationContext, timeout time.Duration) error {
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = withTimeoutCause(ctx, timeout, context.DeadlineExceeded)
+	}
+	defer cancel()
+
+	if aware, ok := handler.(ContextAwareStateHandler); ok {
+		err := aware.InitWithContext(ctx, apiCtx)
+		if err != nil && context.Cause(ctx) != nil && !errors.Is(err, context.Cause(ctx)) {
+			err = fmt.Errorf("%w: %w", context.Cause(ctx), err)
+		}
+		return err
+	}
+	if timeout <= 0 {
+		return handler.Init(apiCtx)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- handler.Init(apiCtx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("provider initialization did not complete within %s: %w", timeout, context.Cause(ctx))
+	}
+}
+
 var statesMap = map[EventType]func(ProviderEventDetails) State{
 	ProviderReady:        func(_ ProviderEventDetails) State { return ReadyState },
 	ProviderConfigChange: func(_ ProviderEventDetails) State { return ReadyState },
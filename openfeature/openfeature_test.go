@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -437,12 +441,12 @@ func TestRequirement_1_1_3(t *testing.T) {
 
 	// Validate provider retrieval by client evaluation. This uses forTransaction("clientName")
 
-	provider, _, _ := api.ForEvaluation("clientA")
+	provider, _, _, _ := api.ForEvaluation("clientA")
 	if provider.Metadata().Name != "providerA" {
 		t.Errorf("expected %s, but got %s", "providerA", providerA.Metadata().Name)
 	}
 
-	provider, _, _ = api.ForEvaluation("clientB")
+	provider, _, _, _ = api.ForEvaluation("clientB")
 	if provider.Metadata().Name != "providerB" {
 		t.Errorf("expected %s, but got %s", "providerB", providerA.Metadata().Name)
 	}
@@ -464,7 +468,7 @@ func TestRequirement_1_1_3(t *testing.T) {
 
 	// Validate provider retrieval by client evaluation. This uses forTransaction("clientName")
 
-	provider, _, _ = api.ForEvaluation("clientB")
+	provider, _, _, _ = api.ForEvaluation("clientB")
 	if provider.Metadata().Name != "providerB2" {
 		t.Errorf("expected %s, but got %s", "providerB2", providerA.Metadata().Name)
 	}
@@ -487,6 +491,182 @@ func TestRequirement_1_1_4(t *testing.T) {
 	}
 }
 
+func TestHooks(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	ctrl := gomock.NewController(t)
+
+	first, second := NewMockHook(ctrl), NewMockHook(ctrl)
+
+	t.Run("API hooks", func(t *testing.T) {
+		AddHooks(first, second)
+
+		hooks := Hooks()
+		if len(hooks) != 2 || hooks[0] != first || hooks[1] != second {
+			t.Fatalf("expected the registered hooks, got %v", hooks)
+		}
+
+		hooks[0], hooks[1] = second, first
+		if registered := Hooks(); registered[0] != first || registered[1] != second {
+			t.Errorf("expected mutating the returned hooks to leave the registered hooks untouched, got %v", registered)
+		}
+
+		hooks = api.GetHooks()
+		hooks[0], hooks[1] = second, first
+		if registered := Hooks(); registered[0] != first || registered[1] != second {
+			t.Errorf("expected mutating the hooks returned by GetHooks to leave the registered hooks untouched, got %v", registered)
+		}
+	})
+
+	t.Run("client hooks", func(t *testing.T) {
+		client := NewClient("test-client")
+		client.AddHooks(first)
+
+		hooks := client.Hooks()
+		if len(hooks) != 1 || hooks[0] != first {
+			t.Fatalf("expected the registered hooks, got %v", hooks)
+		}
+
+		hooks[0] = second
+		if registered := client.Hooks(); registered[0] != first {
+			t.Errorf("expected mutating the returned hooks to leave the registered hooks untouched, got %v", registered)
+		}
+	})
+
+	t.Run("concurrent registration", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				AddHooks(first)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = Hooks()
+			}()
+		}
+		wg.Wait()
+
+		if len(Hooks()) != 12 {
+			t.Errorf("expected 12 hooks, got %d", len(Hooks()))
+		}
+	})
+}
+
+// uncomparableHook is a hook of a non-comparable type
+type uncomparableHook struct {
+	UnimplementedHook
+	tags []string
+}
+
+func TestRemoveHooks(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	ctrl := gomock.NewController(t)
+
+	first, second := NewMockHook(ctrl), NewMockHook(ctrl)
+
+	t.Run("API hooks", func(t *testing.T) {
+		AddHooks(first, second, first)
+		snapshot := Hooks()
+
+		if !RemoveHook(first) {
+			t.Error("expected the registered hook to be removed")
+		}
+		if hooks := Hooks(); len(hooks) != 1 || hooks[0] != second {
+			t.Errorf("expected the remaining hook, got %v", hooks)
+		}
+		if RemoveHook(first) {
+			t.Error("expected removing an unregistered hook to report false")
+		}
+		if RemoveHook(uncomparableHook{}) {
+			t.Error("expected a non-comparable hook to never be removed")
+		}
+		if len(snapshot) != 3 || snapshot[0] != first {
+			t.Errorf("expected an earlier snapshot to be left untouched, got %v", snapshot)
+		}
+
+		ClearHooks()
+		if hooks := Hooks(); len(hooks) != 0 {
+			t.Errorf("expected no hooks, got %v", hooks)
+		}
+	})
+
+	t.Run("client hooks", func(t *testing.T) {
+		client := NewClient("test-client")
+		client.AddHooks(first, uncomparableHook{}, second)
+
+		if !client.RemoveHook(second) {
+			t.Error("expected the registered hook to be removed")
+		}
+		if hooks := client.Hooks(); len(hooks) != 2 || hooks[0] != first {
+			t.Errorf("expected the remaining hooks, got %v", hooks)
+		}
+		if client.RemoveHook(uncomparableHook{}) {
+			t.Error("expected a non-comparable hook to never be removed")
+		}
+
+		client.ClearHooks()
+		if hooks := client.Hooks(); len(hooks) != 0 {
+			t.Errorf("expected no hooks, got %v", hooks)
+		}
+	})
+}
+
+func TestHookSets(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	ctrl := gomock.NewController(t)
+
+	logging, metrics, tracing := NewMockHook(ctrl), NewMockHook(ctrl), NewMockHook(ctrl)
+
+	t.Run("removing a set removes exactly its hooks", func(t *testing.T) {
+		AddHooks(logging)
+		AddHookSet(HookSet{Name: "observability", Hooks: []Hook{metrics, tracing}})
+		AddHookSet(HookSet{Name: "audit", Hooks: []Hook{logging}})
+
+		if hooks := Hooks(); !slices.Equal(hooks, []Hook{logging, metrics, tracing, logging}) {
+			t.Errorf("expected the hooks of the sets to be flattened in order, got %v", hooks)
+		}
+		if hooks := api.GetHooks(); !slices.Equal(hooks, []Hook{logging, metrics, tracing, logging}) {
+			t.Errorf("expected GetHooks to return the hooks of the sets as registered, got %v", hooks)
+		}
+
+		if !RemoveHookSet("observability") {
+			t.Error("expected the registered set to be removed")
+		}
+		if hooks := Hooks(); !slices.Equal(hooks, []Hook{logging, logging}) {
+			t.Errorf("expected the individually registered hook and the other set to be kept, got %v", hooks)
+		}
+		if RemoveHookSet("observability") {
+			t.Error("expected removing an unregistered set to report false")
+		}
+
+		ClearHooks()
+	})
+
+	t.Run("hints of the set", func(t *testing.T) {
+		var setHints, otherHints HookHints
+		AddHookSet(HookSet{
+			Name:  "observability",
+			Hooks: []Hook{hintsRecordingHook{hints: &setHints}},
+			Hints: NewHookHints(map[string]interface{}{"exporter": "otlp", "sampled": false}),
+		})
+		AddHooks(hintsRecordingHook{hints: &otherHints})
+
+		_, err := NewClient(t.Name()).BooleanValue(context.Background(), "flag", false, EvaluationContext{},
+			WithHookHints(NewHookHints(map[string]interface{}{"sampled": true})))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if setHints.Value("exporter") != "otlp" || setHints.Value("sampled") != true {
+			t.Errorf("expected the hints of the set merged with the evaluation's hints, got %v", setHints.Values())
+		}
+		if otherHints.Value("exporter") != nil {
+			t.Errorf("expected the hints of the set to be given to its hooks only, got %v", otherHints.Values())
+		}
+	})
+}
+
 // The API MUST provide a function for retrieving the metadata field of the configured `provider`.
 func TestRequirement_1_1_5(t *testing.T) {
 	defer t.Cleanup(initSingleton)
@@ -713,7 +893,7 @@ func TestDefaultClientUsage(t *testing.T) {
 	}
 
 	// Validate provider retrieval by client evaluation
-	provider, _, _ := api.ForEvaluation("ClientName")
+	provider, _, _, _ := api.ForEvaluation("ClientName")
 
 	if provider.Metadata().Name != "defaultClientReplacement" {
 		t.Errorf("expected %s, but got %s", "defaultClientReplacement", provider.Metadata().Name)
@@ -819,3 +999,1171 @@ func setupProviderWithSemaphores() (struct {
 
 	return provider, intiSem, shutdownSem
 }
+
+func TestProviderStatus(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	eventing := &ProviderEventing{c: make(chan Event, 1)}
+	provider := struct {
+		FeatureProvider
+		EventHandler
+	}{NoopProvider{}, eventing}
+
+	if status := ProviderStatus(t.Name()); status != NotReadyState {
+		t.Errorf("expected %s before a provider is set, got %s", NotReadyState, status)
+	}
+
+	err := SetNamedProviderAndWait(t.Name(), provider)
+	if err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := GetApiInstance().GetNamedClient(t.Name())
+	if status := ProviderStatus(t.Name()); status != ReadyState {
+		t.Errorf("expected %s once the provider is set, got %s", ReadyState, status)
+	}
+
+	// concurrent reads while lifecycle events are processed, stopped before the cleanup resets the API
+	done, stopped := make(chan struct{}), make(chan struct{})
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = client.ProviderStatus()
+				_ = GetApiInstance().ProviderStatus(t.Name())
+			}
+		}
+	}()
+
+	for _, transition := range []struct {
+		event EventType
+		state State
+	}{
+		{event: ProviderStale, state: StaleState},
+		{event: ProviderError, state: ErrorState},
+		{event: ProviderReady, state: ReadyState},
+	} {
+		eventing.Invoke(Event{ProviderName: "provider", EventType: transition.event})
+		eventually(t, func() bool {
+			return client.ProviderStatus() == transition.state && ProviderStatus(t.Name()) == transition.state
+		}, time.Second, 10*time.Millisecond, fmt.Sprintf("expected %s after %s", transition.state, transition.event))
+	}
+}
+
+func TestShutdownWithContext(t *testing.T) {
+	newProvider := func(shutdown func()) FeatureProvider {
+		return struct {
+			FeatureProvider
+			StateHandler
+		}{NoopProvider{}, &stateHandlerForTests{shutdownF: shutdown}}
+	}
+
+	t.Run("returns once all providers are shut down", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		var shutdowns atomic.Int32
+		for _, domain := range []string{"first", "second"} {
+			err := SetNamedProviderAndWait(domain, newProvider(func() { shutdowns.Add(1) }))
+			if err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+		}
+
+		if err := ShutdownWithContext(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if shutdowns.Load() != 2 {
+			t.Errorf("expected both providers to be shut down, got %d", shutdowns.Load())
+		}
+	})
+
+	t.Run("reports the providers not shut down before the deadline", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		release := make(chan struct{})
+		defer close(release)
+		var fastShutdown atomic.Bool
+		if err := SetNamedProviderAndWait("slow", newProvider(func() { <-release })); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+		if err := SetNamedProviderAndWait("fast", newProvider(func() { fastShutdown.Store(true) })); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := ShutdownWithContext(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a deadline exceeded error, got %v", err)
+		}
+		if !strings.Contains(err.Error(), `domain "slow"`) || strings.Contains(err.Error(), `domain "fast"`) {
+			t.Errorf("expected only the slow provider to be reported, got %v", err)
+		}
+		if !fastShutdown.Load() {
+			t.Error("expected the fast provider to be shut down")
+		}
+	})
+}
+
+// A provider instance registered for several domains is shut down exactly once
+func TestShutdownOncePerProvider(t *testing.T) {
+	shutdowns := map[string]func() error{
+		"Shutdown": func() error {
+			Shutdown()
+			return nil
+		},
+		"ShutdownWithContext": func() error {
+			return ShutdownWithContext(context.Background())
+		},
+	}
+
+	for name, shutdown := range shutdowns {
+		shutdown := shutdown
+		t.Run(name, func(t *testing.T) {
+			defer t.Cleanup(initSingleton)
+
+			var calls atomic.Int32
+			provider := struct {
+				FeatureProvider
+				StateHandler
+			}{NoopProvider{}, &stateHandlerForTests{shutdownF: func() { calls.Add(1) }}}
+			for _, domain := range []string{"first", "second"} {
+				if err := SetNamedProviderAndWait(domain, provider); err != nil {
+					t.Fatalf("error setting up provider %v", err)
+				}
+			}
+			if err := SetProviderAndWait(provider); err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+
+			if err := shutdown(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if calls.Load() != 1 {
+				t.Errorf("expected the provider to be shut down once, got %d", calls.Load())
+			}
+		})
+	}
+}
+
+// swappableProvider resolves string flags to its own name, taking a while to initialize
+type swappableProvider struct {
+	NoopProvider
+	name      string
+	initDelay time.Duration
+}
+
+func (p swappableProvider) Metadata() Metadata {
+	return Metadata{Name: p.name}
+}
+
+func (p swappableProvider) StringEvaluation(_ context.Context, _ string, _ string, _ FlattenedContext) StringResolutionDetail {
+	return StringResolutionDetail{Value: p.name, ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason}}
+}
+
+func (p swappableProvider) Init(EvaluationContext) error {
+	time.Sleep(p.initDelay)
+	return nil
+}
+
+func (p swappableProvider) Shutdown() {}
+
+// metadataCheckingHook flags evaluations resolved by another provider than the one of their hook context
+type metadataCheckingHook struct {
+	UnimplementedHook
+	mixed *atomic.Int64
+}
+
+func (h metadataCheckingHook) After(_ context.Context, hookCtx HookContext, details InterfaceEvaluationDetails, _ HookHints) error {
+	if details.Value != hookCtx.ProviderMetadata().Name {
+		h.mixed.Add(1)
+	}
+	return nil
+}
+
+func TestSetNamedProvider_ConcurrentSwaps(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	domain := t.Name()
+	if err := SetNamedProviderAndWait(domain, swappableProvider{name: "initial"}); err != nil {
+		t.Fatalf("error setting up provider: %v", err)
+	}
+
+	t.Run("the current provider serves evaluations while the new one initializes", func(t *testing.T) {
+		if err := SetNamedProvider(domain, swappableProvider{name: "slow", initDelay: 100 * time.Millisecond}); err != nil {
+			t.Fatalf("error setting up provider: %v", err)
+		}
+
+		client := NewClient(domain)
+		value, err := client.StringValue(context.Background(), "flag", "default", EvaluationContext{})
+		if err != nil || value != "initial" {
+			t.Errorf("expected the initial provider to serve the evaluation, got %q, %v", value, err)
+		}
+
+		eventually(t, func() bool {
+			value, _ := client.StringValue(context.Background(), "flag", "default", EvaluationContext{})
+			return value == "slow"
+		}, time.Second, 10*time.Millisecond, "expected the slow provider to replace the initial provider")
+	})
+
+	t.Run("superseded registrations do not replace the latest provider", func(t *testing.T) {
+		if err := SetNamedProvider(domain, swappableProvider{name: "superseded", initDelay: 100 * time.Millisecond}); err != nil {
+			t.Fatalf("error setting up provider: %v", err)
+		}
+		if err := SetNamedProviderAndWait(domain, swappableProvider{name: "latest"}); err != nil {
+			t.Fatalf("error setting up provider: %v", err)
+		}
+
+		time.Sleep(200 * time.Millisecond)
+		if name := GetApiInstance().GetNamedProviderMetadata(domain).Name; name != "latest" {
+			t.Errorf("expected the latest provider to be bound, got %s", name)
+		}
+	})
+
+	t.Run("evaluations are consistent while providers are swapped", func(t *testing.T) {
+		client := NewClient(domain)
+		var mixed, failed atomic.Int64
+		hook := metadataCheckingHook{mixed: &mixed}
+
+		done := make(chan struct{})
+		evaluations := make(chan struct{})
+		for i := 0; i < 8; i++ {
+			go func() {
+				defer func() { evaluations <- struct{}{} }()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					if _, err := client.StringValue(context.Background(), "flag", "default", EvaluationContext{}, WithHooks(hook)); err != nil {
+						failed.Add(1)
+					}
+				}
+			}()
+		}
+
+		swaps := make(chan struct{})
+		for i := 0; i < 4; i++ {
+			go func(i int) {
+				for j := 0; j < 10; j++ {
+					provider := swappableProvider{name: fmt.Sprintf("provider-%d-%d", i, j), initDelay: time.Millisecond}
+					if j%2 == 0 {
+						_ = SetNamedProvider(domain, provider)
+					} else {
+						// concurrent registrations may supersede each other
+						_ = SetNamedProviderAndWait(domain, provider)
+					}
+				}
+				swaps <- struct{}{}
+			}(i)
+		}
+		for i := 0; i < 4; i++ {
+			<-swaps
+		}
+
+		close(done)
+		for i := 0; i < 8; i++ {
+			<-evaluations
+		}
+
+		if mixed.Load() != 0 {
+			t.Errorf("expected evaluations to be resolved by the provider of their hook context, got %d mixed evaluations", mixed.Load())
+		}
+		if failed.Load() != 0 {
+			t.Errorf("expected evaluations to be served while providers initialize, got %d failed evaluations", failed.Load())
+		}
+	})
+}
+
+func TestSetNamedProvider_FirstRegistrationNotReady(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	provider := newLifecycleRecordingProvider()
+	if err := SetNamedProvider(t.Name(), provider); err != nil {
+		t.Fatalf("error setting up provider: %v", err)
+	}
+	<-provider.calls // the provider is initializing
+
+	client := NewClient(t.Name())
+	details, err := client.BooleanValueDetails(context.Background(), "flag", false, EvaluationContext{})
+	if !errors.Is(err, ProviderNotReadyError) {
+		t.Errorf("expected a PROVIDER_NOT_READY error while the provider initializes, got %v", err)
+	}
+	if details.Reason != ErrorReason || details.ErrorCode != ProviderNotReadyCode {
+		t.Errorf("expected the error reason & %s code, got %s, %s", ProviderNotReadyCode, details.Reason, details.ErrorCode)
+	}
+	if state := client.State(); state != NotReadyState {
+		t.Errorf("expected the domain to be %s, got %s", NotReadyState, state)
+	}
+
+	close(provider.release)
+	eventually(t, func() bool {
+		_, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+		return err == nil
+	}, time.Second, 10*time.Millisecond, "expected the provider to serve evaluations once initialized")
+}
+
+func TestSetProviderAndWaitContext(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	t.Run("initialization completing before the deadline", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider, initSem, _ := setupProviderWithSemaphores()
+		err := SetProviderAndWaitContext(context.Background(), provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		<-initSem
+
+		if state := GetApiInstance().GetClient().State(); state != ReadyState {
+			t.Errorf("expected %s, got %s", ReadyState, state)
+		}
+	})
+
+	t.Run("initialization failing", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					return errors.New("initialization failure")
+				},
+			},
+		}
+		if err := SetProviderAndWaitContext(context.Background(), provider); err == nil {
+			t.Error("expected the initialization error, got nil")
+		}
+	})
+
+	t.Run("context cancelled while initialization blocks", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		unblock := make(chan struct{})
+		provider := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					<-unblock
+					return nil
+				},
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}()
+
+		err := SetProviderAndWaitContext(ctx, provider)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected %v, got %v", context.Canceled, err)
+		}
+
+		// the provider stays registered while its initialization carries on
+		if GetApiInstance().(*evaluationAPI).GetProvider() != provider {
+			t.Error("expected the provider to remain the default provider")
+		}
+		if state := GetApiInstance().GetClient().State(); state != NotReadyState {
+			t.Errorf("expected %s, got %s", NotReadyState, state)
+		}
+
+		close(unblock)
+		eventually(t, func() bool {
+			return GetApiInstance().GetClient().State() == ReadyState
+		}, time.Second, 10*time.Millisecond, "expected the provider to become ready once initialized")
+	})
+}
+
+// contextAwareProvider initializes with initF, honoring the cancellation of the initialization context
+type contextAwareProvider struct {
+	NoopProvider
+	initF func(ctx context.Context) error
+}
+
+func (p contextAwareProvider) Init(EvaluationContext) error {
+	return p.initF(context.Background())
+}
+
+func (p contextAwareProvider) InitWithContext(ctx context.Context, _ EvaluationContext) error {
+	return p.initF(ctx)
+}
+
+func (p contextAwareProvider) Shutdown() {}
+
+func TestInitTimeout(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	t.Run("provider honoring the cancellation of the initialization", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		errorEvents := make(chan EventDetails, 1)
+		callback := func(details EventDetails) { errorEvents <- details }
+		AddHandler(ProviderError, &callback)
+
+		var deadlineSet bool
+		provider := contextAwareProvider{initF: func(ctx context.Context) error {
+			_, deadlineSet = ctx.Deadline()
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+
+		err := SetProviderAndWaitWithOptions(provider, WithInitTimeout(50*time.Millisecond))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+		}
+		if !deadlineSet {
+			t.Error("expected the initialization context to carry the deadline of the timeout")
+		}
+		if state := GetApiInstance().GetClient().State(); state != ErrorState {
+			t.Errorf("expected %s, got %s", ErrorState, state)
+		}
+		select {
+		case <-errorEvents:
+		case <-time.After(time.Second):
+			t.Error("expected a PROVIDER_ERROR event")
+		}
+	})
+
+	t.Run("provider ignoring the cancellation of the initialization", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		unblock := make(chan struct{})
+		defer close(unblock)
+		provider := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					<-unblock
+					return nil
+				},
+			},
+		}
+
+		err := SetNamedProviderAndWaitWithOptions(t.Name(), provider, WithInitTimeout(50*time.Millisecond))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("initialization completing within the timeout", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider := contextAwareProvider{initF: func(ctx context.Context) error { return nil }}
+		if err := SetProviderAndWaitWithOptions(provider, WithInitTimeout(time.Second)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state := GetApiInstance().GetClient().State(); state != ReadyState {
+			t.Errorf("expected %s, got %s", ReadyState, state)
+		}
+	})
+}
+
+func TestWaitForReady(t *testing.T) {
+	newProvider := func(init func(EvaluationContext) error) FeatureProvider {
+		return struct {
+			FeatureProvider
+			StateHandler
+		}{NoopProvider{}, &stateHandlerForTests{initF: init}}
+	}
+
+	t.Run("returns at once if the provider is ready", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		if err := SetNamedProviderAndWait(t.Name(), newProvider(nil)); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := WaitForNamedReady(ctx, t.Name()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("waits for the provider to become ready", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		release := make(chan struct{})
+		if err := SetNamedProvider(t.Name(), newProvider(func(EvaluationContext) error {
+			<-release
+			return nil
+		})); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			done <- WaitForNamedReady(ctx, t.Name())
+		}()
+
+		select {
+		case err := <-done:
+			t.Fatalf("expected to wait for the provider, returned %v", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(release)
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if state := ProviderStatus(t.Name()); state != ReadyState {
+			t.Errorf("expected the provider to be ready, got %s", state)
+		}
+	})
+
+	t.Run("returns the context's error if the context is done first", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		release := make(chan struct{})
+		defer close(release)
+		if err := SetNamedProvider(t.Name(), newProvider(func(EvaluationContext) error {
+			<-release
+			return nil
+		})); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := WaitForNamedReady(ctx, t.Name()); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected a deadline exceeded error, got %v", err)
+		}
+	})
+
+	t.Run("returns an error if the provider fails", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		_ = SetNamedProviderAndWait("error", newProvider(func(EvaluationContext) error {
+			return errors.New("unreachable")
+		}))
+		_ = SetNamedProviderAndWait("fatal", newProvider(func(EvaluationContext) error {
+			return &ProviderInitError{ErrorCode: ProviderFatalCode, Message: "misconfigured"}
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := WaitForNamedReady(ctx, "error"); err == nil || errors.Is(err, ProviderFatalError) {
+			t.Errorf("expected a provider error, got %v", err)
+		}
+		if err := WaitForNamedReady(ctx, "fatal"); !errors.Is(err, ProviderFatalError) {
+			t.Errorf("expected a ProviderFatalError, got %v", err)
+		}
+	})
+
+	t.Run("waits for the default provider", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		if err := SetProviderAndWait(newProvider(nil)); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := WaitForReady(ctx); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+// Evaluations of domains without a provider resolve to the caller's default while no default provider is set
+func TestEvaluationWithoutProvider(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	client := NewClient(t.Name())
+
+	details, err := client.StringValueDetails(context.Background(), "flag", "fallback", EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if details.Value != "fallback" || details.Reason != DefaultReason {
+		t.Errorf("expected the default value with the %s reason, got %+v", DefaultReason, details)
+	}
+	if NamedProviderMetadata(t.Name()).Name != (NoopProvider{}).Metadata().Name {
+		t.Errorf("expected the domain to be served by the NoopProvider, got %v", NamedProviderMetadata(t.Name()))
+	}
+}
+
+func TestWithImplicitDefaultProvider(t *testing.T) {
+	assertDefault := func(t *testing.T, client *Client) {
+		t.Helper()
+		details, err := client.StringValueDetails(context.Background(), "flag", "fallback", EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if details.Value != "fallback" || details.Reason != DefaultReason || details.ErrorCode != "" {
+			t.Errorf("expected the default value with the %s reason, got %+v", DefaultReason, details)
+		}
+
+		results, err := client.EvaluateBatch(context.Background(), []FlagRequest{{Key: "flag", Type: String, DefaultValue: "fallback"}}, EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected batch error: %v", err)
+		}
+		if results[0].Value != "fallback" || results[0].Reason != DefaultReason {
+			t.Errorf("expected the default value with the %s reason, got %+v", DefaultReason, results[0])
+		}
+	}
+
+	t.Run("domain whose first provider initializes", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider := newLifecycleRecordingProvider()
+		defer close(provider.release)
+		if err := SetNamedProviderWithOptions(t.Name(), provider, WithImplicitDefaultProvider(true)); err != nil {
+			t.Fatalf("error setting up provider: %v", err)
+		}
+		<-provider.calls // the provider is initializing
+
+		assertDefault(t, NewClient(t.Name()))
+	})
+
+	t.Run("unset domain while the default provider initializes", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider := newLifecycleRecordingProvider()
+		defer close(provider.release)
+		if err := SetProviderWithOptions(provider, WithImplicitDefaultProvider(true)); err != nil {
+			t.Fatalf("error setting up provider: %v", err)
+		}
+		<-provider.calls // the provider is initializing
+
+		assertDefault(t, NewClient(t.Name()))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider := newLifecycleRecordingProvider()
+		defer close(provider.release)
+		if err := SetNamedProvider(t.Name(), provider); err != nil {
+			t.Fatalf("error setting up provider: %v", err)
+		}
+		<-provider.calls // the provider is initializing
+
+		_, err := NewClient(t.Name()).StringValueDetails(context.Background(), "flag", "fallback", EvaluationContext{})
+		if !errors.Is(err, ProviderNotReadyError) {
+			t.Errorf("expected a PROVIDER_NOT_READY error without the option, got %v", err)
+		}
+	})
+
+	t.Run("ready providers serve the evaluations", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider := &initCountingProvider{name: "ready"}
+		if err := SetNamedProviderAndWaitWithOptions(t.Name(), provider, WithImplicitDefaultProvider(true)); err != nil {
+			t.Fatalf("error setting up provider: %v", err)
+		}
+
+		if evaluating, _, _, _ := api.ForEvaluation(t.Name()); evaluating != provider {
+			t.Errorf("expected the domain to be served by its provider, got %v", evaluating)
+		}
+	})
+}
+
+// shutdownCountingProvider counts its shutdowns, compared by identity
+type shutdownCountingProvider struct {
+	NoopProvider
+	name      string
+	shutdowns atomic.Int32
+}
+
+func (p *shutdownCountingProvider) Metadata() Metadata {
+	return Metadata{Name: p.name}
+}
+
+func (p *shutdownCountingProvider) Init(EvaluationContext) error {
+	return nil
+}
+
+func (p *shutdownCountingProvider) Shutdown() {
+	p.shutdowns.Add(1)
+}
+
+func TestSwapProvider(t *testing.T) {
+	t.Run("named provider", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		first, second := &shutdownCountingProvider{name: "first"}, &shutdownCountingProvider{name: "second"}
+		previous, err := SwapNamedProvider(t.Name(), first)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if previous != nil {
+			t.Errorf("expected no previous provider, got %v", previous)
+		}
+
+		previous, err = SwapNamedProvider(t.Name(), second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if previous != first {
+			t.Errorf("expected the previously registered instance, got %v", previous)
+		}
+		if name := NamedProviderMetadata(t.Name()).Name; name != "second" {
+			t.Errorf("expected the new provider to be registered, got %s", name)
+		}
+
+		// the swapped out provider is the caller's to shut down, or restore
+		previous, err = SwapNamedProvider(t.Name(), previous)
+		if err != nil || previous != second {
+			t.Errorf("expected the rollback to return the new provider, got %v, %v", previous, err)
+		}
+		if name := NamedProviderMetadata(t.Name()).Name; name != "first" {
+			t.Errorf("expected the previous provider to be restored, got %s", name)
+		}
+		assertShutdowns(t, map[*shutdownCountingProvider]int32{first: 1, second: 0})
+	})
+
+	t.Run("named provider failing initialization", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		first := &shutdownCountingProvider{name: "first"}
+		if err := SetNamedProviderAndWait(t.Name(), first); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		failing := struct {
+			FeatureProvider
+			StateHandler
+		}{NoopProvider{}, &stateHandlerForTests{initF: func(EvaluationContext) error { return errors.New("unreachable") }}}
+
+		previous, err := SwapNamedProvider(t.Name(), failing)
+		if err == nil {
+			t.Fatal("expected the initialization error")
+		}
+		if previous != nil {
+			t.Errorf("expected no provider to be replaced, got %v", previous)
+		}
+		if name := NamedProviderMetadata(t.Name()).Name; name != "first" {
+			t.Errorf("expected the previous provider to stay in place, got %s", name)
+		}
+	})
+
+	t.Run("default provider", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		first := &shutdownCountingProvider{name: "first"}
+		previous, err := SwapProvider(first)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if previous != nil {
+			t.Errorf("expected no previous provider, got %v", previous)
+		}
+
+		second := &shutdownCountingProvider{name: "second"}
+		previous, err = SwapProvider(second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if previous != first {
+			t.Errorf("expected the previously registered instance, got %v", previous)
+		}
+		assertShutdowns(t, map[*shutdownCountingProvider]int32{first: 0, second: 1})
+	})
+
+	t.Run("default provider failing initialization", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		first := &shutdownCountingProvider{name: "first"}
+		if err := SetProviderAndWait(first); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		failing := struct {
+			FeatureProvider
+			StateHandler
+		}{NoopProvider{}, &stateHandlerForTests{initF: func(EvaluationContext) error { return errors.New("unreachable") }}}
+
+		client := GetApiInstance().GetClient()
+		errored := make(chan EventDetails, 1)
+		onError := func(details EventDetails) { errored <- details }
+		client.AddHandler(ProviderError, &onError)
+		apiErrored := make(chan EventDetails, 1)
+		onAPIError := func(details EventDetails) { apiErrored <- details }
+		AddHandler(ProviderError, &onAPIError)
+
+		previous, err := SwapProvider(failing)
+		if err == nil {
+			t.Fatal("expected the initialization error")
+		}
+		if previous != nil {
+			t.Errorf("expected no provider to be replaced, got %v", previous)
+		}
+		if name := ProviderMetadata().Name; name != "first" {
+			t.Errorf("expected the previous provider to stay in place, got %s", name)
+		}
+		if state := client.State(); state != ReadyState {
+			t.Errorf("expected the default provider to stay %s, got %s", ReadyState, state)
+		}
+
+		// the failure is conveyed to the API level handlers only, the client being served by the previous provider
+		select {
+		case <-apiErrored:
+		case <-time.After(time.Second):
+			t.Fatal("expected the API level handlers to be notified of the failure")
+		}
+		select {
+		case details := <-errored:
+			t.Errorf("expected the client handlers not to be notified, got %+v", details)
+		default:
+		}
+		assertShutdowns(t, map[*shutdownCountingProvider]int32{first: 1})
+	})
+}
+
+// assertShutdowns shuts down the API, which shuts down the providers bound to it, and checks the number of shutdowns of
+// every provider
+func assertShutdowns(t *testing.T, expected map[*shutdownCountingProvider]int32) {
+	t.Helper()
+
+	Shutdown()
+	for provider, shutdowns := range expected {
+		if got := provider.shutdowns.Load(); got != shutdowns {
+			t.Errorf("expected provider %s to be shut down %d times, got %d", provider.name, shutdowns, got)
+		}
+	}
+}
+
+// refreshingProvider counts its refreshes, failing them with err if set
+type refreshingProvider struct {
+	NoopProvider
+	err       error
+	refreshes atomic.Int32
+}
+
+func (p *refreshingProvider) Refresh(context.Context) error {
+	p.refreshes.Add(1)
+	return p.err
+}
+
+func TestRefreshProvider(t *testing.T) {
+	t.Run("successful refreshes emit a configuration change", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		provider := &refreshingProvider{}
+		if err := SetNamedProviderAndWait(t.Name(), provider); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		changed := make(chan EventDetails, 1)
+		handler := func(details EventDetails) { changed <- details }
+		AddNamedHandler(t.Name(), ProviderConfigChange, &handler)
+
+		if err := RefreshProvider(context.Background(), t.Name()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if provider.refreshes.Load() != 1 {
+			t.Errorf("expected the provider to be refreshed once, got %d refreshes", provider.refreshes.Load())
+		}
+		select {
+		case details := <-changed:
+			if details.ProviderName != provider.Metadata().Name {
+				t.Errorf("expected the event of the refreshed provider, got %s", details.ProviderName)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a configuration change event")
+		}
+	})
+
+	t.Run("failing refreshes return the error", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		refreshErr := errors.New("configuration unreachable")
+		if err := SetNamedProviderAndWait(t.Name(), &refreshingProvider{err: refreshErr}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		changed := make(chan EventDetails, 1)
+		handler := func(details EventDetails) { changed <- details }
+		AddNamedHandler(t.Name(), ProviderConfigChange, &handler)
+
+		if err := RefreshProvider(context.Background(), t.Name()); !errors.Is(err, refreshErr) {
+			t.Errorf("expected the refresh error, got %v", err)
+		}
+		select {
+		case <-changed:
+			t.Error("expected no configuration change event for a failed refresh")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("providers not implementing Refresher are unsupported", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+
+		if err := RefreshProvider(context.Background(), t.Name()); !errors.Is(err, RefreshUnsupportedError) {
+			t.Errorf("expected an unsupported error for the default noop provider, got %v", err)
+		}
+	})
+}
+
+func TestProviderMetadataByDomain(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	if name := api.ProviderMetadata("").Name; name != (NoopProvider{}).Metadata().Name {
+		t.Errorf("expected the noop provider's metadata without registered provider, got %s", name)
+	}
+
+	first, second := &shutdownCountingProvider{name: "first"}, &shutdownCountingProvider{name: "second"}
+	if err := SetNamedProviderAndWait(t.Name(), first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := api.ProviderMetadata(t.Name()).Name; name != "first" {
+		t.Errorf("expected the metadata of the provider of the domain, got %s", name)
+	}
+	if name := api.ProviderMetadata("unbound").Name; name != (NoopProvider{}).Metadata().Name {
+		t.Errorf("expected the default provider's metadata for an unbound domain, got %s", name)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			provider := first
+			if i%2 == 0 {
+				provider = second
+			}
+			if _, err := SwapNamedProvider(t.Name(), provider); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if name := api.ProviderMetadata(t.Name()).Name; name != "first" && name != "second" {
+			t.Fatalf("expected the metadata of either provider during the swaps, got %s", name)
+		}
+	}
+}
+
+// initCountingProvider is a provider counting its initializations, each taking the delay
+type initCountingProvider struct {
+	NoopProvider
+	name  string
+	delay time.Duration
+	inits atomic.Int32
+}
+
+func (p *initCountingProvider) Metadata() Metadata {
+	return Metadata{Name: p.name}
+}
+
+func (p *initCountingProvider) Init(EvaluationContext) error {
+	p.inits.Add(1)
+	time.Sleep(p.delay)
+	return nil
+}
+
+func (p *initCountingProvider) Shutdown() {}
+
+// lifecycleRecordingProvider records its initializations and shutdowns, its initializations blocking until released
+type lifecycleRecordingProvider struct {
+	NoopProvider
+	calls   chan string
+	release chan struct{}
+}
+
+func newLifecycleRecordingProvider() *lifecycleRecordingProvider {
+	return &lifecycleRecordingProvider{calls: make(chan string, 10), release: make(chan struct{})}
+}
+
+func (p *lifecycleRecordingProvider) Init(EvaluationContext) error {
+	p.calls <- "init"
+	<-p.release
+	return nil
+}
+
+func (p *lifecycleRecordingProvider) Shutdown() {
+	p.calls <- "shutdown"
+}
+
+func TestLazyInit(t *testing.T) {
+	t.Run("initialized once on first evaluation", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		provider := &initCountingProvider{name: "lazy", delay: 10 * time.Millisecond}
+		if err := SetNamedProviderAndWaitWithOptions(t.Name(), provider, WithLazyInit(true)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		client := NewClient(t.Name())
+		ready := make(chan EventDetails, 10)
+		callback := func(details EventDetails) { ready <- details }
+		client.AddHandler(ProviderReady, &callback)
+		if inits := provider.inits.Load(); inits != 0 {
+			t.Errorf("expected no initialization before the first evaluation, got %d", inits)
+		}
+		if state := client.State(); state != NotReadyState {
+			t.Errorf("expected the provider to be %s until initialized, got %s", NotReadyState, state)
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Errorf("expected concurrent evaluations to wait for the initialization, got %v", err)
+			}
+		}
+
+		if inits := provider.inits.Load(); inits != 1 {
+			t.Errorf("expected a single initialization, got %d", inits)
+		}
+		if state := client.State(); state != ReadyState {
+			t.Errorf("expected the provider to be %s once initialized, got %s", ReadyState, state)
+		}
+		select {
+		case details := <-ready:
+			if details.ProviderName != "lazy" {
+				t.Errorf("expected the PROVIDER_READY event of the provider, got %s", details.ProviderName)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a PROVIDER_READY event after the lazy initialization")
+		}
+		select {
+		case <-ready:
+			t.Error("expected a single PROVIDER_READY event")
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("default provider", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		provider := &initCountingProvider{name: "lazy"}
+		if err := SetProviderAndWaitWithOptions(provider, WithLazyInit(true)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if inits := provider.inits.Load(); inits != 0 {
+			t.Errorf("expected no initialization before the first evaluation, got %d", inits)
+		}
+		if metadata := NamedProviderMetadata(t.Name()); metadata.Name != "lazy" || provider.inits.Load() != 0 {
+			t.Errorf("expected the metadata of the provider without initializing it, got %s", metadata.Name)
+		}
+
+		if _, err := NewClient(t.Name()).BooleanValue(context.Background(), "foo", false, EvaluationContext{}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if inits := provider.inits.Load(); inits != 1 {
+			t.Errorf("expected a single initialization, got %d", inits)
+		}
+	})
+
+	t.Run("waiting for readiness initializes the provider", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		provider := &initCountingProvider{name: "lazy"}
+		if err := SetNamedProviderAndWaitWithOptions(t.Name(), provider, WithLazyInit(true)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := WaitForNamedReady(ctx, t.Name()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if inits := provider.inits.Load(); inits != 1 {
+			t.Errorf("expected a single initialization, got %d", inits)
+		}
+	})
+
+	t.Run("providers replaced before use are never initialized", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		replaced := &initCountingProvider{name: "replaced"}
+		if err := SetNamedProviderAndWaitWithOptions(t.Name(), replaced, WithLazyInit(true)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		eager := &initCountingProvider{name: "eager"}
+		if err := SetNamedProviderAndWait(t.Name(), eager); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := NewClient(t.Name()).BooleanValue(context.Background(), "foo", false, EvaluationContext{}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if replaced.inits.Load() != 0 || eager.inits.Load() != 1 {
+			t.Errorf("expected only the eager provider to initialize, got %d and %d", replaced.inits.Load(), eager.inits.Load())
+		}
+	})
+
+	t.Run("providers shut down before use are neither shut down nor initialized", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		provider := newLifecycleRecordingProvider()
+		close(provider.release)
+		if err := SetNamedProviderAndWaitWithOptions(t.Name(), provider, WithLazyInit(true)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ShutdownWithContext(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _ = NewClient(t.Name()).BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+		if len(provider.calls) != 0 {
+			t.Errorf("expected the provider never to be used, got %s", <-provider.calls)
+		}
+	})
+
+	t.Run("replacing a provider while it initializes does not block the API", func(t *testing.T) {
+		defer t.Cleanup(initSingleton)
+		provider := newLifecycleRecordingProvider()
+		if err := SetNamedProviderAndWaitWithOptions(t.Name(), provider, WithLazyInit(true)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		evaluated := make(chan struct{})
+		go func() {
+			defer close(evaluated)
+			_, _ = NewClient(t.Name()).BooleanValue(context.Background(), "foo", false, EvaluationContext{})
+		}()
+		if call := <-provider.calls; call != "init" {
+			t.Fatalf("expected the evaluation to initialize the provider, got %s", call)
+		}
+
+		replaced := make(chan error)
+		go func() {
+			replaced <- SetNamedProvider(t.Name(), NoopProvider{})
+		}()
+		select {
+		case err := <-replaced:
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the provider to be replaced without waiting for the initialization")
+		}
+		if _, err := NewClient("other").BooleanValue(context.Background(), "foo", false, EvaluationContext{}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		close(provider.release)
+		<-evaluated
+		select {
+		case call := <-provider.calls:
+			if call != "shutdown" {
+				t.Errorf("expected the replaced provider to be shut down once initialized, got %s", call)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the replaced provider to be shut down")
+		}
+	})
+}
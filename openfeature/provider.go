@@ -2,7 +2,9 @@ package openfeature
 
 import (
 	"context"
-	"errors"
+	"reflect"
+	"slices"
+	"strings"
 )
 
 const (
@@ -33,6 +35,9 @@ const (
 	ProviderConfigChange EventType = "PROVIDER_CONFIGURATION_CHANGED"
 	ProviderStale        EventType = "PROVIDER_STALE"
 	ProviderError        EventType = "PROVIDER_ERROR"
+	// HookError is emitted on behalf of the provider when a before or after hook fails, see WithHookErrorEvents. It
+	// does not change the state of the provider.
+	HookError EventType = "HOOK_ERROR"
 
 	TargetingKey string = "targetingKey" // evaluation context map key. The targeting key uniquely identifies the subject (end-user, or client service) of a flag evaluation.
 )
@@ -41,9 +46,69 @@ const (
 // TargetingKey ("targetingKey") is stored as a string value if provided in the evaluation context.
 type FlattenedContext map[string]interface{}
 
-// Reason indicates the semantic reason for a returned flag value
+// FlattenNested returns a copy of the flattened context whose nested maps with string keys are flattened into dotted
+// keys, e.g. {"user": {"email": "..."}} into {"user.email": "..."}, for providers expecting a flat structure.
+// Keys given explicitly win over conflicting keys produced by flattening, and among conflicting flattened keys the one
+// of the lexically smallest attribute wins. Empty nested maps produce no keys. Arrays and slices are kept as is, maps
+// nested in them are not flattened.
+func FlattenNested(flatCtx FlattenedContext) FlattenedContext {
+	flattened := make(FlattenedContext, len(flatCtx))
+	var nestedKeys []string
+	for key, value := range flatCtx {
+		if _, ok := nestedMap(value); ok {
+			nestedKeys = append(nestedKeys, key)
+			continue
+		}
+		flattened[key] = value
+	}
+
+	slices.Sort(nestedKeys)
+	for _, key := range nestedKeys {
+		nested, _ := nestedMap(flatCtx[key])
+		for nestedKey, value := range FlattenNested(nested) {
+			dotted := key + "." + nestedKey
+			if _, conflict := flattened[dotted]; !conflict {
+				flattened[dotted] = value
+			}
+		}
+	}
+	return flattened
+}
+
+// nestedMap returns the entries of the value if it is a map with string keys
+func nestedMap(value interface{}) (FlattenedContext, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case FlattenedContext:
+		return v, true
+	}
+
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Map || reflected.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	entries := make(FlattenedContext, reflected.Len())
+	for iter := reflected.MapRange(); iter.Next(); {
+		entries[iter.Key().String()] = iter.Value().Interface()
+	}
+	return entries, true
+}
+
+// Reason indicates the semantic reason for a returned flag value. Providers may return reasons beyond the standard
+// ones, e.g. a vendor specific "HOLDOUT", which are passed to hooks and callers as is.
 type Reason string
 
+// IsStandard reports whether the reason is one of the reasons defined by the specification, e.g. TargetingMatchReason
+func (r Reason) IsStandard() bool {
+	switch r {
+	case DefaultReason, TargetingMatchReason, SplitReason, DisabledReason, StaticReason, CachedReason, UnknownReason,
+		ErrorReason:
+		return true
+	}
+	return false
+}
+
 // FeatureProvider interface defines a set of functions that can be called in order to evaluate a flag.
 // This should be implemented by flag management systems.
 type FeatureProvider interface {
@@ -66,12 +131,42 @@ type StateHandler interface {
 	Shutdown()
 }
 
+// ContextAwareStateHandler is an optional extension of StateHandler for providers honoring the cancellation of their
+// initialization. InitWithContext is called in place of Init, with a context cancelled once the initialization
+// exceeds the timeout of the registration, see WithInitTimeout.
+type ContextAwareStateHandler interface {
+	StateHandler
+	InitWithContext(ctx context.Context, evaluationContext EvaluationContext) error
+}
+
 // Tracker is the contract for tracking
 // FeatureProvider can opt in for this behavior by implementing the interface
 type Tracker interface {
 	Track(ctx context.Context, trackingEventName string, evaluationContext EvaluationContext, details TrackingEventDetails)
 }
 
+// HealthChecker is the contract for health checking
+// FeatureProvider can opt in for this behavior by implementing the interface, the API then polls the provider's health
+// as configured by SetHealthCheckConfig, emitting a ProviderStale event when a health check fails and a ProviderReady
+// event once a health check succeeds again
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// BatchEvaluator is the contract for resolving multiple flags at once
+// FeatureProvider can opt in for this behavior by implementing the interface
+type BatchEvaluator interface {
+	// BatchEvaluation resolves the given flags, returning a resolution detail per request in the order of the requests
+	BatchEvaluation(ctx context.Context, requests []BatchFlagRequest) []InterfaceResolutionDetail
+}
+
+// BatchFlagRequest is a flag to be resolved by a BatchEvaluator, along with the flattened evaluation context
+// resulting from the flag's before hooks
+type BatchFlagRequest struct {
+	FlagRequest
+	FlattenedContext FlattenedContext
+}
+
 // NoopStateHandler is a noop StateHandler implementation
 // Status always set to ReadyState to comply with specification
 type NoopStateHandler struct {
@@ -102,7 +197,9 @@ type EventType string
 
 // ProviderEventDetails is the event payload emitted by FeatureProvider
 type ProviderEventDetails struct {
-	Message       string
+	Message string
+	// FlagChanges lists the keys of the flags changed by a PROVIDER_CONFIGURATION_CHANGED event, for providers able
+	// to report them. An empty list means that any flag may have changed.
 	FlagChanges   []string
 	EventMetadata map[string]interface{}
 	ErrorCode     ErrorCode
@@ -117,6 +214,8 @@ type Event struct {
 
 type EventDetails struct {
 	ProviderName string
+	// Domain is the domain the handler receiving the event was registered for, empty for API level handlers
+	Domain string
 	ProviderEventDetails
 }
 
@@ -152,6 +251,7 @@ func (p ProviderResolutionDetail) ResolutionDetail() ResolutionDetail {
 		Reason:       p.Reason,
 		ErrorCode:    p.ResolutionError.code,
 		ErrorMessage: p.ResolutionError.message,
+		ErrorDetails: p.ResolutionError.details,
 		FlagMetadata: metadata,
 	}
 }
@@ -160,7 +260,8 @@ func (p ProviderResolutionDetail) Error() error {
 	if p.ResolutionError.code == "" {
 		return nil
 	}
-	return errors.New(p.ResolutionError.Error())
+	// the resolution error itself is returned to keep its code, details & cause inspectable with errors.Is & errors.As
+	return p.ResolutionError
 }
 
 // BoolResolutionDetail provides a resolution detail with boolean type
@@ -193,9 +294,99 @@ type InterfaceResolutionDetail struct {
 	ProviderResolutionDetail
 }
 
-// Metadata provides provider name
+// Metadata provides provider name, as well as the version and capabilities of providers implementing
+// MetadataExtension
 type Metadata struct {
 	Name string
+	// Version is the version of the provider, empty unless the provider implements MetadataExtension
+	Version string
+	// capabilities are joined with capabilitySeparator to keep Metadata comparable
+	capabilities string
+}
+
+// capabilitySeparator is the ASCII unit separator, which is not expected in capability names
+const capabilitySeparator = "\x1f"
+
+// Capabilities returns the capabilities of the provider, empty unless the provider implements MetadataExtension
+func (m Metadata) Capabilities() []string {
+	if m.capabilities == "" {
+		return nil
+	}
+	return strings.Split(m.capabilities, capabilitySeparator)
+}
+
+// WithCapabilities returns a copy of the Metadata with the given capabilities, e.g. to build a HookContext in tests
+func (m Metadata) WithCapabilities(capabilities ...string) Metadata {
+	m.capabilities = strings.Join(capabilities, capabilitySeparator)
+	return m
+}
+
+// Capabilities of a provider, reported through MetadataExtension.Capabilities
+const (
+	TrackingCapability     = "tracking"
+	BatchCapability        = "batch"
+	FlagMetadataCapability = "flag-metadata"
+)
+
+// MetadataExtension is an optional interface a FeatureProvider can implement to report its version and capabilities
+// (e.g. TrackingCapability) in addition to its name. The SDK surfaces them in the provider Metadata given to hooks
+// through HookContext.ProviderMetadata and returned by ProviderMetadata and NamedProviderMetadata.
+type MetadataExtension interface {
+	Version() string
+	Capabilities() []string
+}
+
+// ContextRequirements is an optional interface a FeatureProvider can implement to declare the evaluation context
+// fields it requires (e.g. "tenant_id", or TargetingKey for the targeting key). Evaluations whose context, amended by
+// the before hooks, lacks any of the fields fail without calling the provider, with a TARGETING_KEY_MISSING error for
+// the targeting key and an INVALID_CONTEXT error for any other field. Attributes holding nil are considered missing.
+type ContextRequirements interface {
+	RequiredContextFields() []string
+}
+
+// ContextFlattening is an optional interface a FeatureProvider can implement to receive flattened contexts whose
+// nested maps are flattened into dotted keys (e.g. "user.email"), see FlattenNested. Providers not implementing it,
+// or returning false, receive the nested structure as is.
+type ContextFlattening interface {
+	FlattenNestedContext() bool
+}
+
+// VariantForcer is an optional interface a FeatureProvider can implement to resolve a flag to a given variant,
+// regardless of its targeting rules, e.g. to verify the behavior of every variant in tests.
+// ForceVariant returns the variant's value, or a resolution error if the flag or the variant does not exist.
+type VariantForcer interface {
+	ForceVariant(
+		ctx context.Context, flag string, flagType Type, variant string, defaultValue interface{}, evalCtx FlattenedContext,
+	) InterfaceResolutionDetail
+}
+
+// Refresher is an optional interface a FeatureProvider can implement to reload its configuration on demand, e.g. to
+// fetch the latest flag definitions from a remote source without restarting, see RefreshProvider.
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// FlagChecker is an optional interface a FeatureProvider can implement to report whether a flag exists without
+// evaluating it, e.g. for UIs deciding which flags to evaluate.
+type FlagChecker interface {
+	FlagExists(ctx context.Context, flag string) (bool, error)
+}
+
+// FlagLister is an optional interface a FeatureProvider can implement to enumerate the keys of its flags, e.g. for
+// admin dashboards.
+type FlagLister interface {
+	ListFlags(ctx context.Context) ([]string, error)
+}
+
+// providerMetadata returns the metadata of the provider, enriched with its version and capabilities if it implements
+// MetadataExtension
+func providerMetadata(provider FeatureProvider) Metadata {
+	metadata := provider.Metadata()
+	if extension, ok := provider.(MetadataExtension); ok {
+		metadata.Version = extension.Version()
+		metadata = metadata.WithCapabilities(extension.Capabilities()...)
+	}
+	return metadata
 }
 
 // TrackingEventDetails provides a tracking details with float64 value
@@ -216,6 +216,80 @@ func (mr *MockTrackerMockRecorder) Track(ctx, trackingEventName, evaluationConte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockTracker)(nil).Track), ctx, trackingEventName, evaluationContext, details)
 }
 
+// MockHealthChecker is a mock of HealthChecker interface.
+type MockHealthChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthCheckerMockRecorder
+}
+
+// MockHealthCheckerMockRecorder is the mock recorder for MockHealthChecker.
+type MockHealthCheckerMockRecorder struct {
+	mock *MockHealthChecker
+}
+
+// NewMockHealthChecker creates a new mock instance.
+func NewMockHealthChecker(ctrl *gomock.Controller) *MockHealthChecker {
+	mock := &MockHealthChecker{ctrl: ctrl}
+	mock.recorder = &MockHealthCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthChecker) EXPECT() *MockHealthCheckerMockRecorder {
+	return m.recorder
+}
+
+// HealthCheck mocks base method.
+func (m *MockHealthChecker) HealthCheck(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthCheck", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockHealthCheckerMockRecorder) HealthCheck(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockHealthChecker)(nil).HealthCheck), ctx)
+}
+
+// MockBatchEvaluator is a mock of BatchEvaluator interface.
+type MockBatchEvaluator struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchEvaluatorMockRecorder
+}
+
+// MockBatchEvaluatorMockRecorder is the mock recorder for MockBatchEvaluator.
+type MockBatchEvaluatorMockRecorder struct {
+	mock *MockBatchEvaluator
+}
+
+// NewMockBatchEvaluator creates a new mock instance.
+func NewMockBatchEvaluator(ctrl *gomock.Controller) *MockBatchEvaluator {
+	mock := &MockBatchEvaluator{ctrl: ctrl}
+	mock.recorder = &MockBatchEvaluatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchEvaluator) EXPECT() *MockBatchEvaluatorMockRecorder {
+	return m.recorder
+}
+
+// BatchEvaluation mocks base method.
+func (m *MockBatchEvaluator) BatchEvaluation(ctx context.Context, requests []BatchFlagRequest) []InterfaceResolutionDetail {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchEvaluation", ctx, requests)
+	ret0, _ := ret[0].([]InterfaceResolutionDetail)
+	return ret0
+}
+
+// BatchEvaluation indicates an expected call of BatchEvaluation.
+func (mr *MockBatchEvaluatorMockRecorder) BatchEvaluation(ctx, requests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchEvaluation", reflect.TypeOf((*MockBatchEvaluator)(nil).BatchEvaluation), ctx, requests)
+}
+
 // MockEventHandler is a mock of EventHandler interface.
 type MockEventHandler struct {
 	ctrl     *gomock.Controller
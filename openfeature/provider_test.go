@@ -2,7 +2,9 @@ package openfeature
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -118,3 +120,216 @@ func TestTrackingEventDetails_Add(t *testing.T) {
 		})
 	}
 }
+
+// versionedProvider reports its version and capabilities through MetadataExtension
+type versionedProvider struct {
+	NoopProvider
+}
+
+func (p versionedProvider) Version() string {
+	return "1.2.3"
+}
+
+func (p versionedProvider) Capabilities() []string {
+	return []string{TrackingCapability, FlagMetadataCapability}
+}
+
+func TestMetadataExtension(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	// the hook records the provider metadata given to the Before stage
+	var metadata []Metadata
+	hook := NewMockHook(gomock.NewController(t))
+	hook.EXPECT().Before(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, hookContext HookContext, _ HookHints) (*EvaluationContext, error) {
+			metadata = append(metadata, hookContext.ProviderMetadata())
+			return nil, nil
+		}).Times(2)
+	hook.EXPECT().After(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	hook.EXPECT().Finally(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	if err := SetNamedProviderAndWait("versioned", versionedProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := SetNamedProviderAndWait("plain", NoopProvider{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	NewClient("versioned").Boolean(context.Background(), "flag", false, EvaluationContext{}, WithHooks(hook))
+	NewClient("plain").Boolean(context.Background(), "flag", false, EvaluationContext{}, WithHooks(hook))
+
+	expectedCapabilities := []string{TrackingCapability, FlagMetadataCapability}
+	if metadata[0].Version != "1.2.3" || !reflect.DeepEqual(metadata[0].Capabilities(), expectedCapabilities) {
+		t.Errorf("expected version 1.2.3 with capabilities %v, got %q with %v",
+			expectedCapabilities, metadata[0].Version, metadata[0].Capabilities())
+	}
+	if metadata[1].Version != "" || metadata[1].Capabilities() != nil {
+		t.Errorf("expected no version nor capabilities, got %q with %v", metadata[1].Version, metadata[1].Capabilities())
+	}
+
+	if NamedProviderMetadata("versioned") != metadata[0] {
+		t.Errorf("expected named provider metadata %v, got %v", metadata[0], NamedProviderMetadata("versioned"))
+	}
+}
+
+// tenantProvider requires the tenant_id evaluation context field
+type tenantProvider struct {
+	*MockFeatureProvider
+}
+
+func (p tenantProvider) RequiredContextFields() []string {
+	return []string{"tenant_id", TargetingKey}
+}
+
+func TestContextRequirements(t *testing.T) {
+	newTenantClient := func(t *testing.T) (*Client, tenantProvider) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		provider := tenantProvider{MockFeatureProvider: NewMockFeatureProvider(ctrl)}
+		provider.EXPECT().Metadata().AnyTimes()
+		provider.EXPECT().Hooks().AnyTimes()
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Return(provider, nil, EvaluationContext{}, nil)
+		return newClient("test-client", mockEvaluationApi, mockClientApi), provider
+	}
+
+	t.Run("missing field", func(t *testing.T) {
+		client, provider := newTenantClient(t)
+		provider.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		details, err := client.BooleanValueDetails(context.Background(), "flag", true, NewEvaluationContext("user", nil))
+		if !strings.Contains(fmt.Sprint(err), "tenant_id") {
+			t.Errorf("expected an error naming the missing field, got %v", err)
+		}
+		if details.Value != true {
+			t.Error("expected the default value")
+		}
+		if code := errorCode(err); code != InvalidContextCode {
+			t.Errorf("expected a %s error, got %s", InvalidContextCode, code)
+		}
+	})
+
+	t.Run("missing targeting key", func(t *testing.T) {
+		client, provider := newTenantClient(t)
+		provider.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := client.BooleanValue(context.Background(), "flag", true,
+			NewTargetlessEvaluationContext(map[string]interface{}{"tenant_id": "acme"}))
+		if code := errorCode(err); code != TargetingKeyMissingCode {
+			t.Errorf("expected a %s error, got %v", TargetingKeyMissingCode, err)
+		}
+	})
+
+	t.Run("present fields", func(t *testing.T) {
+		client, provider := newTenantClient(t)
+		provider.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(BoolResolutionDetail{Value: false})
+
+		value, err := client.BooleanValue(context.Background(), "flag", true,
+			NewEvaluationContext("user", map[string]interface{}{"tenant_id": "acme"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value != false {
+			t.Error("expected the resolved value")
+		}
+	})
+}
+
+func TestFlattenNested(t *testing.T) {
+	tests := map[string]struct {
+		flatCtx  FlattenedContext
+		expected FlattenedContext
+	}{
+		"nested maps": {
+			flatCtx: FlattenedContext{
+				TargetingKey: "user",
+				"user":       map[string]interface{}{"email": "user@example.com", "address": map[string]string{"city": "Paris"}},
+			},
+			expected: FlattenedContext{TargetingKey: "user", "user.email": "user@example.com", "user.address.city": "Paris"},
+		},
+		"explicit keys win over flattened keys": {
+			flatCtx: FlattenedContext{
+				"user.email": "explicit@example.com",
+				"user":       map[string]interface{}{"email": "nested@example.com", "plan": "premium"},
+			},
+			expected: FlattenedContext{"user.email": "explicit@example.com", "user.plan": "premium"},
+		},
+		"the lexically smallest attribute wins conflicting flattened keys": {
+			flatCtx: FlattenedContext{
+				"a":   map[string]interface{}{"b.c": "from a"},
+				"a.b": map[string]interface{}{"c": "from a.b"},
+			},
+			expected: FlattenedContext{"a.b.c": "from a"},
+		},
+		"arrays are kept as is": {
+			flatCtx: FlattenedContext{
+				"groups": []interface{}{map[string]interface{}{"name": "admins"}, "users"},
+				"scores": [2]int{1, 2},
+			},
+			expected: FlattenedContext{
+				"groups": []interface{}{map[string]interface{}{"name": "admins"}, "users"},
+				"scores": [2]int{1, 2},
+			},
+		},
+		"empty nested maps produce no keys": {
+			flatCtx:  FlattenedContext{"user": map[string]interface{}{}, "plan": "free"},
+			expected: FlattenedContext{"plan": "free"},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			if flattened := FlattenNested(test.flatCtx); !reflect.DeepEqual(flattened, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, flattened)
+			}
+		})
+	}
+}
+
+// flatProvider receives flattened contexts with nested maps flattened into dotted keys
+type flatProvider struct {
+	*MockFeatureProvider
+}
+
+func (p flatProvider) FlattenNestedContext() bool {
+	return true
+}
+
+func TestContextFlattening(t *testing.T) {
+	evalCtx := NewEvaluationContext("user", map[string]interface{}{
+		"user": map[string]interface{}{"email": "user@example.com"},
+	})
+
+	t.Run("flattens nested maps for providers implementing ContextFlattening", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClientApi := NewMockclientEvent(ctrl)
+		mockClientApi.EXPECT().State(gomock.Any()).AnyTimes().Return(ReadyState)
+		mockEvaluationApi := NewMockevaluationImpl(ctrl)
+		provider := flatProvider{MockFeatureProvider: NewMockFeatureProvider(ctrl)}
+		provider.EXPECT().Metadata().AnyTimes()
+		provider.EXPECT().Hooks().AnyTimes()
+		mockEvaluationApi.EXPECT().ForEvaluation(gomock.Any()).Return(provider, nil, EvaluationContext{}, nil)
+		client := newClient("test-client", mockEvaluationApi, mockClientApi)
+
+		provider.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false,
+			FlattenedContext{TargetingKey: "user", "user.email": "user@example.com"})
+		if _, err := client.BooleanValue(context.Background(), "flag", false, evalCtx); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("keeps the nested structure for other providers", func(t *testing.T) {
+		mocks := hydratedMocksForClientTests(t, 1)
+		client := newClient("test-client", mocks.evaluationAPI, mocks.clientHandlerAPI)
+
+		mocks.providerAPI.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, FlattenedContext{
+			TargetingKey: "user", "user": map[string]interface{}{"email": "user@example.com"},
+		})
+		if _, err := client.BooleanValue(context.Background(), "flag", false, evalCtx); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -14,11 +14,12 @@ func newProviderRef(provider FeatureProvider) providerReference {
 }
 
 // providerReference is a helper struct to store FeatureProvider along with their
-// shutdown semaphore
+// shutdown semaphore and health poller
 type providerReference struct {
 	featureProvider   FeatureProvider
 	kind              reflect.Kind
 	shutdownSemaphore chan interface{}
+	healthPoller      *healthPoller
 }
 
 func (pr providerReference) equals(other providerReference) bool {
@@ -32,12 +32,56 @@ type ResolutionError struct {
 	// this effectively emulates an enum
 	code    ErrorCode
 	message string
+	// cause is the error this ResolutionError originates from, e.g. the joined hook errors of an evaluation
+	cause error
+	// details carries provider specific information about the error
+	details map[string]interface{}
 }
 
 func (r ResolutionError) Error() string {
 	return fmt.Sprintf("%s: %s", r.code, r.message)
 }
 
+// Unwrap returns the error this ResolutionError originates from, if any, allowing errors.Is & errors.As to inspect it
+func (r ResolutionError) Unwrap() error {
+	return r.cause
+}
+
+// Is reports whether the target is the sentinel error of the resolution error's code, e.g. FlagNotFoundError for
+// FLAG_NOT_FOUND, so that errors.Is matches resolution errors by code
+func (r ResolutionError) Is(target error) bool {
+	sentinel, ok := codeSentinels[r.code]
+	return ok && target == sentinel
+}
+
+// Code returns the error code of the resolution error
+func (r ResolutionError) Code() ErrorCode {
+	return r.code
+}
+
+// Details returns the provider specific details of the resolution error, if any
+func (r ResolutionError) Details() map[string]interface{} {
+	return r.details
+}
+
+// WithDetails returns a copy of the resolution error carrying the given provider specific details, e.g. the upstream
+// status of a remote flag service. The details are reported in EvaluationDetails.ErrorDetails.
+func (r ResolutionError) WithDetails(details map[string]interface{}) ResolutionError {
+	r.details = details
+	return r
+}
+
+// newHookResolutionError constructs a resolution error with code GENERAL wrapping the joined errors of the hooks
+// failing an evaluation, the first of which occurred in the given stage
+func newHookResolutionError(stage HookStage, errs ...error) ResolutionError {
+	joined := errors.Join(errs...)
+	return ResolutionError{
+		code:    GeneralCode,
+		message: fmt.Sprintf("%s hook: %s", stage, joined),
+		cause:   joined,
+	}
+}
+
 // NewProviderNotReadyResolutionError constructs a resolution error with code PROVIDER_NOT_READY
 //
 // Explanation - The value was resolved before the provider was ready.
@@ -108,6 +152,42 @@ func NewGeneralResolutionError(msg string) ResolutionError {
 	}
 }
 
+// HookPanicError represents a panic recovered from a hook stage.
+type HookPanicError struct {
+	Stage HookStage   // Field to store the stage of the panicking hook
+	Value interface{} // Field to store the value the hook panicked with
+	Stack []byte      // Field to store the stack trace of the panicking goroutine
+}
+
+// Error implements the error interface for HookPanicError.
+func (e *HookPanicError) Error() string {
+	return fmt.Sprintf("%s hook panicked: %v", e.Stage, e.Value)
+}
+
+// Unwrap returns the value the hook panicked with, if it is an error.
+func (e *HookPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// ProviderPanicError represents a panic recovered from a provider resolving a flag.
+type ProviderPanicError struct {
+	Provider string      // Field to store the name of the panicking provider
+	Value    interface{} // Field to store the value the provider panicked with
+	Stack    []byte      // Field to store the stack trace of the panicking goroutine
+}
+
+// Error implements the error interface for ProviderPanicError.
+func (e *ProviderPanicError) Error() string {
+	return fmt.Sprintf("provider %s panicked: %v", e.Provider, e.Value)
+}
+
+// Unwrap returns the value the provider panicked with, if it is an error.
+func (e *ProviderPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
 // ProviderInitError represents an error that occurs during provider initialization.
 type ProviderInitError struct {
 	ErrorCode ErrorCode // Field to store the specific error code
@@ -124,4 +204,47 @@ var (
 	ProviderNotReadyError = errors.New("provider not yet initialized")
 	// ProviderFatalError signifies that an operation failed because the provider is in a FATAL state.
 	ProviderFatalError = errors.New("provider is in an irrecoverable error state")
+	// HookTimeoutError signifies that a hook stage did not complete within the configured hook timeout.
+	HookTimeoutError = errors.New("hook timed out")
+	// ProviderTimeoutError signifies that the provider did not resolve a flag within the timeout of WithProviderTimeout.
+	ProviderTimeoutError = errors.New("provider timed out")
+	// VariantForcingUnsupportedError signifies that a variant was forced with a provider not implementing VariantForcer.
+	VariantForcingUnsupportedError = errors.New("provider does not support forcing variants")
+	// FlagCheckingUnsupportedError signifies that the existence of a flag was checked with a provider not implementing
+	// FlagChecker.
+	FlagCheckingUnsupportedError = errors.New("provider does not support checking the existence of flags")
+	// FlagListingUnsupportedError signifies that the flags were listed with a provider not implementing FlagLister.
+	FlagListingUnsupportedError = errors.New("provider does not support listing flags")
+	// HookLimitExceededError signifies that an evaluation had more hooks than the limit of WithMaxHooks.
+	HookLimitExceededError = errors.New("hook limit exceeded")
+	// RefreshUnsupportedError signifies that a provider not implementing Refresher was refreshed.
+	RefreshUnsupportedError = errors.New("provider does not support refreshing its configuration")
+)
+
+// Resolution errors match the sentinel error of their code with errors.Is, the PROVIDER_NOT_READY and PROVIDER_FATAL
+// codes matching ProviderNotReadyError and ProviderFatalError respectively.
+var (
+	// FlagNotFoundError is matched by resolution errors with the FLAG_NOT_FOUND code.
+	FlagNotFoundError = errors.New("flag not found")
+	// ParseError is matched by resolution errors with the PARSE_ERROR code.
+	ParseError = errors.New("parse error")
+	// TypeMismatchError is matched by resolution errors with the TYPE_MISMATCH code.
+	TypeMismatchError = errors.New("type mismatch")
+	// TargetingKeyMissingError is matched by resolution errors with the TARGETING_KEY_MISSING code.
+	TargetingKeyMissingError = errors.New("targeting key missing")
+	// InvalidContextError is matched by resolution errors with the INVALID_CONTEXT code.
+	InvalidContextError = errors.New("invalid context")
+	// GeneralError is matched by resolution errors with the GENERAL code.
+	GeneralError = errors.New("general error")
 )
+
+var codeSentinels = map[ErrorCode]error{
+	ProviderNotReadyCode:    ProviderNotReadyError,
+	ProviderFatalCode:       ProviderFatalError,
+	FlagNotFoundCode:        FlagNotFoundError,
+	ParseErrorCode:          ParseError,
+	TypeMismatchCode:        TypeMismatchError,
+	TargetingKeyMissingCode: TargetingKeyMissingError,
+	InvalidContextCode:      InvalidContextError,
+	GeneralCode:             GeneralError,
+}
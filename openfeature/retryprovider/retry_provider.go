@@ -0,0 +1,218 @@
+package retryprovider
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 50 * time.Millisecond
+	defaultMaxBackoff     = time.Second
+)
+
+// RetryProvider is a FeatureProvider decorator retrying the resolutions of the underlying provider failing with a
+// retryable error code, waiting for an exponential backoff with jitter between attempts. Retries stop early when the
+// context is done, or would be done before the next attempt.
+//
+// By default, GENERAL and PROVIDER_NOT_READY errors are retried up to 3 attempts. Metadata, hooks, initialization,
+// shutdown and events of the underlying provider are passed through unchanged.
+type RetryProvider struct {
+	provider       openfeature.FeatureProvider
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	jitter         time.Duration
+	retryable      []openfeature.ErrorCode
+}
+
+// interface guards to ensure that RetryProvider passes through the initialization, shutdown and events of its provider
+var (
+	_ openfeature.StateHandler = (*RetryProvider)(nil)
+	_ openfeature.EventHandler = (*RetryProvider)(nil)
+)
+
+// Option configures a RetryProvider
+type Option func(*RetryProvider)
+
+// WithMaxAttempts sets the maximum number of attempts of a resolution, including the first one
+func WithMaxAttempts(attempts int) Option {
+	return func(p *RetryProvider) {
+		p.maxAttempts = attempts
+	}
+}
+
+// WithBackoff sets the backoff before the first retry, doubling for every subsequent retry up to the maximum backoff
+func WithBackoff(initial time.Duration, maximum time.Duration) Option {
+	return func(p *RetryProvider) {
+		p.initialBackoff = initial
+		p.maxBackoff = maximum
+	}
+}
+
+// WithJitter sets the upper bound of a random duration added to every backoff
+func WithJitter(jitter time.Duration) Option {
+	return func(p *RetryProvider) {
+		p.jitter = jitter
+	}
+}
+
+// WithRetryableCodes sets the error codes of the resolutions to retry, replacing the default GENERAL and
+// PROVIDER_NOT_READY codes
+func WithRetryableCodes(codes ...openfeature.ErrorCode) Option {
+	return func(p *RetryProvider) {
+		p.retryable = codes
+	}
+}
+
+// NewRetryProvider creates a RetryProvider retrying the resolutions of the given provider
+func NewRetryProvider(provider openfeature.FeatureProvider, options ...Option) *RetryProvider {
+	p := &RetryProvider{
+		provider:       provider,
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		retryable:      []openfeature.ErrorCode{openfeature.GeneralCode, openfeature.ProviderNotReadyCode},
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// Metadata returns the metadata of the underlying provider
+func (p *RetryProvider) Metadata() openfeature.Metadata {
+	return p.provider.Metadata()
+}
+
+func (p *RetryProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	return retry(ctx, p,
+		func() openfeature.BoolResolutionDetail {
+			return p.provider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.BoolResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (p *RetryProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	return retry(ctx, p,
+		func() openfeature.StringResolutionDetail {
+			return p.provider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.StringResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (p *RetryProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	return retry(ctx, p,
+		func() openfeature.FloatResolutionDetail {
+			return p.provider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.FloatResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (p *RetryProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	return retry(ctx, p,
+		func() openfeature.IntResolutionDetail {
+			return p.provider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.IntResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+func (p *RetryProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return retry(ctx, p,
+		func() openfeature.InterfaceResolutionDetail {
+			return p.provider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+		},
+		func(detail openfeature.InterfaceResolutionDetail) openfeature.ProviderResolutionDetail {
+			return detail.ProviderResolutionDetail
+		},
+	)
+}
+
+// Hooks returns the hooks of the underlying provider
+func (p *RetryProvider) Hooks() []openfeature.Hook {
+	return p.provider.Hooks()
+}
+
+// Init initializes the underlying provider if it implements openfeature.StateHandler
+func (p *RetryProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	if handler, ok := p.provider.(openfeature.StateHandler); ok {
+		return handler.Init(evaluationContext)
+	}
+	return nil
+}
+
+// Shutdown shuts down the underlying provider if it implements openfeature.StateHandler
+func (p *RetryProvider) Shutdown() {
+	if handler, ok := p.provider.(openfeature.StateHandler); ok {
+		handler.Shutdown()
+	}
+}
+
+// EventChannel returns the event channel of the underlying provider, or a nil channel if it does not implement
+// openfeature.EventHandler
+func (p *RetryProvider) EventChannel() <-chan openfeature.Event {
+	if handler, ok := p.provider.(openfeature.EventHandler); ok {
+		return handler.EventChannel()
+	}
+	return nil
+}
+
+// retry resolves with the evaluate function until the resolution does not fail with a retryable error code, the
+// attempts are exhausted or the context is done, returning the last resolution
+func retry[T any](
+	ctx context.Context, p *RetryProvider, evaluate func() T, detail func(T) openfeature.ProviderResolutionDetail,
+) T {
+	result := evaluate()
+	for attempt := 1; attempt < p.maxAttempts; attempt++ {
+		code := detail(result).ResolutionDetail().ErrorCode
+		if code == "" || !slices.Contains(p.retryable, code) {
+			return result
+		}
+
+		backoff := p.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && deadline.Sub(openfeature.CurrentClock().Now()) < backoff {
+			return result
+		}
+
+		timer := openfeature.CurrentClock().NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result
+		case <-timer.C():
+		}
+
+		result = evaluate()
+	}
+	return result
+}
+
+// backoff returns the wait before the given retry, starting at 1
+func (p *RetryProvider) backoff(retry int) time.Duration {
+	backoff := p.initialBackoff
+	for i := 1; i < retry && backoff < p.maxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.maxBackoff)
+
+	if p.jitter > 0 {
+		backoff += time.Duration(rand.Int63n(int64(p.jitter)))
+	}
+	return backoff
+}
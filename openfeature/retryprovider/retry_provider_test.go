@@ -0,0 +1,167 @@
+package retryprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// failingProvider fails its boolean resolutions with the given error until the given number of attempts, succeeding
+// afterwards
+type failingProvider struct {
+	openfeature.NoopProvider
+	failures int
+	err      openfeature.ResolutionError
+	attempts *int
+	events   chan openfeature.Event
+}
+
+func newFailingProvider(failures int, err openfeature.ResolutionError) failingProvider {
+	return failingProvider{failures: failures, err: err, attempts: new(int), events: make(chan openfeature.Event, 1)}
+}
+
+func (p failingProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: "failingProvider"}
+}
+
+func (p failingProvider) BooleanEvaluation(context.Context, string, bool, openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	*p.attempts++
+	if *p.attempts <= p.failures {
+		return openfeature.BoolResolutionDetail{
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{ResolutionError: p.err, Reason: openfeature.ErrorReason},
+		}
+	}
+	return openfeature.BoolResolutionDetail{
+		Value:                    true,
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason},
+	}
+}
+
+func (p failingProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+func TestRetryProvider_Evaluation(t *testing.T) {
+	general := openfeature.NewGeneralResolutionError("transient failure")
+	backoff := WithBackoff(time.Millisecond, 4*time.Millisecond)
+
+	tests := map[string]struct {
+		provider failingProvider
+		options  []Option
+		attempts int
+		success  bool
+	}{
+		"retryable errors are retried until success": {
+			provider: newFailingProvider(2, general),
+			attempts: 3,
+			success:  true,
+		},
+		"retries stop after the maximum attempts": {
+			provider: newFailingProvider(5, general),
+			options:  []Option{WithMaxAttempts(4)},
+			attempts: 4,
+		},
+		"non-retryable errors return immediately": {
+			provider: newFailingProvider(1, openfeature.NewFlagNotFoundResolutionError("missing")),
+			attempts: 1,
+		},
+		"type mismatches return immediately": {
+			provider: newFailingProvider(1, openfeature.NewTypeMismatchResolutionError("mismatch")),
+			attempts: 1,
+		},
+		"retryable codes are configurable": {
+			provider: newFailingProvider(1, openfeature.NewFlagNotFoundResolutionError("missing")),
+			options:  []Option{WithRetryableCodes(openfeature.FlagNotFoundCode)},
+			attempts: 2,
+			success:  true,
+		},
+		"successful resolutions are not retried": {
+			provider: newFailingProvider(0, general),
+			attempts: 1,
+			success:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider := NewRetryProvider(test.provider, append([]Option{backoff, WithJitter(time.Millisecond)}, test.options...)...)
+
+			resolution := provider.BooleanEvaluation(context.Background(), "flag", false, openfeature.FlattenedContext{})
+			if *test.provider.attempts != test.attempts {
+				t.Errorf("expected %d attempts, got %d", test.attempts, *test.provider.attempts)
+			}
+			if success := resolution.Error() == nil; success != test.success {
+				t.Errorf("expected success to be %t, got resolution error %v", test.success, resolution.Error())
+			}
+		})
+	}
+}
+
+func TestRetryProvider_ContextDeadline(t *testing.T) {
+	t.Run("no retry is attempted past the deadline", func(t *testing.T) {
+		failing := newFailingProvider(5, openfeature.NewGeneralResolutionError("transient failure"))
+		provider := NewRetryProvider(failing, WithMaxAttempts(5), WithBackoff(time.Second, time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		provider.BooleanEvaluation(ctx, "flag", false, openfeature.FlattenedContext{})
+		if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+			t.Errorf("expected the resolution to return without waiting for the backoff, took %s", elapsed)
+		}
+		if *failing.attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", *failing.attempts)
+		}
+	})
+
+	t.Run("cancellation stops the backoff", func(t *testing.T) {
+		failing := newFailingProvider(5, openfeature.NewGeneralResolutionError("transient failure"))
+		provider := NewRetryProvider(failing, WithMaxAttempts(5), WithBackoff(time.Second, time.Second))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+		}()
+
+		start := time.Now()
+		provider.BooleanEvaluation(ctx, "flag", false, openfeature.FlattenedContext{})
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("expected the cancellation to stop the backoff, took %s", elapsed)
+		}
+		if *failing.attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", *failing.attempts)
+		}
+	})
+}
+
+func TestRetryProvider_Backoff(t *testing.T) {
+	provider := NewRetryProvider(openfeature.NoopProvider{}, WithBackoff(10*time.Millisecond, 35*time.Millisecond))
+
+	for retry, expected := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 35 * time.Millisecond, 10: 35 * time.Millisecond} {
+		if backoff := provider.backoff(retry); backoff != expected {
+			t.Errorf("expected a backoff of %s for retry %d, got %s", expected, retry, backoff)
+		}
+	}
+}
+
+func TestRetryProvider_PassThrough(t *testing.T) {
+	failing := newFailingProvider(0, openfeature.ResolutionError{})
+	provider := NewRetryProvider(failing)
+
+	if name := provider.Metadata().Name; name != "failingProvider" {
+		t.Errorf("expected the metadata of the underlying provider, got %s", name)
+	}
+	if provider.EventChannel() != (<-chan openfeature.Event)(failing.events) {
+		t.Error("expected the event channel of the underlying provider")
+	}
+	if NewRetryProvider(openfeature.NoopProvider{}).EventChannel() != nil {
+		t.Error("expected no event channel for a provider without events")
+	}
+	if err := provider.Init(openfeature.EvaluationContext{}); err != nil {
+		t.Errorf("unexpected initialization error: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package routingprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// Route dispatches the evaluations of the flags matched by Match to Provider
+type Route struct {
+	Match    func(flag string) bool
+	Provider openfeature.FeatureProvider
+}
+
+// KeyPrefix returns a Route matcher for the flags whose key starts with the prefix, e.g. "mobile."
+func KeyPrefix(prefix string) func(flag string) bool {
+	return func(flag string) bool {
+		return strings.HasPrefix(flag, prefix)
+	}
+}
+
+// RoutingProvider is a FeatureProvider dispatching the evaluation of every flag to the provider of the first Route
+// matching the flag key, or to the default provider if no route matches. This allows consolidating providers of
+// separate backends behind a single client.
+//
+// The RoutingProvider initializes and shuts down all of its providers, and multiplexes their events. Hooks of the
+// routed providers are not run.
+type RoutingProvider struct {
+	routes          []Route
+	defaultProvider openfeature.FeatureProvider
+	events          chan openfeature.Event
+
+	mu       sync.Mutex
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// interface guards to ensure that RoutingProvider initializes its providers and multiplexes their events
+var (
+	_ openfeature.StateHandler = (*RoutingProvider)(nil)
+	_ openfeature.EventHandler = (*RoutingProvider)(nil)
+)
+
+// NewRoutingProvider creates a RoutingProvider dispatching flags with the given routes, in order, and unmatched flags
+// to the default provider. A nil default provider fails the evaluation of unmatched flags with FLAG_NOT_FOUND.
+func NewRoutingProvider(defaultProvider openfeature.FeatureProvider, routes ...Route) *RoutingProvider {
+	return &RoutingProvider{
+		routes:          routes,
+		defaultProvider: defaultProvider,
+		events:          make(chan openfeature.Event, 5),
+	}
+}
+
+// Metadata returns the RoutingProvider's metadata, naming all of the routed providers followed by the default
+// provider
+func (r *RoutingProvider) Metadata() openfeature.Metadata {
+	providers := r.providers()
+	names := make([]string, len(providers))
+	for i, provider := range providers {
+		names[i] = provider.Metadata().Name
+	}
+	return openfeature.Metadata{
+		Name: fmt.Sprintf("RoutingProvider(%s)", strings.Join(names, ", ")),
+	}
+}
+
+func (r *RoutingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	provider := r.route(flag)
+	if provider == nil {
+		return openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: unroutedDetail(flag)}
+	}
+	return provider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func (r *RoutingProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	provider := r.route(flag)
+	if provider == nil {
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: unroutedDetail(flag)}
+	}
+	return provider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func (r *RoutingProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	provider := r.route(flag)
+	if provider == nil {
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: unroutedDetail(flag)}
+	}
+	return provider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func (r *RoutingProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	provider := r.route(flag)
+	if provider == nil {
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: unroutedDetail(flag)}
+	}
+	return provider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func (r *RoutingProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	provider := r.route(flag)
+	if provider == nil {
+		return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: unroutedDetail(flag)}
+	}
+	return provider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// Hooks returns no hooks, the hooks of the routed providers are not run
+func (r *RoutingProvider) Hooks() []openfeature.Hook {
+	return []openfeature.Hook{}
+}
+
+// Init initializes all routed providers implementing openfeature.StateHandler, returning their joined errors, and
+// starts multiplexing the events of the routed providers implementing openfeature.EventHandler
+func (r *RoutingProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.shutdown == nil {
+		r.shutdown = make(chan struct{})
+		for _, provider := range r.providers() {
+			if handler, ok := provider.(openfeature.EventHandler); ok {
+				r.wg.Add(1)
+				go r.forward(handler.EventChannel(), r.shutdown)
+			}
+		}
+	}
+
+	var errs []error
+	for _, provider := range r.providers() {
+		if handler, ok := provider.(openfeature.StateHandler); ok {
+			if err := handler.Init(evaluationContext); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", provider.Metadata().Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down all routed providers implementing openfeature.StateHandler and stops multiplexing their events
+func (r *RoutingProvider) Shutdown() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.shutdown != nil {
+		close(r.shutdown)
+		r.wg.Wait()
+		r.shutdown = nil
+	}
+
+	for _, provider := range r.providers() {
+		if handler, ok := provider.(openfeature.StateHandler); ok {
+			handler.Shutdown()
+		}
+	}
+}
+
+// EventChannel returns the channel the events of all routed providers are multiplexed to
+func (r *RoutingProvider) EventChannel() <-chan openfeature.Event {
+	return r.events
+}
+
+func (r *RoutingProvider) forward(events <-chan openfeature.Event, shutdown <-chan struct{}) {
+	defer r.wg.Done()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			select {
+			case r.events <- event:
+			case <-shutdown:
+				return
+			}
+		case <-shutdown:
+			return
+		}
+	}
+}
+
+// route returns the provider of the first route matching the flag, otherwise the default provider
+func (r *RoutingProvider) route(flag string) openfeature.FeatureProvider {
+	for _, route := range r.routes {
+		if route.Match != nil && route.Match(flag) {
+			return route.Provider
+		}
+	}
+	return r.defaultProvider
+}
+
+// providers returns the providers of all routes followed by the default provider, skipping nil providers
+func (r *RoutingProvider) providers() []openfeature.FeatureProvider {
+	providers := make([]openfeature.FeatureProvider, 0, len(r.routes)+1)
+	for _, route := range r.routes {
+		if route.Provider != nil {
+			providers = append(providers, route.Provider)
+		}
+	}
+	if r.defaultProvider != nil {
+		providers = append(providers, r.defaultProvider)
+	}
+	return providers
+}
+
+func unroutedDetail(flag string) openfeature.ProviderResolutionDetail {
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewFlagNotFoundResolutionError(fmt.Sprintf("no provider is routed for flag %s", flag)),
+		Reason:          openfeature.ErrorReason,
+	}
+}
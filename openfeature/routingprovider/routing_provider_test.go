@@ -0,0 +1,132 @@
+package routingprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+// namedProvider is an InMemoryProvider with its own name, resolving "mobile.checkout", "web.checkout" and
+// "checkout" to the given value
+type namedProvider struct {
+	memprovider.InMemoryProvider
+	name   string
+	events chan openfeature.Event
+}
+
+func newNamedProvider(name string, value string) namedProvider {
+	flags := map[string]memprovider.InMemoryFlag{}
+	for _, key := range []string{"mobile.checkout", "web.checkout", "checkout"} {
+		flags[key] = memprovider.InMemoryFlag{
+			Key:            key,
+			State:          memprovider.Enabled,
+			DefaultVariant: "value",
+			Variants:       map[string]interface{}{"value": value},
+		}
+	}
+	return namedProvider{
+		InMemoryProvider: memprovider.NewInMemoryProvider(flags),
+		name:             name,
+		events:           make(chan openfeature.Event, 1),
+	}
+}
+
+func (p namedProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: p.name}
+}
+
+func (p namedProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+func TestRoutingProvider_Evaluation(t *testing.T) {
+	ctx := context.Background()
+	mobile, web, fallback := newNamedProvider("mobile", "m"), newNamedProvider("web", "w"), newNamedProvider("default", "d")
+
+	t.Run("flags are routed by key prefix", func(t *testing.T) {
+		routing := NewRoutingProvider(fallback,
+			Route{Match: KeyPrefix("mobile."), Provider: mobile},
+			Route{Match: KeyPrefix("web."), Provider: web})
+
+		tests := map[string]string{
+			"mobile.checkout": "m",
+			"web.checkout":    "w",
+		}
+		for flag, expected := range tests {
+			resolution := routing.StringEvaluation(ctx, flag, "", openfeature.FlattenedContext{})
+			if resolution.Error() != nil {
+				t.Fatalf("unexpected error for %s: %v", flag, resolution.Error())
+			}
+			if resolution.Value != expected {
+				t.Errorf("expected %s to resolve to %s, got %s", flag, expected, resolution.Value)
+			}
+		}
+	})
+
+	t.Run("unmatched flags fall through to the default provider", func(t *testing.T) {
+		routing := NewRoutingProvider(fallback, Route{Match: KeyPrefix("mobile."), Provider: mobile})
+
+		resolution := routing.StringEvaluation(ctx, "checkout", "", openfeature.FlattenedContext{})
+		if resolution.Error() != nil {
+			t.Fatalf("unexpected error: %v", resolution.Error())
+		}
+		if resolution.Value != "d" {
+			t.Errorf("expected the default provider's value, got %s", resolution.Value)
+		}
+	})
+
+	t.Run("the first matching route wins", func(t *testing.T) {
+		routing := NewRoutingProvider(fallback,
+			Route{Match: KeyPrefix("web."), Provider: web},
+			Route{Match: func(string) bool { return true }, Provider: mobile})
+
+		resolution := routing.StringEvaluation(ctx, "web.checkout", "", openfeature.FlattenedContext{})
+		if resolution.Value != "w" {
+			t.Errorf("expected the first matching route's value, got %s", resolution.Value)
+		}
+	})
+
+	t.Run("unmatched flags fail without a default provider", func(t *testing.T) {
+		routing := NewRoutingProvider(nil, Route{Match: KeyPrefix("mobile."), Provider: mobile})
+
+		resolution := routing.StringEvaluation(ctx, "checkout", "fallback", openfeature.FlattenedContext{})
+		if resolution.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode || resolution.Value != "fallback" {
+			t.Errorf("expected the default value with a FLAG_NOT_FOUND error, got %+v", resolution)
+		}
+	})
+}
+
+func TestRoutingProvider_Metadata(t *testing.T) {
+	routing := NewRoutingProvider(newNamedProvider("default", ""),
+		Route{Match: KeyPrefix("mobile."), Provider: newNamedProvider("mobile", "")},
+		Route{Match: KeyPrefix("web."), Provider: newNamedProvider("web", "")})
+
+	if name := routing.Metadata().Name; name != "RoutingProvider(mobile, web, default)" {
+		t.Errorf("expected the metadata to name the routed providers, got %s", name)
+	}
+}
+
+func TestRoutingProvider_Events(t *testing.T) {
+	mobile, fallback := newNamedProvider("mobile", ""), newNamedProvider("default", "")
+	routing := NewRoutingProvider(fallback, Route{Match: KeyPrefix("mobile."), Provider: mobile})
+
+	if err := routing.Init(openfeature.EvaluationContext{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer routing.Shutdown()
+
+	for _, provider := range []namedProvider{mobile, fallback} {
+		provider.events <- openfeature.Event{ProviderName: provider.name, EventType: openfeature.ProviderConfigChange}
+		select {
+		case event := <-routing.EventChannel():
+			if event.ProviderName != provider.name {
+				t.Errorf("expected the event of %s to be forwarded, got %s", provider.name, event.ProviderName)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the event of %s to be forwarded", provider.name)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Package streamingprovider helps authors of providers backed by a streaming configuration service, e.g. flagd,
+// emitting the provider's events as the configuration updates arrive.
+package streamingprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const (
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	// eventBufferSize bounds the events emitted ahead of their dispatch by the SDK
+	eventBufferSize = 10
+)
+
+// Update is a configuration update received from the stream, already applied by the provider to its flags
+type Update struct {
+	// FlagChanges are the keys of the flags changed by the update, empty if any flag may have changed
+	FlagChanges []string
+	// Message describes the update
+	Message string
+	// Metadata is the metadata of the PROVIDER_CONFIGURATION_CHANGED event of the update
+	Metadata map[string]interface{}
+}
+
+// Stream connects to the configuration service and sends the updates received to the channel, blocking until the
+// stream ends or the context is done. Its first update on every connection is the initial synchronization of the
+// configuration. It returns the error which ended the stream.
+type Stream func(ctx context.Context, updates chan<- Update) error
+
+// StreamingProvider consumes a Stream of configuration updates in the background, to be embedded in a
+// openfeature.FeatureProvider implementing the flag resolutions. It implements the initialization, shutdown and
+// events of the provider:
+//
+//   - the initialization connects to the stream and completes with the initial synchronization;
+//   - every later update emits a PROVIDER_CONFIGURATION_CHANGED event;
+//   - a stream ending with an error emits a PROVIDER_STALE event, the stream is reconnected with an exponential
+//     backoff, and the initial synchronization of the new connection emits a PROVIDER_READY event followed by a
+//     PROVIDER_CONFIGURATION_CHANGED event, as the configuration may have changed while disconnected.
+//
+// The initialization retries the connection until the initial synchronization succeeds, bound it with
+// openfeature.WithInitTimeout.
+type StreamingProvider struct {
+	name           string
+	stream         Stream
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	events         chan openfeature.Event
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// interface guards to ensure that StreamingProvider handles the initialization, shutdown and events of the provider
+var (
+	_ openfeature.ContextAwareStateHandler = (*StreamingProvider)(nil)
+	_ openfeature.EventHandler             = (*StreamingProvider)(nil)
+)
+
+// Option configures a StreamingProvider
+type Option func(*StreamingProvider)
+
+// WithBackoff sets the backoff before the first reconnection, doubling for every subsequent reconnection up to the
+// maximum backoff. The backoff is reset once a connection synchronizes. Defaults to 1 second up to 30 seconds.
+func WithBackoff(initial time.Duration, maximum time.Duration) Option {
+	return func(p *StreamingProvider) {
+		p.initialBackoff = initial
+		p.maxBackoff = maximum
+	}
+}
+
+// NewStreamingProvider creates a StreamingProvider consuming the stream, emitting the events on behalf of the provider
+// with the given name
+func NewStreamingProvider(name string, stream Stream, options ...Option) *StreamingProvider {
+	p := &StreamingProvider{
+		name:           name,
+		stream:         stream,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		events:         make(chan openfeature.Event, eventBufferSize),
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// Init connects to the stream and waits for its initial synchronization, see InitWithContext
+func (p *StreamingProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	return p.InitWithContext(context.Background(), evaluationContext)
+}
+
+// InitWithContext connects to the stream and waits for its initial synchronization, reconnecting with backoff until
+// it succeeds. It returns an error if the context is done first, stopping the stream.
+func (p *StreamingProvider) InitWithContext(ctx context.Context, _ openfeature.EvaluationContext) error {
+	p.mu.Lock()
+	if p.cancel != nil {
+		p.mu.Unlock()
+		return errors.New("streaming provider is already initialized")
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.cancel, p.done = cancel, done
+	p.mu.Unlock()
+
+	synced := make(chan struct{})
+	go p.run(runCtx, synced, done)
+
+	select {
+	case <-synced:
+		return nil
+	case <-ctx.Done():
+		p.Shutdown()
+		return fmt.Errorf("stream of provider %s did not synchronize: %w", p.name, context.Cause(ctx))
+	}
+}
+
+// Shutdown stops the stream, returning once its background goroutine exited. The provider can be initialized again.
+func (p *StreamingProvider) Shutdown() {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.cancel, p.done = nil, nil
+	p.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// EventChannel returns the channel of the events emitted as the updates arrive
+func (p *StreamingProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+// run consumes the stream until the context is done, reconnecting with backoff whenever the stream ends. The synced
+// channel is closed on the initial synchronization of the first connection, the done channel once run returns.
+func (p *StreamingProvider) run(ctx context.Context, synced chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	initialized, stale := false, false
+	backoff := p.initialBackoff
+	for {
+		updates := make(chan Update)
+		ended := make(chan error, 1)
+		go func() {
+			ended <- p.stream(ctx, updates)
+		}()
+
+		var err error
+		for err == nil {
+			select {
+			case update := <-updates:
+				backoff = p.initialBackoff
+				switch {
+				case !initialized:
+					// the SDK emits the PROVIDER_READY event of a successful initialization
+					initialized = true
+					close(synced)
+				case stale:
+					// initial synchronization of a reconnection
+					p.emit(ctx, openfeature.Event{
+						ProviderName:         p.name,
+						EventType:            openfeature.ProviderReady,
+						ProviderEventDetails: openfeature.ProviderEventDetails{Message: "stream reconnected"},
+					})
+					fallthrough
+				default:
+					p.emit(ctx, openfeature.Event{
+						ProviderName: p.name,
+						EventType:    openfeature.ProviderConfigChange,
+						ProviderEventDetails: openfeature.ProviderEventDetails{
+							Message:       update.Message,
+							FlagChanges:   update.FlagChanges,
+							EventMetadata: update.Metadata,
+						},
+					})
+				}
+				stale = false
+			case err = <-ended:
+				if err == nil {
+					err = errors.New("stream ended")
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		if initialized && !stale {
+			stale = true
+			p.emit(ctx, openfeature.Event{
+				ProviderName:         p.name,
+				EventType:            openfeature.ProviderStale,
+				ProviderEventDetails: openfeature.ProviderEventDetails{Message: fmt.Sprintf("stream disconnected: %v", err)},
+			})
+		}
+
+		timer := openfeature.CurrentClock().NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+		backoff = min(2*backoff, p.maxBackoff)
+	}
+}
+
+// emit sends the event, unless the context is done first
+func (p *StreamingProvider) emit(ctx context.Context, event openfeature.Event) {
+	select {
+	case p.events <- event:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,161 @@
+package streamingprovider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// fakeStream is a Stream serving its connections in turn, a connection failing once its script is played
+type fakeStream struct {
+	connections atomic.Int32
+	scripts     []func(ctx context.Context, updates chan<- Update) error
+}
+
+func (s *fakeStream) stream(ctx context.Context, updates chan<- Update) error {
+	connection := int(s.connections.Add(1)) - 1
+	if connection < len(s.scripts) {
+		return s.scripts[connection](ctx, updates)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// script sends the updates, failing the connection afterwards
+func script(err error, sent ...Update) func(ctx context.Context, updates chan<- Update) error {
+	return func(ctx context.Context, updates chan<- Update) error {
+		for _, update := range sent {
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err == nil {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return err
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan openfeature.Event) openfeature.Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("expected an event")
+		return openfeature.Event{}
+	}
+}
+
+func TestStreamingProvider(t *testing.T) {
+	t.Run("updates emit events and errors reconnect", func(t *testing.T) {
+		stream := &fakeStream{scripts: []func(context.Context, chan<- Update) error{
+			script(errors.New("connection reset"), Update{Message: "initial"}, Update{Message: "changed", FlagChanges: []string{"flag"}}),
+			script(nil, Update{Message: "resynchronized"}),
+		}}
+		provider := NewStreamingProvider("streaming", stream.stream, WithBackoff(time.Millisecond, time.Millisecond))
+		if err := provider.Init(openfeature.EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer provider.Shutdown()
+
+		expected := []struct {
+			eventType   openfeature.EventType
+			message     string
+			flagChanges []string
+		}{
+			{openfeature.ProviderConfigChange, "changed", []string{"flag"}},
+			{openfeature.ProviderStale, "stream disconnected: connection reset", nil},
+			{openfeature.ProviderReady, "stream reconnected", nil},
+			{openfeature.ProviderConfigChange, "resynchronized", nil},
+		}
+		for _, want := range expected {
+			event := nextEvent(t, provider.EventChannel())
+			if event.EventType != want.eventType || event.Message != want.message || !reflect.DeepEqual(event.FlagChanges, want.flagChanges) {
+				t.Errorf("expected a %s event %q %v, got %s %q %v", want.eventType, want.message, want.flagChanges,
+					event.EventType, event.Message, event.FlagChanges)
+			}
+			if event.ProviderName != "streaming" {
+				t.Errorf("expected the event on behalf of the provider, got %s", event.ProviderName)
+			}
+		}
+		if connections := stream.connections.Load(); connections != 2 {
+			t.Errorf("expected a single reconnection, got %d connections", connections)
+		}
+	})
+
+	t.Run("the initialization retries until the initial synchronization", func(t *testing.T) {
+		stream := &fakeStream{scripts: []func(context.Context, chan<- Update) error{
+			script(errors.New("unavailable")),
+			script(errors.New("unavailable")),
+			script(nil, Update{Message: "initial"}),
+		}}
+		provider := NewStreamingProvider("streaming", stream.stream, WithBackoff(time.Millisecond, time.Millisecond))
+		if err := provider.Init(openfeature.EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		provider.Shutdown()
+
+		if connections := stream.connections.Load(); connections != 3 {
+			t.Errorf("expected the connection to be retried until it synchronizes, got %d connections", connections)
+		}
+		select {
+		case event := <-provider.EventChannel():
+			t.Errorf("expected no event before the initial synchronization, got %s", event.EventType)
+		default:
+		}
+	})
+
+	t.Run("the initialization fails if the context is done first", func(t *testing.T) {
+		stream := &fakeStream{scripts: []func(context.Context, chan<- Update) error{script(nil)}}
+		provider := NewStreamingProvider("streaming", stream.stream)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := provider.InitWithContext(ctx, openfeature.EvaluationContext{}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the deadline of the context, got %v", err)
+		}
+	})
+
+	t.Run("events reach the handlers of the SDK", func(t *testing.T) {
+		stream := &fakeStream{scripts: []func(context.Context, chan<- Update) error{
+			script(nil, Update{Message: "initial"}, Update{Message: "changed", FlagChanges: []string{"flag"}}),
+		}}
+		provider := struct {
+			openfeature.NoopProvider
+			*StreamingProvider
+		}{StreamingProvider: NewStreamingProvider("streaming", stream.stream)}
+
+		changes := make(chan openfeature.EventDetails, 1)
+		handler := func(details openfeature.EventDetails) { changes <- details }
+		openfeature.AddNamedHandler(t.Name(), openfeature.ProviderConfigChange, &handler)
+		defer openfeature.RemoveNamedHandler(t.Name(), openfeature.ProviderConfigChange, &handler)
+		if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Cleanup(func() {
+			// unbind the domain of the test from the provider
+			if err := openfeature.SetNamedProviderAndWait(t.Name(), openfeature.NoopProvider{}); err != nil {
+				t.Errorf("unexpected error resetting the domain: %v", err)
+			}
+		})
+		defer provider.Shutdown()
+
+		select {
+		case details := <-changes:
+			if !reflect.DeepEqual(details.FlagChanges, []string{"flag"}) {
+				t.Errorf("expected the changed flags, got %v", details.FlagChanges)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a configuration change event")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EvaluationAttributes returns the attributes of the evaluation event of CreateEvaluationEvent as OpenTelemetry
+// attributes, sorted by key, e.g. to annotate spans or log records from custom hooks. The mapping of the evaluation
+// details is the one of CreateEvaluationEvent, keeping both in line with the semantic conventions.
+func EvaluationAttributes(hookContext openfeature.HookContext, details openfeature.InterfaceEvaluationDetails) []attribute.KeyValue {
+	event := CreateEvaluationEvent(hookContext, details)
+
+	keys := make([]string, 0, len(event.Attributes))
+	for key := range event.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]attribute.KeyValue, len(keys))
+	for i, key := range keys {
+		attributes[i] = KeyValue(key, event.Attributes[key])
+	}
+	return attributes
+}
+
+// KeyValue converts an attribute value to an OpenTelemetry attribute, falling back to the string representation of
+// values of other types than strings, booleans and numbers, e.g. to record evaluation context attributes
+func KeyValue(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case openfeature.ErrorCode:
+		return attribute.String(key, string(v))
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
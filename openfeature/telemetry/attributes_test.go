@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestEvaluationAttributes(t *testing.T) {
+	hookContext := openfeature.NewHookContext("test-flag", openfeature.Boolean, false,
+		openfeature.NewClientMetadata("test-client"), openfeature.Metadata{Name: "test-provider"},
+		openfeature.NewEvaluationContext("test-target-key", nil))
+
+	t.Run("successful evaluation", func(t *testing.T) {
+		details := openfeature.InterfaceEvaluationDetails{
+			Value: true,
+			EvaluationDetails: openfeature.EvaluationDetails{
+				FlagKey:  "test-flag",
+				FlagType: openfeature.Boolean,
+				ResolutionDetail: openfeature.ResolutionDetail{
+					Variant:      "on",
+					Reason:       openfeature.TargetingMatchReason,
+					FlagMetadata: openfeature.FlagMetadata{TelemetryFlagMetaFlagSetId: "set", TelemetryFlagMetaVersion: int64(3)},
+				},
+			},
+		}
+
+		expected := []attribute.KeyValue{
+			attribute.String(TelemetryContextID, "test-target-key"),
+			attribute.String(TelemetryReason, "targeting_match"),
+			attribute.String(TelemetryKey, "test-flag"),
+			attribute.String(TelemetryProvider, "test-provider"),
+			attribute.String(TelemetryFlagSetID, "set"),
+			attribute.String(TelemetryVariant, "on"),
+			attribute.Int64(TelemetryVersion, 3),
+		}
+		if attributes := EvaluationAttributes(hookContext, details); !reflect.DeepEqual(attributes, expected) {
+			t.Errorf("expected attributes %v, got %v", expected, attributes)
+		}
+	})
+
+	t.Run("errored evaluation", func(t *testing.T) {
+		details := openfeature.InterfaceEvaluationDetails{
+			Value: false,
+			EvaluationDetails: openfeature.EvaluationDetails{
+				FlagKey:  "test-flag",
+				FlagType: openfeature.Boolean,
+				ResolutionDetail: openfeature.ResolutionDetail{
+					Reason:       openfeature.ErrorReason,
+					ErrorCode:    openfeature.FlagNotFoundCode,
+					ErrorMessage: "flag not found",
+					FlagMetadata: openfeature.FlagMetadata{},
+				},
+			},
+		}
+
+		expected := []attribute.KeyValue{
+			attribute.String(TelemetryErrorCode, string(openfeature.FlagNotFoundCode)),
+			attribute.String(TelemetryContextID, "test-target-key"),
+			attribute.String(TelemetryErrorMsg, "flag not found"),
+			attribute.String(TelemetryReason, "error"),
+			attribute.String(TelemetryKey, "test-flag"),
+			attribute.String(TelemetryProvider, "test-provider"),
+		}
+		if attributes := EvaluationAttributes(hookContext, details); !reflect.DeepEqual(attributes, expected) {
+			t.Errorf("expected attributes %v, got %v", expected, attributes)
+		}
+	})
+}
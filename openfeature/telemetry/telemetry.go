@@ -46,8 +46,11 @@ func CreateEvaluationEvent(hookContext openfeature.HookContext, details openfeat
 		TelemetryProvider: hookContext.ProviderMetadata().Name,
 	}
 
-	if details.EvaluationDetails.ResolutionDetail.Reason != "" {
-		attributes[TelemetryReason] = strings.ToLower(string(details.ResolutionDetail.Reason))
+	if reason := details.EvaluationDetails.ResolutionDetail.Reason; reason.IsStandard() {
+		attributes[TelemetryReason] = strings.ToLower(string(reason))
+	} else if reason != "" {
+		// provider defined reasons are reported as is
+		attributes[TelemetryReason] = string(reason)
 	} else {
 		attributes[TelemetryReason] = strings.ToLower(string(openfeature.UnknownReason))
 	}
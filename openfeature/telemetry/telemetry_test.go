@@ -256,3 +256,27 @@ func TestCreateEvaluationEvent_1_4_7_WithUnknownReason(t *testing.T) {
 		t.Errorf("Expected evaluation reason to be '%s', got '%s'", strings.ToLower(string(openfeature.UnknownReason)), event.Attributes[TelemetryReason])
 	}
 }
+
+func TestCreateEvaluationEvent_WithProviderDefinedReason(t *testing.T) {
+	flagKey := "test-flag"
+
+	mockHookContext := openfeature.NewHookContext(flagKey, openfeature.Boolean, true,
+		openfeature.NewClientMetadata("test-client"), openfeature.Metadata{Name: "test-provider"}, openfeature.EvaluationContext{})
+
+	mockDetails := openfeature.InterfaceEvaluationDetails{
+		Value: true,
+		EvaluationDetails: openfeature.EvaluationDetails{
+			FlagKey: flagKey,
+			ResolutionDetail: openfeature.ResolutionDetail{
+				Reason:       "Holdout",
+				FlagMetadata: openfeature.FlagMetadata{},
+			},
+		},
+	}
+
+	event := CreateEvaluationEvent(mockHookContext, mockDetails)
+
+	if event.Attributes[TelemetryReason] != "Holdout" {
+		t.Errorf("Expected the provider defined reason to be reported as is, got '%s'", event.Attributes[TelemetryReason])
+	}
+}
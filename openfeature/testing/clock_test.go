@@ -0,0 +1,157 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+func TestFakeClock_CacheExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer openfeature.WithClock(clock)()
+
+	var resolutions int
+	evaluator := func(flag memprovider.InMemoryFlag, _ openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		resolutions++
+		return true, openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason}
+	}
+	provider := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"boolFlag": {
+			Key:              "boolFlag",
+			State:            memprovider.Enabled,
+			DefaultVariant:   "on",
+			Variants:         map[string]interface{}{"on": true, "off": false},
+			ContextEvaluator: &evaluator,
+		},
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatal(err)
+	}
+	client := openfeature.NewClient(t.Name()).WithEvaluationCache(openfeature.CacheConfig{TTL: time.Minute})
+
+	evaluate := func() {
+		if _, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	evaluate()
+	clock.Advance(59 * time.Second)
+	evaluate()
+	if resolutions != 1 {
+		t.Fatalf("expected the resolution to be cached within the TTL, got %d resolutions", resolutions)
+	}
+
+	clock.Advance(time.Second)
+	evaluate()
+	if resolutions != 2 {
+		t.Errorf("expected the resolution to expire after the TTL, got %d resolutions", resolutions)
+	}
+}
+
+func TestFakeClock_Timers(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	timer := clock.NewTimer(time.Second)
+	stopped := clock.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Error("expected a pending timer to be stopped")
+	}
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("expected the timer not to fire before its duration")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	select {
+	case <-timer.C():
+	default:
+		t.Fatal("expected the timer to fire once its duration elapsed")
+	}
+	select {
+	case <-stopped.C():
+		t.Error("expected the stopped timer not to fire")
+	default:
+	}
+	if timer.Stop() {
+		t.Error("expected stopping a fired timer to report false")
+	}
+}
+
+func TestFakeClock_CacheCreatedBeforeTheClock(t *testing.T) {
+	var resolutions int
+	evaluator := func(flag memprovider.InMemoryFlag, _ openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		resolutions++
+		return true, openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason}
+	}
+	provider := memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{
+		"boolFlag": {
+			Key:              "boolFlag",
+			State:            memprovider.Enabled,
+			DefaultVariant:   "on",
+			Variants:         map[string]interface{}{"on": true, "off": false},
+			ContextEvaluator: &evaluator,
+		},
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatal(err)
+	}
+	client := openfeature.NewClient(t.Name()).WithEvaluationCache(openfeature.CacheConfig{TTL: time.Minute})
+
+	clock := NewFakeClock(time.Now())
+	defer openfeature.WithClock(clock)()
+
+	evaluate := func() {
+		if _, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	evaluate()
+	clock.Advance(time.Minute)
+	evaluate()
+	if resolutions != 2 {
+		t.Errorf("expected the cache to expire its entries by the clock replaced afterwards, got %d resolutions", resolutions)
+	}
+}
+
+// deadlineHook records the deadline of the context of its before stage
+type deadlineHook struct {
+	openfeature.UnimplementedHook
+	deadlines chan time.Time
+}
+
+func (h deadlineHook) Before(ctx context.Context, _ openfeature.HookContext, _ openfeature.HookHints) (*openfeature.EvaluationContext, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil, errors.New("no deadline")
+	}
+	h.deadlines <- deadline
+	return nil, nil
+}
+
+func TestFakeClock_Deadlines(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(now)
+	defer openfeature.WithClock(clock)()
+
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), openfeature.NoopProvider{}); err != nil {
+		t.Fatal(err)
+	}
+	hook := deadlineHook{deadlines: make(chan time.Time, 1)}
+	client := openfeature.NewClient(t.Name())
+	if _, err := client.BooleanValue(context.Background(), "boolFlag", false, openfeature.EvaluationContext{},
+		openfeature.WithHooks(hook), openfeature.WithHookTimeout(time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deadline := <-hook.deadlines; !deadline.Equal(now.Add(time.Second)) {
+		t.Errorf("expected the deadline to follow the clock, %s, got %s", now.Add(time.Second), deadline)
+	}
+}
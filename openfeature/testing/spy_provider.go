@@ -0,0 +1,224 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// SpyCall is an evaluation recorded by a SpyProvider
+type SpyCall struct {
+	Flag         string
+	Type         openfeature.Type
+	DefaultValue interface{}
+	// FlattenedContext is a copy of the flattened evaluation context given to the provider
+	FlattenedContext openfeature.FlattenedContext
+}
+
+// SpyProvider is a provider recording every evaluation, to assert how the code under test evaluates flags. Its
+// responses are programmed per flag with RespondWith, Respond and FailWith, evaluations of other flags fail with a
+// FLAG_NOT_FOUND error.
+//
+// Unlike TestProvider, a SpyProvider is not scoped to a test: create a SpyProvider per test and set it as the provider
+// of the domain of the test, e.g. with openfeature.SetNamedProviderAndWait(t.Name(), spy).
+type SpyProvider struct {
+	mu        sync.Mutex
+	responses map[string]openfeature.InterfaceResolutionDetail
+	calls     []SpyCall
+	initErr   error
+	inits     int
+	shutdowns int
+}
+
+// interface guard to ensure that SpyProvider records its initialization and shutdown
+var _ openfeature.StateHandler = (*SpyProvider)(nil)
+
+// NewSpyProvider creates a SpyProvider without programmed responses
+func NewSpyProvider() *SpyProvider {
+	return &SpyProvider{responses: map[string]openfeature.InterfaceResolutionDetail{}}
+}
+
+// RespondWith programs the evaluations of the flag to resolve to the value with the STATIC reason. Values of integer
+// flags are given as int or int64.
+func (p *SpyProvider) RespondWith(flag string, value interface{}) {
+	p.Respond(flag, openfeature.InterfaceResolutionDetail{
+		Value:                    value,
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason},
+	})
+}
+
+// Respond programs the evaluations of the flag to return the resolution, e.g. to set its variant and metadata
+func (p *SpyProvider) Respond(flag string, resolution openfeature.InterfaceResolutionDetail) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.responses[flag] = resolution
+}
+
+// FailWith programs the evaluations of the flag to fail with the error, resolving to the default value
+func (p *SpyProvider) FailWith(flag string, err openfeature.ResolutionError) {
+	p.Respond(flag, openfeature.InterfaceResolutionDetail{
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+			ResolutionError: err,
+			Reason:          openfeature.ErrorReason,
+		},
+	})
+}
+
+// FailInitWith programs the initialization of the provider to fail with the error, nil to succeed
+func (p *SpyProvider) FailInitWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.initErr = err
+}
+
+// Calls returns the evaluations recorded, in order
+func (p *SpyProvider) Calls() []SpyCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	calls := make([]SpyCall, len(p.calls))
+	copy(calls, p.calls)
+	return calls
+}
+
+// CallsFor returns the evaluations of the flag recorded, in order
+func (p *SpyProvider) CallsFor(flag string) []SpyCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var calls []SpyCall
+	for _, call := range p.calls {
+		if call.Flag == flag {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Inits returns the number of initializations of the provider
+func (p *SpyProvider) Inits() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inits
+}
+
+// Shutdowns returns the number of shutdowns of the provider
+func (p *SpyProvider) Shutdowns() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.shutdowns
+}
+
+// Reset forgets the recorded evaluations, initializations and shutdowns, keeping the programmed responses
+func (p *SpyProvider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls, p.inits, p.shutdowns = nil, 0, 0
+}
+
+func (p *SpyProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: "SpyProvider"}
+}
+
+func (p *SpyProvider) Hooks() []openfeature.Hook {
+	return []openfeature.Hook{}
+}
+
+func (p *SpyProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inits++
+	return p.initErr
+}
+
+func (p *SpyProvider) Shutdown() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.shutdowns++
+}
+
+func (p *SpyProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, flCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	resolution := p.resolve(flag, openfeature.Boolean, defaultValue, flCtx)
+	value, ok := resolution.Value.(bool)
+	if !ok {
+		return openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, openfeature.Boolean, resolution)}
+	}
+	return openfeature.BoolResolutionDetail{Value: value, ProviderResolutionDetail: resolution.ProviderResolutionDetail}
+}
+
+func (p *SpyProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, flCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	resolution := p.resolve(flag, openfeature.String, defaultValue, flCtx)
+	value, ok := resolution.Value.(string)
+	if !ok {
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, openfeature.String, resolution)}
+	}
+	return openfeature.StringResolutionDetail{Value: value, ProviderResolutionDetail: resolution.ProviderResolutionDetail}
+}
+
+func (p *SpyProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, flCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	resolution := p.resolve(flag, openfeature.Float, defaultValue, flCtx)
+	value, ok := resolution.Value.(float64)
+	if !ok {
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, openfeature.Float, resolution)}
+	}
+	return openfeature.FloatResolutionDetail{Value: value, ProviderResolutionDetail: resolution.ProviderResolutionDetail}
+}
+
+func (p *SpyProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, flCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	resolution := p.resolve(flag, openfeature.Int, defaultValue, flCtx)
+	var value int64
+	switch v := resolution.Value.(type) {
+	case int64:
+		value = v
+	case int:
+		value = int64(v)
+	default:
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, openfeature.Int, resolution)}
+	}
+	return openfeature.IntResolutionDetail{Value: value, ProviderResolutionDetail: resolution.ProviderResolutionDetail}
+}
+
+func (p *SpyProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, flCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return p.resolve(flag, openfeature.Object, defaultValue, flCtx)
+}
+
+// resolve records the evaluation and returns the response programmed for the flag, resolving failed evaluations to the
+// default value
+func (p *SpyProvider) resolve(flag string, flagType openfeature.Type, defaultValue interface{}, flCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	recorded := make(openfeature.FlattenedContext, len(flCtx))
+	for key, value := range flCtx {
+		recorded[key] = value
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, SpyCall{Flag: flag, Type: flagType, DefaultValue: defaultValue, FlattenedContext: recorded})
+
+	resolution, ok := p.responses[flag]
+	if !ok {
+		return openfeature.InterfaceResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewFlagNotFoundResolutionError(fmt.Sprintf("flag %s has no programmed response", flag)),
+				Reason:          openfeature.ErrorReason,
+			},
+		}
+	}
+	if resolution.Error() != nil {
+		resolution.Value = defaultValue
+	}
+	return resolution
+}
+
+// typeMismatch returns the details of a resolution of the flag failing with a TYPE_MISMATCH error, unless the
+// resolution failed already
+func typeMismatch(flag string, flagType openfeature.Type, resolution openfeature.InterfaceResolutionDetail) openfeature.ProviderResolutionDetail {
+	if resolution.Error() != nil {
+		return resolution.ProviderResolutionDetail
+	}
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewTypeMismatchResolutionError(
+			fmt.Sprintf("programmed value %v (%T) of flag %s is not of type %s", resolution.Value, resolution.Value, flag, flagType)),
+		Reason: openfeature.ErrorReason,
+	}
+}
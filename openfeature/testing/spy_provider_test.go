@@ -0,0 +1,117 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestSpyProvider(t *testing.T) {
+	spy := NewSpyProvider()
+	spy.RespondWith("enabled", true)
+	spy.RespondWith("limit", 10)
+	spy.Respond("color", openfeature.InterfaceResolutionDetail{
+		Value:                    "blue",
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{Reason: openfeature.TargetingMatchReason, Variant: "b"},
+	})
+	spy.FailWith("broken", openfeature.NewParseErrorResolutionError("malformed flag"))
+
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), spy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := openfeature.NewClient(t.Name())
+	evalCtx := openfeature.NewEvaluationContext("user-1", map[string]interface{}{"plan": "pro"})
+	ctx := context.Background()
+
+	t.Run("programmed responses", func(t *testing.T) {
+		if value, err := client.BooleanValue(ctx, "enabled", false, evalCtx); err != nil || !value {
+			t.Errorf("expected the programmed value, got %v, %v", value, err)
+		}
+		if value, err := client.IntValue(ctx, "limit", 1, evalCtx); err != nil || value != 10 {
+			t.Errorf("expected the programmed value, got %v, %v", value, err)
+		}
+		details, err := client.StringValueDetails(ctx, "color", "red", evalCtx)
+		if err != nil || details.Value != "blue" || details.Variant != "b" || details.Reason != openfeature.TargetingMatchReason {
+			t.Errorf("expected the programmed resolution, got %+v, %v", details, err)
+		}
+	})
+
+	t.Run("error injection", func(t *testing.T) {
+		details, err := client.StringValueDetails(ctx, "broken", "default", evalCtx)
+		if details.Value != "default" || details.ErrorCode != openfeature.ParseErrorCode {
+			t.Errorf("expected the default value with the injected error, got %+v", details)
+		}
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("unprogrammed and mistyped flags fail", func(t *testing.T) {
+		if details, _ := client.BooleanValueDetails(ctx, "missing", true, evalCtx); details.ErrorCode != openfeature.FlagNotFoundCode || !details.Value {
+			t.Errorf("expected the default value with a FLAG_NOT_FOUND error, got %+v", details)
+		}
+		if details, _ := client.StringValueDetails(ctx, "enabled", "off", evalCtx); details.ErrorCode != openfeature.TypeMismatchCode || details.Value != "off" {
+			t.Errorf("expected the default value with a TYPE_MISMATCH error, got %+v", details)
+		}
+	})
+
+	t.Run("calls are recorded", func(t *testing.T) {
+		spy.Reset()
+		if _, err := client.FloatValue(ctx, "ratio", 0.5, evalCtx); err == nil {
+			t.Error("expected an error for an unprogrammed flag")
+		}
+		_, _ = client.BooleanValue(ctx, "enabled", false, openfeature.EvaluationContext{})
+
+		calls := spy.Calls()
+		expected := []SpyCall{
+			{
+				Flag:             "ratio",
+				Type:             openfeature.Float,
+				DefaultValue:     0.5,
+				FlattenedContext: openfeature.FlattenedContext{openfeature.TargetingKey: "user-1", "plan": "pro"},
+			},
+			{Flag: "enabled", Type: openfeature.Boolean, DefaultValue: false, FlattenedContext: openfeature.FlattenedContext{}},
+		}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("expected calls %+v, got %+v", expected, calls)
+		}
+		if enabled := spy.CallsFor("enabled"); len(enabled) != 1 || enabled[0].Type != openfeature.Boolean {
+			t.Errorf("expected a single evaluation of the flag, got %+v", enabled)
+		}
+	})
+}
+
+func TestSpyProviderLifecycle(t *testing.T) {
+	t.Run("initialization errors are injected", func(t *testing.T) {
+		spy := NewSpyProvider()
+		spy.FailInitWith(errors.New("not configured"))
+		if err := openfeature.SetNamedProviderAndWait(t.Name(), spy); err == nil {
+			t.Error("expected the programmed initialization error")
+		}
+		if spy.Inits() != 1 {
+			t.Errorf("expected a single initialization, got %d", spy.Inits())
+		}
+	})
+
+	t.Run("shutdowns are recorded", func(t *testing.T) {
+		spy := NewSpyProvider()
+		if err := openfeature.SetNamedProviderAndWait(t.Name(), spy); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := openfeature.SetNamedProviderAndWait(t.Name(), NewSpyProvider()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// the SDK shuts down replaced providers in the background
+		deadline := time.Now().Add(time.Second)
+		for spy.Shutdowns() == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if spy.Inits() != 1 || spy.Shutdowns() != 1 {
+			t.Errorf("expected a single initialization and shutdown, got %d and %d", spy.Inits(), spy.Shutdowns())
+		}
+	})
+}
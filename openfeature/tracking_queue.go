@@ -0,0 +1,130 @@
+package openfeature
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// defaultTrackingWorkers is the number of workers of the tracking queue if TrackingQueueConfig.Workers is not set
+	defaultTrackingWorkers = 1
+	// defaultTrackingQueueSize bounds the tracking queue if TrackingQueueConfig.Size is not set
+	defaultTrackingQueueSize = 100
+)
+
+// TrackingOverflow is the behavior of Client.TrackAsync when the tracking queue is full
+type TrackingOverflow int
+
+const (
+	// DropOnOverflow drops the tracking events which do not fit in the queue, which is the default
+	DropOnOverflow TrackingOverflow = iota
+	// BlockOnOverflow blocks the caller until the tracking event fits in the queue, or its context is done
+	BlockOnOverflow
+)
+
+// TrackingQueueConfig configures the queue of the tracking events of Client.TrackAsync, see SetTrackingQueueConfig
+type TrackingQueueConfig struct {
+	// Workers is the number of tracking events handed to providers concurrently. Defaults to 1.
+	Workers int
+	// Size bounds the number of queued tracking events. Defaults to 100.
+	Size int
+	// Overflow is the behavior when the queue is full. Defaults to DropOnOverflow.
+	Overflow TrackingOverflow
+}
+
+// trackingQueue hands tracking events to the providers from a bounded worker pool
+type trackingQueue struct {
+	config TrackingQueueConfig
+	jobs   chan func()
+	wg     sync.WaitGroup
+	// closing is closed once the queue is drained, releasing the producers blocked on a full queue
+	closing chan struct{}
+	// drained is closed once the queued jobs completed
+	drained chan struct{}
+	// senders tracks the producers sending to the jobs channel, which is closed once they all returned
+	senders sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newTrackingQueue(config TrackingQueueConfig) *trackingQueue {
+	if config.Workers <= 0 {
+		config.Workers = defaultTrackingWorkers
+	}
+	if config.Size <= 0 {
+		config.Size = defaultTrackingQueueSize
+	}
+
+	q := &trackingQueue{
+		config:  config,
+		jobs:    make(chan func(), config.Size),
+		closing: make(chan struct{}),
+		drained: make(chan struct{}),
+	}
+	q.wg.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go func() {
+			defer q.wg.Done()
+			for job := range q.jobs {
+				job()
+			}
+		}()
+	}
+	return q
+}
+
+// enqueue queues the job, reporting whether it was queued. Jobs are dropped once the queue is drained, or if the queue
+// is full and either overflow drops jobs, the context is done or the queue is drained first.
+func (q *trackingQueue) enqueue(ctx context.Context, job func()) bool {
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return false
+	}
+	q.senders.Add(1)
+	q.mu.RUnlock()
+	defer q.senders.Done()
+
+	if q.config.Overflow == BlockOnOverflow {
+		select {
+		case q.jobs <- job:
+			return true
+		case <-q.closing:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+	select {
+	case q.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+// drain stops accepting jobs and waits for the queued jobs to complete, returning an error if the context is done first
+func (q *trackingQueue) drain(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.closing)
+		go func() {
+			// the jobs channel is closed once no producer can send to it anymore
+			q.senders.Wait()
+			close(q.jobs)
+			q.wg.Wait()
+			close(q.drained)
+		}()
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("tracking queue did not drain in time, %d tracking events pending: %w", len(q.jobs), ctx.Err())
+	}
+}